@@ -0,0 +1,331 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package agent
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrNoAgents     = errors.New("no agents online")
+	ErrUnknownAgent = errors.New("unknown agent")
+)
+
+const (
+	// pollTimeout is how long a poll request waits for work
+	pollTimeout = 25 * time.Second
+
+	// offlineAfter is how long an agent may stay silent before its shard is requeued
+	offlineAfter = 2 * time.Minute
+)
+
+// ResultHandler receives results reported by an agent for a job
+// done is set on the final report of a shard
+type ResultHandler func(agent Info, job JobSpec, results []checker.ProxyResult, done bool)
+
+// Coordinator hands out job shards to registered agents and collects their results
+type Coordinator struct {
+	mutex     sync.Mutex
+	agents    map[string]*Info
+	queue     []*Shard
+	assigned  map[string]*Shard
+	wake      chan struct{}
+	onResults ResultHandler
+	onLog     func(string)
+}
+
+// NewCoordinator creates a coordinator delivering results to onResults
+func NewCoordinator(onResults ResultHandler, onLog func(string)) *Coordinator {
+	return &Coordinator{
+		agents:    make(map[string]*Info),
+		assigned:  make(map[string]*Shard),
+		wake:      make(chan struct{}),
+		onResults: onResults,
+		onLog:     onLog,
+	}
+}
+
+// Agents returns the registered agents sorted by name
+func (c *Coordinator) Agents() []Info {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	agents := make([]Info, 0, len(c.agents))
+	for _, a := range c.agents {
+		info := *a
+		info.Online = time.Since(a.LastSeen) < offlineAfter
+		agents = append(agents, info)
+	}
+
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Name < agents[j].Name })
+	return agents
+}
+
+// OnlineAgents returns the IDs of agents seen recently
+func (c *Coordinator) OnlineAgents() []string {
+	var ids []string
+	for _, a := range c.Agents() {
+		if a.Online {
+			ids = append(ids, a.ID)
+		}
+	}
+	return ids
+}
+
+// Pending returns the number of shards waiting for or being processed by an agent
+func (c *Coordinator) Pending() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.queue) + len(c.assigned)
+}
+
+// Submit splits proxies into shards of at most shardSize and queues them for any agent
+// Returns the number of shards created
+func (c *Coordinator) Submit(job JobSpec, proxies []string, shardSize int) int {
+	if shardSize <= 0 {
+		shardSize = 500
+	}
+
+	c.mutex.Lock()
+	count := 0
+	for start := 0; start < len(proxies); start += shardSize {
+		end := start + shardSize
+		if end > len(proxies) {
+			end = len(proxies)
+		}
+		c.queue = append(c.queue, &Shard{ID: newID(), Job: job, Proxies: proxies[start:end]})
+		count++
+	}
+	c.notifyLocked()
+	c.mutex.Unlock()
+
+	return count
+}
+
+// SubmitTo queues the whole proxy list for one specific agent
+func (c *Coordinator) SubmitTo(agentID string, job JobSpec, proxies []string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.agents[agentID]; !ok {
+		return ErrUnknownAgent
+	}
+
+	c.queue = append(c.queue, &Shard{ID: newID(), Job: job, Proxies: proxies, target: agentID})
+	c.notifyLocked()
+	return nil
+}
+
+// Cancel drops all queued shards; shards already assigned are left to finish
+func (c *Coordinator) Cancel() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.queue = nil
+}
+
+// ServeHTTP implements the agent protocol
+func (c *Coordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch r.URL.Path {
+	case PathRegister:
+		c.handleRegister(w, r)
+	case PathPoll:
+		c.handlePoll(w, r)
+	case PathResults:
+		c.handleResults(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleRegister registers a new agent
+func (c *Coordinator) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	info := &Info{
+		ID:           newID(),
+		Name:         req.Name,
+		RemoteAddr:   r.RemoteAddr,
+		RegisteredAt: now,
+		LastSeen:     now,
+	}
+	if info.Name == "" {
+		info.Name = r.RemoteAddr
+	}
+
+	c.mutex.Lock()
+	c.agents[info.ID] = info
+	c.mutex.Unlock()
+
+	c.log("Agent registered: " + info.Name + " (" + info.RemoteAddr + ")")
+	writeJSON(w, RegisterResponse{ID: info.ID})
+}
+
+// handlePoll hands the next shard to an agent, waiting up to pollTimeout for work
+func (c *Coordinator) handlePoll(w http.ResponseWriter, r *http.Request) {
+	var req PollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deadline := time.NewTimer(pollTimeout)
+	defer deadline.Stop()
+
+	for {
+		shard, wake, err := c.next(req.AgentID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if shard != nil {
+			writeJSON(w, shard)
+			return
+		}
+
+		select {
+		case <-wake:
+		case <-deadline.C:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// next assigns a queued shard to an agent, or returns a channel closed when work arrives
+func (c *Coordinator) next(agentID string) (*Shard, <-chan struct{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	agent, ok := c.agents[agentID]
+	if !ok {
+		return nil, nil, ErrUnknownAgent
+	}
+	agent.LastSeen = time.Now()
+
+	c.requeueStaleLocked()
+
+	for i, shard := range c.queue {
+		if shard.target != "" && shard.target != agentID {
+			continue
+		}
+
+		c.queue = append(c.queue[:i], c.queue[i+1:]...)
+		shard.agentID = agentID
+		shard.assignedAt = time.Now()
+		c.assigned[shard.ID] = shard
+		agent.ActiveShard = shard.ID
+		return shard, nil, nil
+	}
+
+	return nil, c.wake, nil
+}
+
+// requeueStaleLocked puts shards of agents that went silent back in the queue
+func (c *Coordinator) requeueStaleLocked() {
+	for id, shard := range c.assigned {
+		agent, ok := c.agents[shard.agentID]
+		if ok && time.Since(agent.LastSeen) < offlineAfter {
+			continue
+		}
+
+		delete(c.assigned, id)
+		if ok {
+			agent.ActiveShard = ""
+		}
+		// Shards pinned to a lost agent cannot be moved elsewhere
+		if shard.target == "" {
+			c.queue = append(c.queue, shard)
+		}
+	}
+}
+
+// handleResults records results reported by an agent
+func (c *Coordinator) handleResults(w http.ResponseWriter, r *http.Request) {
+	var req ResultsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mutex.Lock()
+	agent, ok := c.agents[req.AgentID]
+	if !ok {
+		c.mutex.Unlock()
+		http.Error(w, ErrUnknownAgent.Error(), http.StatusNotFound)
+		return
+	}
+
+	var job JobSpec
+	if shard, ok := c.assigned[req.ShardID]; ok {
+		job = shard.Job
+	}
+
+	agent.LastSeen = time.Now()
+	agent.Results += len(req.Results)
+	if req.Done {
+		delete(c.assigned, req.ShardID)
+		agent.ActiveShard = ""
+		agent.ShardsDone++
+	}
+	info := *agent
+	c.mutex.Unlock()
+
+	if (len(req.Results) > 0 || req.Done) && c.onResults != nil {
+		c.onResults(info, job, req.Results, req.Done)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// notifyLocked wakes up agents waiting for work
+func (c *Coordinator) notifyLocked() {
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
+
+// log forwards a message to the log callback
+func (c *Coordinator) log(msg string) {
+	if c.onLog != nil {
+		c.onLog(msg)
+	}
+}
+
+// newID returns a random identifier
+func newID() string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,87 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package agent implements distributed checking with remote headless workers.
+//
+// Agents register with the coordinator running inside the GUI, long-poll for
+// job shards, check them locally and stream results back. All requests are
+// authenticated with the control API bearer token.
+package agent
+
+import (
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Protocol paths, served under the control API
+const (
+	PathRegister = "/v1/agents/register"
+	PathPoll     = "/v1/agents/poll"
+	PathResults  = "/v1/agents/results"
+)
+
+// JobSpec describes how the proxies of a shard must be checked
+type JobSpec struct {
+	ProxyType     checker.ProxyType `json:"proxyType"`
+	Endpoint      string            `json:"endpoint"`
+	Threads       int               `json:"threads"`
+	UpstreamProxy string            `json:"upstreamProxy,omitempty"`
+	UpstreamType  checker.ProxyType `json:"upstreamType,omitempty"`
+
+	// Comparison identifies the vantage comparison a shard belongs to (empty for regular runs)
+	Comparison string `json:"comparison,omitempty"`
+}
+
+// Shard is a slice of a job assigned to a single agent
+type Shard struct {
+	ID      string   `json:"id"`
+	Job     JobSpec  `json:"job"`
+	Proxies []string `json:"proxies"`
+
+	// target restricts the shard to one agent (empty for any agent)
+	target     string
+	agentID    string
+	assignedAt time.Time
+}
+
+// RegisterRequest is sent by an agent when it starts
+type RegisterRequest struct {
+	Name string `json:"name"`
+}
+
+// RegisterResponse carries the ID assigned to a registered agent
+type RegisterResponse struct {
+	ID string `json:"id"`
+}
+
+// PollRequest asks the coordinator for work
+type PollRequest struct {
+	AgentID string `json:"agentId"`
+}
+
+// ResultsRequest delivers results of a shard; Done marks the shard as finished
+type ResultsRequest struct {
+	AgentID string                `json:"agentId"`
+	ShardID string                `json:"shardId"`
+	Results []checker.ProxyResult `json:"results"`
+	Done    bool                  `json:"done"`
+}
+
+// Info describes a registered agent
+type Info struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	RemoteAddr   string    `json:"remoteAddr"`
+	RegisteredAt time.Time `json:"registeredAt"`
+	LastSeen     time.Time `json:"lastSeen"`
+	ActiveShard  string    `json:"activeShard,omitempty"`
+	ShardsDone   int       `json:"shardsDone"`
+	Results      int       `json:"results"`
+	Online       bool      `json:"online"`
+}
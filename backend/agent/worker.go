@@ -0,0 +1,191 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// resultFlushInterval is how often partial results are sent to the coordinator
+const resultFlushInterval = 2 * time.Second
+
+// Worker is a headless agent checking shards handed out by a coordinator
+type Worker struct {
+	// Coordinator is the control API root of the GUI, e.g. http://10.0.0.5:8766
+	Coordinator string
+
+	// Token is the control API token
+	Token string
+
+	// Name identifies the agent in the GUI
+	Name string
+
+	// Log receives progress messages; may be nil
+	Log func(string)
+
+	// OnResult receives every result as soon as it completes, before it is sent to the
+	// coordinator; may be nil
+	OnResult func(checker.ProxyResult)
+
+	id     string
+	client *http.Client
+}
+
+// Run registers with the coordinator and processes shards until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) error {
+	w.client = &http.Client{Timeout: pollTimeout + 10*time.Second}
+	w.Coordinator = strings.TrimRight(w.Coordinator, "/")
+
+	var reg RegisterResponse
+	if err := w.post(ctx, PathRegister, RegisterRequest{Name: w.Name}, &reg); err != nil {
+		return fmt.Errorf("failed to register with coordinator: %w", err)
+	}
+	w.id = reg.ID
+	w.log("Registered with coordinator as " + w.id)
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var shard Shard
+		err := w.post(ctx, PathPoll, PollRequest{AgentID: w.id}, &shard)
+		if err == errNoContent {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.log("Poll failed: " + err.Error())
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+		w.log(fmt.Sprintf("Received shard %s with %d proxies", shard.ID, len(shard.Proxies)))
+		if err := w.runShard(ctx, &shard); err != nil {
+			w.log("Shard " + shard.ID + " failed: " + err.Error())
+		}
+	}
+}
+
+// runShard checks a shard with a local manager, streaming results back as they complete
+func (w *Worker) runShard(ctx context.Context, shard *Shard) error {
+	manager := checker.NewManager()
+	done := make(chan struct{})
+	manager.SetCompletionHandler(func() { close(done) })
+	if w.OnResult != nil {
+		manager.SetResultHandler(w.OnResult)
+	}
+
+	manager.Start(checker.ProxyCheckRequest{
+		ProxyList:     shard.Proxies,
+		ProxyType:     shard.Job.ProxyType,
+		Endpoint:      shard.Job.Endpoint,
+		Threads:       shard.Job.Threads,
+		UpstreamProxy: shard.Job.UpstreamProxy,
+		UpstreamType:  shard.Job.UpstreamType,
+	}, func(string) {}, func() {})
+
+	ticker := time.NewTicker(resultFlushInterval)
+	defer ticker.Stop()
+
+	sent := 0
+	for {
+		select {
+		case <-ctx.Done():
+			manager.Stop(true)
+			return ctx.Err()
+		case <-ticker.C:
+			results := manager.GetResults()
+			if err := w.sendResults(ctx, shard.ID, results[sent:], false); err != nil {
+				w.log("Failed to send results: " + err.Error())
+				continue
+			}
+			sent = len(results)
+		case <-done:
+			results := manager.GetResults()
+			return w.sendResults(ctx, shard.ID, results[sent:], true)
+		}
+	}
+}
+
+// sendResults reports results of a shard to the coordinator
+func (w *Worker) sendResults(ctx context.Context, shardID string, results []checker.ProxyResult, done bool) error {
+	if len(results) == 0 && !done {
+		return nil
+	}
+
+	return w.post(ctx, PathResults, ResultsRequest{
+		AgentID: w.id,
+		ShardID: shardID,
+		Results: results,
+		Done:    done,
+	}, nil)
+}
+
+// errNoContent is returned by post when the coordinator has no work
+var errNoContent = fmt.Errorf("no content")
+
+// post sends an authenticated JSON request and decodes the response into out
+func (w *Worker) post(ctx context.Context, path string, body interface{}, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Coordinator+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+w.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		if out != nil {
+			return errNoContent
+		}
+		return nil
+	case resp.StatusCode != http.StatusOK:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("coordinator returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// log forwards a message to the log callback
+func (w *Worker) log(msg string) {
+	if w.Log != nil {
+		w.Log(msg)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/agent"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// GetAgents returns the remote agents registered with the control API
+func (a *App) GetAgents() []agent.Info {
+	return a.coordinator.Agents()
+}
+
+// StartDistributedCheck splits the proxy list into shards checked by the online agents
+// The control API must be running for agents to connect
+func (a *App) StartDistributedCheck(params CheckParams) string {
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	if len(a.coordinator.OnlineAgents()) == 0 {
+		return "No agents online"
+	}
+
+	if err := a.filterInput(&params); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	a.clampThreads(&params)
+	if err := a.buildCheckRequest(params).Validate(); err != nil {
+		a.emit("log", err.Error())
+		return "Check refused: " + err.Error()
+	}
+
+	if !a.manager.PrepareExternalRun(toCheckRequest(params)) {
+		return "Check already in progress"
+	}
+
+	a.resultsMux.Lock()
+	a.results = make([]ProxyResult, 0, len(params.ProxyList))
+	a.lastParams = params
+	a.resultsMux.Unlock()
+
+	shards := a.coordinator.Submit(toJobSpec(params), params.ProxyList, a.config.GetConfig().AgentShardSize)
+	a.emit("log", fmt.Sprintf("Distributed %d proxies to agents in %d shards", len(params.ProxyList), shards))
+	a.updateStats()
+
+	return "Distributed check started"
+}
+
+// CancelDistributedCheck drops shards not yet picked up by an agent
+func (a *App) CancelDistributedCheck() string {
+	a.coordinator.Cancel()
+	a.emit("log", "Cancelled queued agent shards")
+	return "Distributed check cancelled"
+}
+
+// onAgentResults merges results reported by an agent into the current run
+func (a *App) onAgentResults(info agent.Info, job agent.JobSpec, results []checker.ProxyResult, done bool) {
+	if job.Comparison != "" {
+		a.recordComparison(job.Comparison, info.Name, results, done)
+		return
+	}
+	if len(results) == 0 {
+		return
+	}
+
+	a.resultsMux.Lock()
+	sources := a.lastParams.Sources
+	metadata := a.lastParams.Metadata
+	a.resultsMux.Unlock()
+
+	for i := range results {
+		results[i].Vantage = info.Name
+		if results[i].Source == "" {
+			results[i].Source = sources[results[i].Proxy]
+		}
+		if md, ok := metadata[results[i].Proxy]; ok && results[i].Metadata == nil {
+			results[i].Metadata = &md
+		}
+	}
+
+	a.manager.AppendResults(results)
+	a.updateResults()
+	a.updateStats()
+}
+
+// toJobSpec converts check parameters to an agent job specification
+func toJobSpec(params CheckParams) agent.JobSpec {
+	return agent.JobSpec{
+		ProxyType:     checker.ProxyType(params.ProxyType),
+		Endpoint:      params.Endpoint,
+		Threads:       params.Threads,
+		UpstreamProxy: params.UpstreamProxy,
+		UpstreamType:  checker.ProxyType(params.UpstreamType),
+	}
+}
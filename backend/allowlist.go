@@ -0,0 +1,67 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// AllowlistSettings represents the allowlist configuration
+type AllowlistSettings struct {
+	Enabled   bool     `json:"Enabled"`
+	Ranges    []string `json:"Ranges"`
+	Countries []string `json:"Countries"`
+}
+
+// GetAllowlist returns the current allowlist settings
+func (a *App) GetAllowlist() AllowlistSettings {
+	cfg := a.config.GetConfig()
+	return AllowlistSettings{
+		Enabled:   cfg.AllowlistEnabled,
+		Ranges:    cfg.AllowlistRanges,
+		Countries: cfg.AllowlistCountries,
+	}
+}
+
+// SetAllowlist validates and saves the allowlist settings
+func (a *App) SetAllowlist(settings AllowlistSettings) error {
+	for _, r := range settings.Ranges {
+		if err := checker.ValidatePattern(r); err != nil {
+			return err
+		}
+	}
+
+	if settings.Enabled && len(settings.Countries) > 0 && a.geoDB() == nil {
+		a.emit("log", "No GeoIP database installed: country allowlist will refuse all proxies not matched by a range")
+	}
+
+	return a.config.UpdateAllowlist(settings.Enabled, settings.Ranges, settings.Countries)
+}
+
+// applyAllowlist refuses proxies outside the approved ranges when allowlist mode is enabled
+func (a *App) applyAllowlist(proxies []string) ([]string, error) {
+	cfg := a.config.GetConfig()
+	if !cfg.AllowlistEnabled {
+		return proxies, nil
+	}
+
+	allowlist, err := checker.NewAllowlist(cfg.AllowlistRanges, cfg.AllowlistCountries, a.countryOf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowlist: %w", err)
+	}
+
+	allowed, refused := allowlist.Filter(proxies)
+	if len(refused) > 0 {
+		a.emit("log", fmt.Sprintf("Allowlist mode: refused %d proxies outside approved ranges", len(refused)))
+	}
+
+	return allowed, nil
+}
@@ -12,13 +12,23 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/agent"
 	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
 	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
-	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/control"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/enrich"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/geoip"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/server"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/vault"
 )
 
 // App struct
@@ -28,6 +38,90 @@ type App struct {
 	config     *config.ConfigManager
 	resultsMux sync.Mutex
 	results    []ProxyResult
+	lastParams CheckParams
+	// completedLive holds the live results of the last completed run
+	completedLive []checker.ProxyResult
+	// previous is the last run that checked its whole list, offered for reload
+	previous      *previousRun
+	monitorMux    sync.Mutex
+	monitorStop   chan struct{}
+	liveServer    *server.Server
+	controlAPI    *control.Server
+	coordinator   *agent.Coordinator
+	comparisonMux sync.Mutex
+	comparison    *vantageComparison
+	shardMux      sync.Mutex
+	sharded       *shardedRun
+	// runDone is signalled when a run completes
+	runDone chan struct{}
+	// stability tracks proxy outcomes across runs for scoring
+	stability stabilityTracker
+	// history keeps the checks of every proxy across runs for the detail view
+	history proxyHistory
+	// latency keeps the latency samples of the monitored proxies
+	latency latencySeries
+	// callback is the listener of the round-trip probe, bound to callbackAddr
+	callback     *checker.CallbackListener
+	callbackAddr string
+	callbackMux  sync.Mutex
+	// verified holds the proxies that stayed live over consecutive runs
+	verified verifiedPool
+	// throughput remembers the checks per second of past runs by thread count
+	throughput throughputHistory
+	// quarantine excludes proxies flapping between monitoring cycles from exports
+	quarantine quarantineTracker
+	// runs are the labelled runs started alongside the main run
+	runsMux sync.Mutex
+	runs    map[string]*parallelRun
+	runSeq  int
+	// checkQueue holds checks started while the main run was busy
+	queueMux   sync.Mutex
+	checkQueue []QueuedCheck
+	queueSeq   int
+	events     eventBus
+	// runLog captures the log lines of the current main run
+	runLog    *runLog
+	runLogMux sync.Mutex
+	// viewer is the run bundle open in the read-only viewer
+	viewer    *BundleView
+	viewerMux sync.Mutex
+	// lock protects the stored data behind a PIN
+	lock appLock
+	// audit records the user actions
+	audit auditTrail
+	// logs keeps the recent log lines of the main run for snapshots
+	logs logBuffer
+	// logSearch stores the log lines for QueryLogs
+	logSearch logStore
+	geoMux    sync.Mutex
+	geo       *geoip.DB
+	// geoUpdateStop ends the GeoIP update loop
+	geoUpdateStop chan struct{}
+	// schedulerStop ends the loop refreshing the list subscriptions
+	schedulerStop chan struct{}
+	// subs are the remote list subscriptions
+	subs    []*subscription
+	subsMux sync.Mutex
+	// ptr resolves the reverse DNS names of exit IPs
+	ptr *enrich.PTRResolver
+	// rdap looks up the network registrations of exit IPs
+	rdap *enrich.RDAPClient
+	// typeCache remembers the types detected in Auto mode across runs
+	typeCache *checker.TypeCache
+	// vault holds the encrypted credentials of authenticated proxies
+	vault *vault.Vault
+	// redactor hides sensitive substrings in logs
+	redactor redactor
+	// debug serves the pprof endpoints when enabled
+	debug debugServer
+	// monitorGen identifies the health, network and sleep monitors of the current main run
+	monitorGen atomic.Int64
+	// networkChange is the last network change of the main run not yet handled
+	networkChange *event.NetworkChange
+	networkMux    sync.Mutex
+	// closing is set once the app has started shutting down
+	closing   atomic.Bool
+	closeOnce sync.Once
 }
 
 // ProxyResult represents the result of a proxy check
@@ -37,51 +131,152 @@ type ProxyResult struct {
 	Status     string  `json:"status"`
 	Latency    float64 `json:"latency,omitempty"`
 	OutgoingIP string  `json:"outgoingIp,omitempty"`
-	Geo        string  `json:"geo,omitempty"`
-	Error      string  `json:"error,omitempty"`
+	IPVersion  int     `json:"ipVersion,omitempty"`
+	Hostname   string  `json:"hostname,omitempty"`
+	// DialedIP and ResolvedIPs are the resolution of a proxy given as a hostname, IPChecks
+	// the outcome of checking each resolved address
+	DialedIP    string            `json:"dialedIp,omitempty"`
+	ResolvedIPs []string          `json:"resolvedIps,omitempty"`
+	IPChecks    []checker.IPCheck `json:"ipChecks,omitempty"`
+	Geo         string            `json:"geo,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	ErrorKind   string            `json:"errorKind,omitempty"`
+	// Suspect flags a failure checked around a system sleep
+	Suspect bool `json:"suspect,omitempty"`
+	// Reply is the SOCKS reply code or HTTP status of a proxy that refused the check
+	Reply   *checker.ProtocolReply `json:"reply,omitempty"`
+	Source  string                 `json:"source,omitempty"`
+	Vantage string                 `json:"vantage,omitempty"`
+	// SharedExit is the number of live proxies using the same outgoing IP (0 if unique)
+	SharedExit int `json:"sharedExit,omitempty"`
+	// Score is the quality score of a live proxy (0-100)
+	Score float64 `json:"score,omitempty"`
+	// Provider and Expires (YYYY-MM-DD) come from the purchase metadata of the proxy
+	Provider string `json:"provider,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	// City, Region, Timezone and the coordinates need a city-level GeoIP database
+	City      string  `json:"city,omitempty"`
+	Region    string  `json:"region,omitempty"`
+	Timezone  string  `json:"timezone,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	// Network is the RDAP registration of the outgoing IP
+	Network *checker.NetworkInfo `json:"network,omitempty"`
+	// Probes holds the outcome of the advanced diagnostics
+	Probes *checker.Probes `json:"probes,omitempty"`
+	// ClockSkew (seconds) and StaleResponse come from the Date header of the judge response
+	ClockSkew     int64 `json:"clockSkew,omitempty"`
+	StaleResponse bool  `json:"staleResponse,omitempty"`
+	// Cached flags a judge response served from a caching middlebox
+	Cached bool `json:"cached,omitempty"`
 }
 
 // Stats represents the statistics of proxy checks
 type Stats struct {
-	Total           int            `json:"Total"`
-	Live            int            `json:"Live"`
-	Dead            int            `json:"Dead"`
-	Errors          int            `json:"Errors"`
-	Pending         int            `json:"Pending"`
-	SuccessRate     float64        `json:"SuccessRate"`
-	AverageSpeed    int64          `json:"AverageSpeed"`
-	ChecksPerSecond float64        `json:"ChecksPerSecond"`
-	StartTime       time.Time      `json:"StartTime"`
-	TypeCounts      map[string]int `json:"TypeCounts"`
+	Total           int                            `json:"Total"`
+	Live            int                            `json:"Live"`
+	Slow            int                            `json:"Slow"`
+	Dead            int                            `json:"Dead"`
+	Blocked         int                            `json:"Blocked"`
+	Errors          int                            `json:"Errors"`
+	Aborted         int                            `json:"Aborted"`
+	Pending         int                            `json:"Pending"`
+	SuccessRate     float64                        `json:"SuccessRate"`
+	AverageSpeed    int64                          `json:"AverageSpeed"`
+	ChecksPerSecond float64                        `json:"ChecksPerSecond"`
+	StartTime       time.Time                      `json:"StartTime"`
+	TypeCounts      map[string]int                 `json:"TypeCounts"`
+	SourceStats     map[string]checker.SourceStats `json:"SourceStats"`
+	ErrorKinds      map[string]int                 `json:"ErrorKinds"`
+	// BytesTransferred is the traffic of the checks of the run
+	BytesTransferred int64 `json:"BytesTransferred"`
 }
 
 // CheckParams represents the parameters for a proxy check
 type CheckParams struct {
-	ProxyList     []string `json:"ProxyList"`
-	ProxyType     string   `json:"ProxyType"`
-	Endpoint      string   `json:"Endpoint"`
-	Threads       int      `json:"Threads"`
-	UpstreamProxy string   `json:"UpstreamProxy,omitempty"`
-	UpstreamType  string   `json:"UpstreamType,omitempty"`
+	ProxyList     []string          `json:"ProxyList"`
+	ProxyType     string            `json:"ProxyType"`
+	Endpoint      string            `json:"Endpoint"`
+	Threads       int               `json:"Threads"`
+	UpstreamProxy string            `json:"UpstreamProxy,omitempty"`
+	UpstreamType  string            `json:"UpstreamType,omitempty"`
+	Sources       map[string]string `json:"Sources,omitempty"`
+	Priority      *PriorityParams   `json:"Priority,omitempty"`
+	Order         string            `json:"Order,omitempty"`
+	// Countries restricts the check to proxies located in these ISO country codes
+	Countries []string `json:"Countries,omitempty"`
+	// ExcludeCountries inverts Countries, skipping proxies located in them
+	ExcludeCountries bool `json:"ExcludeCountries,omitempty"`
+	// Metadata is the purchase information of imported proxies (proxy -> metadata)
+	Metadata map[string]checker.Metadata `json:"Metadata,omitempty"`
+	// SourceAddress binds the checks to an IP address or network interface, overriding the configured one
+	SourceAddress string `json:"SourceAddress,omitempty"`
+	// AllowDuplicate starts the check even if an identical run finished recently
+	AllowDuplicate bool `json:"AllowDuplicate,omitempty"`
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{
-		manager: checker.NewManager(),
-		config:  config.GetInstance(),
-		results: make([]ProxyResult, 0),
+	app := &App{
+		manager:   checker.NewManager(),
+		config:    config.GetInstance(),
+		results:   make([]ProxyResult, 0),
+		runDone:   make(chan struct{}, 1),
+		ptr:       enrich.NewPTRResolver(defaultPTRConcurrency, time.Hour),
+		rdap:      enrich.NewRDAPClient(defaultRDAPConcurrency, rdapCacheTTL),
+		typeCache: checker.NewTypeCache(typeCacheTTL),
 	}
+	app.vault = vault.New(filepath.Join(app.config.DataDir(), vaultFile))
+	app.manager.SetCompletionHandler(app.onCheckComplete)
+	app.manager.SetResultHandler(app.resultHandler(MainRunID))
+	app.manager.SetPanicHandler(app.panicHandler(MainRunID))
+	app.liveServer = server.New(app.exportSnapshot)
+	app.liveServer.HandleList("verified", app.GetVerifiedPool)
+	app.controlAPI = control.NewServer(&controlService{app: app})
+	app.coordinator = agent.NewCoordinator(app.onAgentResults, func(msg string) { app.emit("log", msg) })
+	app.controlAPI.Handle("/v1/agents/", app.coordinator)
+	app.controlAPI.Handle("/v1/pools", http.HandlerFunc(app.servePoolHealth))
+	app.controlAPI.Handle("/v1/pools/", http.HandlerFunc(app.servePoolHealth))
+	return app
 }
 
 // Startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
+	// Redact the standard logger output, also when it is redirected to a log file
+	log.SetOutput(&redactingWriter{out: os.Stderr, redact: a.redact})
+
 	// Load configuration
 	if err := a.config.Load(); err != nil {
 		log.Printf("Failed to load config: %v", err)
 	}
+
+	a.loadProfileData()
+
+	// Keep the GeoIP database fresh when a license key is configured
+	a.geoUpdateStop = make(chan struct{})
+	go a.geoUpdateLoop(a.geoUpdateStop)
+
+	// Refresh the list subscriptions when they are due
+	a.schedulerStop = make(chan struct{})
+	go a.schedulerLoop(a.schedulerStop)
+
+	a.startDebugServer()
+
+	// Start the local live list server if enabled
+	if a.config.GetConfig().LiveServerEnabled {
+		if err := a.StartLiveServer(); err != nil {
+			log.Printf("Failed to start live list server: %v", err)
+		}
+	}
+
+	// Start the automation API if enabled
+	if a.config.GetConfig().ControlAPIEnabled {
+		if _, err := a.StartControlAPI(); err != nil {
+			log.Printf("Failed to start control API: %v", err)
+		}
+	}
 }
 
 // Greet returns a greeting for the given name
@@ -90,26 +285,100 @@ func (a *App) Greet(name string) string {
 }
 
 // GetConfig returns the current configuration
+// While the app is locked, only the theme is returned over the default settings
 func (a *App) GetConfig() config.Config {
-	return a.config.GetConfig()
+	cfg := a.config.GetConfig()
+	if a.lock.isLocked() {
+		locked := config.DefaultConfig()
+		locked.Theme = cfg.Theme
+		return *locked
+	}
+	return cfg
 }
 
 // UpdateConfig updates the configuration
 func (a *App) UpdateConfig(cfg config.Config) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
 	return a.config.UpdateConfig(func(c *config.Config) {
 		*c = cfg
 	})
 }
 
 // StartCheck starts checking proxies with the given parameters
-func (a *App) StartCheck(params CheckParams) string {
+func (a *App) StartCheck(params CheckParams) (outcome string) {
+	defer func() { a.recordAudit("start", checkDetails(params), outcome) }()
+
+	if a.closing.Load() {
+		return "Check refused: application is shutting down"
+	}
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	if a.shardedActive() {
+		return "Check refused: " + ErrShardedRunActive.Error()
+	}
+
+	// Drop blocklisted, out-of-scope and unwanted-country proxies before anything is queued
+	if err := a.filterInput(&params); err != nil {
+		a.emit("log", err.Error())
+		return "Check refused: " + err.Error()
+	}
+
+	// Enforce the thread limit, then report invalid parameters before anything is started
+	a.clampThreads(&params)
+	if err := a.buildCheckRequest(params).Validate(); err != nil {
+		a.emit("log", err.Error())
+		return "Check refused: " + err.Error()
+	}
+
+	// Offer the results of an identical recent run instead of checking the list again
+	if dup := a.duplicateRun(params); dup != nil {
+		a.emit("duplicate-run", dup)
+		return fmt.Sprintf("Identical run finished %s ago: reload its results or start again to recheck",
+			time.Since(dup.Finished).Round(time.Second))
+	}
+
+	// Queue the check instead of refusing it while another one is running
+	if a.manager.IsRunning() {
+		if !a.config.GetConfig().QueueChecks {
+			return "Check already in progress"
+		}
+		position := a.enqueueCheck(params)
+		a.emit("log", fmt.Sprintf("Check queued at position %d", position))
+		return fmt.Sprintf("Check queued (position %d)", position)
+	}
+
+	return a.startCheck(params)
+}
+
+// checkStarted is the outcome of a check that started
+const checkStarted = "Check started"
+
+// startCheck starts a check of an already filtered proxy list
+func (a *App) startCheck(params CheckParams) string {
+	return a.launchCheck(params, a.buildCheckRequest(params), a.manager.TryStart,
+		fmt.Sprintf("Starting check with %d proxies, type: %s, threads: %d",
+			len(params.ProxyList), params.ProxyType, params.Threads))
+}
+
+// launchCheck starts checkRequest on the main manager through start and sets up what
+// every main run needs: the run log, fresh results, the round-trip callback and the
+// health, network, sleep and CPU watchers. message is the first line of the run log
+func (a *App) launchCheck(params CheckParams, checkRequest checker.ProxyCheckRequest,
+	start func(req checker.ProxyCheckRequest, logCb func(string), updateCb func()) bool, message string) string {
+	a.beginRunLog()
+
 	// Log the start of the check
-	runtime.EventsEmit(a.ctx, "log", fmt.Sprintf("Starting check with %d proxies, type: %s, threads: %d",
-		len(params.ProxyList), params.ProxyType, params.Threads))
+	a.emit("log", message)
 
-	// Clear previous results
+	// Clear previous results unless they are merged with the new run
 	a.resultsMux.Lock()
-	a.results = make([]ProxyResult, 0, len(params.ProxyList))
+	if !a.config.GetConfig().MergeRuns {
+		a.results = make([]ProxyResult, 0, len(params.ProxyList))
+	}
+	a.lastParams = params
 	a.resultsMux.Unlock()
 
 	// Update initial stats
@@ -121,56 +390,120 @@ func (a *App) StartCheck(params CheckParams) string {
 		Errors:     0,
 		TypeCounts: make(map[string]int),
 	}
-	runtime.EventsEmit(a.ctx, "stats-update", stats)
+	a.emit("stats-update", stats)
 
-	// Convert parameters to checker.ProxyCheckRequest
-	checkRequest := checker.ProxyCheckRequest{
-		ProxyList:     params.ProxyList,
-		ProxyType:     checker.ProxyType(params.ProxyType),
-		Endpoint:      params.Endpoint,
-		Threads:       params.Threads,
-		UpstreamProxy: params.UpstreamProxy,
-		UpstreamType:  checker.ProxyType(params.UpstreamType),
+	// The round-trip callback listens for the duration of the run
+	if cfg := a.config.GetConfig(); cfg.RoundTripProbe {
+		checkRequest.Probes.Callback = a.roundTripCallback(cfg)
 	}
 
 	// Start the check in the manager
-	go a.manager.Start(checkRequest,
+	started := start(checkRequest,
 		// Log callback
 		func(msg string) {
-			runtime.EventsEmit(a.ctx, "log", msg)
+			a.emit("log", msg)
 		},
 		// Update callback
 		func() {
 			a.updateResults()
 			a.updateStats()
 		})
+	if !started {
+		return "Check already in progress"
+	}
 
 	// Emit check status
-	runtime.EventsEmit(a.ctx, "check-status", "running")
+	a.setRunState(MainRunID, "running")
+	a.networkMux.Lock()
+	a.networkChange = nil
+	a.networkMux.Unlock()
+	generation := a.monitorGen.Add(1)
+	go a.watchHealth(generation, checkRequest)
+	go a.watchNetwork(generation, checkRequest)
+	go a.watchSleep(generation, checkRequest)
+	go a.watchCPU(generation)
+
+	return checkStarted
+}
+
+// toCheckRequest converts check parameters to a checker.ProxyCheckRequest
+func toCheckRequest(params CheckParams) checker.ProxyCheckRequest {
+	return checker.ProxyCheckRequest{
+		ProxyList:     params.ProxyList,
+		ProxyType:     checker.ProxyType(params.ProxyType),
+		Endpoint:      params.Endpoint,
+		Threads:       params.Threads,
+		UpstreamProxy: params.UpstreamProxy,
+		UpstreamType:  checker.ProxyType(params.UpstreamType),
+		Sources:       params.Sources,
+		Metadata:      params.Metadata,
+	}
+}
 
-	return "Check started"
+// buildCheckRequest converts check parameters to a request with the configured
+// queue priorities, ordering and latency threshold applied
+func (a *App) buildCheckRequest(params CheckParams) checker.ProxyCheckRequest {
+	cfg := a.config.GetConfig()
+
+	req := toCheckRequest(params)
+	req.Priorities = a.buildPriorities(params)
+	req.MaxLatency = int64(cfg.MaxAcceptableLatency)
+	req.WatchdogFactor = cfg.WatchdogFactor
+	req.Merge = cfg.MergeRuns
+	if cfg.EnableGeolocation {
+		req.Geolocate = a.geolocate
+	}
+	req.Enrich = a.enrichResult
+	req.Probes = probeOptions(cfg)
+	req.CacheBust = cfg.CacheBust
+	if cfg.TypeCacheEnabled {
+		req.TypeCache = a.typeCache
+	}
+	if cfg.EndpointFailover {
+		req.FallbackEndpoints = cfg.DefaultEndpoints
+		req.FailoverThreshold = cfg.FailoverThreshold
+	}
+	req.SourceAddress = cfg.SourceAddress
+	if a.vault.Unlocked() {
+		req.Credentials = a.vaultCredentials
+	}
+	if params.SourceAddress != "" {
+		req.SourceAddress = params.SourceAddress
+	}
+	req.ExpectedRate = a.throughput.rate(params.Threads)
+	req.ReferenceEndpoint = cfg.ACLReferenceJudge
+	req.CheckEachIP = cfg.CheckEachResolvedIP
+	req.SOCKS4Ident = cfg.SOCKS4Ident
+	req.SOCKS4Idents = cfg.SOCKS4Idents
+	req.BandwidthLimit = int64(cfg.BandwidthLimitMbps * 1000 * 1000 / 8)
+	req.Order = checker.OrderMode(params.Order)
+	if req.Order == "" {
+		req.Order = checker.OrderMode(cfg.QueueOrder)
+	}
+	return req
 }
 
 // PauseCheck pauses the current check
 
 func (a *App) PauseCheck() string {
 	fmt.Println("PauseCheck called")
-	runtime.EventsEmit(a.ctx, "log", "Pausing check...")
+	a.recordAudit("pause", "", "")
+	a.emit("log", "Pausing check...")
 
 	if a.manager == nil || !a.manager.IsRunning() {
-		runtime.EventsEmit(a.ctx, "log", "No check in progress to pause")
+		a.emit("log", "No check in progress to pause")
 		return "No check in progress"
 	}
 
 	/* if a.manager != nil && a.manager.IsRunning() && !a.manager.IsPaused() {
 		// Use ForcePause instead of Pause for immediate effect
 		a.manager.ForcePause()
-		runtime.EventsEmit(a.ctx, "check-status", "paused")
-		runtime.EventsEmit(a.ctx, "log", "Check paused")
+		a.setRunState(MainRunID, "paused")
+		a.emit("log", "Check paused")
 	} */
 
 	if a.manager.IsPaused() {
-		runtime.EventsEmit(a.ctx, "log", "Check is already paused")
+		a.emit("log", "Check is already paused")
 		return "Check already paused"
 	}
 
@@ -192,8 +525,8 @@ func (a *App) PauseCheck() string {
 				totalWorkers = 1 // Prevent division by zero
 			}
 
-			runtime.EventsEmit(a.ctx, "check-status", "pausing")
-			runtime.EventsEmit(a.ctx, "log", fmt.Sprintf("Pausing %d workers...", totalWorkers))
+			a.setRunState(MainRunID, "pausing")
+			a.emit("log", fmt.Sprintf("Pausing %d workers...", totalWorkers))
 
 			// Set a timeout for the pause operation
 			timeoutChan := time.After(5 * time.Second)
@@ -204,14 +537,14 @@ func (a *App) PauseCheck() string {
 				select {
 				case <-timeoutChan:
 					// Timeout reached, force transition to paused state
-					runtime.EventsEmit(a.ctx, "check-status", "paused")
-					runtime.EventsEmit(a.ctx, "log", "Pause timeout reached, forcing paused state")
+					a.setRunState(MainRunID, "paused")
+					a.emit("log", "Pause timeout reached, forcing paused state")
 					return
 				default:
 					pausedWorkers := a.manager.GetPausedWorkerCount()
 
 					// Emit progress event
-					runtime.EventsEmit(a.ctx, "pause-progress", map[string]interface{}{
+					a.emit("pause-progress", map[string]interface{}{
 						"paused":  pausedWorkers,
 						"total":   totalWorkers,
 						"percent": float64(pausedWorkers) / float64(totalWorkers) * 100,
@@ -219,8 +552,8 @@ func (a *App) PauseCheck() string {
 
 					// Check if all workers are paused
 					if pausedWorkers >= totalWorkers && totalWorkers > 0 {
-						runtime.EventsEmit(a.ctx, "check-status", "paused")
-						runtime.EventsEmit(a.ctx, "log", fmt.Sprintf("Check paused - all %d workers stopped", pausedWorkers))
+						a.setRunState(MainRunID, "paused")
+						a.emit("log", fmt.Sprintf("Check paused - all %d workers stopped", pausedWorkers))
 						return
 					}
 
@@ -230,8 +563,8 @@ func (a *App) PauseCheck() string {
 			}
 
 			// If we get here, we've exceeded maxAttempts without all workers pausing
-			runtime.EventsEmit(a.ctx, "check-status", "paused")
-			runtime.EventsEmit(a.ctx, "log", "Maximum pause attempts reached, forcing paused state")
+			a.setRunState(MainRunID, "paused")
+			a.emit("log", "Maximum pause attempts reached, forcing paused state")
 		}()
 
 		return "Check pausing"
@@ -243,21 +576,22 @@ func (a *App) PauseCheck() string {
 // ResumeCheck resumes the current paused check
 func (a *App) ResumeCheck() string {
 	fmt.Println("ResumeCheck called")
-	runtime.EventsEmit(a.ctx, "log", "Resuming check...")
+	a.recordAudit("resume", "", "")
+	a.emit("log", "Resuming check...")
 
 	if a.manager == nil || !a.manager.IsRunning() {
-		runtime.EventsEmit(a.ctx, "log", "No check in progress to resume")
+		a.emit("log", "No check in progress to resume")
 		return "No check in progress"
 	}
 
 	if !a.manager.IsPaused() {
-		runtime.EventsEmit(a.ctx, "log", "Check is not paused")
+		a.emit("log", "Check is not paused")
 		return "Check not paused"
 	}
 
 	if a.manager.Resume() {
-		runtime.EventsEmit(a.ctx, "check-status", "running")
-		runtime.EventsEmit(a.ctx, "log", "Check resumed")
+		a.setRunState(MainRunID, "running")
+		a.emit("log", "Check resumed")
 		return "Check resumed"
 	}
 
@@ -265,31 +599,36 @@ func (a *App) ResumeCheck() string {
 }
 
 // StopCheck stops the current check gracefully
+// No new proxies are started; the checks in flight finish so the final stats are accurate
 func (a *App) StopCheck() string {
 	fmt.Println("StopCheck called")
-	runtime.EventsEmit(a.ctx, "log", "Stopping check gracefully...")
+	a.recordAudit("stop", "", "")
+	a.emit("log", "Stopping check gracefully...")
 	if a.manager != nil {
-		a.manager.Stop(true)
+		a.manager.Stop(false)
 
 	}
-	runtime.EventsEmit(a.ctx, "check-status", "stopped")
-	return "Check stopped"
+	a.setRunState(MainRunID, event.StateStopping)
+	return "Check stopping"
 }
 
 // ForceStopCheck forces the current check to stop immediately
-/* func (a *App) ForceStopCheck() string {
+// The checks in flight are cancelled and recorded as aborted
+func (a *App) ForceStopCheck() string {
 	fmt.Println("ForceStopCheck called")
-	runtime.EventsEmit(a.ctx, "log", "Force stopping check...")
+	a.recordAudit("force-stop", "", "")
+	a.emit("log", "Force stopping check...")
 	if a.manager != nil {
 		a.manager.Stop(true)
 	}
-	runtime.EventsEmit(a.ctx, "check-status", "stopped")
+	a.setRunState(MainRunID, "stopped")
 	return "Check force stopped"
-} */
+}
 
 // ClearResults clears all results and resets the manager
 func (a *App) ClearResults() string {
 	fmt.Println("ClearResults called")
+	a.recordAudit("clear", "", "")
 
 	// Clear the app's results
 	a.resultsMux.Lock()
@@ -307,15 +646,18 @@ func (a *App) ClearResults() string {
 			} else {
 				// Create a new manager instance to effectively clear all results
 				a.manager = checker.NewManager()
+				a.manager.SetCompletionHandler(a.onCheckComplete)
+				a.manager.SetResultHandler(a.resultHandler(MainRunID))
+				a.manager.SetPanicHandler(a.panicHandler(MainRunID))
 			}
 		} else {
-			runtime.EventsEmit(a.ctx, "log", "Cannot clear results while check is running. Stop or pause first.")
+			a.emit("log", "Cannot clear results while check is running. Stop or pause first.")
 		}
 	}
 
 	// Emit events to update the UI
-	runtime.EventsEmit(a.ctx, "results-update", []ProxyResult{})
-	runtime.EventsEmit(a.ctx, "stats-update", Stats{
+	a.emit("results-update", []ProxyResult{})
+	a.emit("stats-update", Stats{
 		Total:      0,
 		Pending:    0,
 		Live:       0,
@@ -329,18 +671,28 @@ func (a *App) ClearResults() string {
 
 // GetWorkingProxies returns a list of working proxies
 func (a *App) GetWorkingProxies() []string {
+	// Collapsed exports need the outgoing IPs of the manager's results
+	if a.config.GetConfig().CollapseDuplicateExits {
+		workingProxies := []string{}
+		for _, r := range a.exportable(a.liveResults(a.manager.GetResults())) {
+			workingProxies = append(workingProxies, r.Proxy)
+		}
+		return workingProxies
+	}
+
 	// First check if we have results in the App struct
 	a.resultsMux.Lock()
 	appResults := a.results
 	a.resultsMux.Unlock()
 
 	workingProxies := []string{}
+	includeSlow := !a.config.GetConfig().ExcludeSlow
 
 	// Check results from the App struct
 	for _, result := range appResults {
 		status := strings.ToLower(result.Status)
 		// Check if the proxy is live/working - check for multiple possible status values
-		if status == "live" || status == "working" || status == "success" {
+		if status == "live" || status == "working" || status == "success" || (includeSlow && status == "slow") {
 			workingProxies = append(workingProxies, result.Proxy)
 		}
 	}
@@ -377,40 +729,93 @@ func (a *App) updateResults() {
 	a.resultsMux.Lock()
 	defer a.resultsMux.Unlock()
 
+	a.results = a.convertResults(managerResults)
+
+	// Emit results update
+	a.emit("results-update", a.results)
+}
+
+// convertResults converts checker results to app results with exit sharing and scores
+func (a *App) convertResults(managerResults []checker.ProxyResult) []ProxyResult {
+	exits := exitCounts(managerResults)
+	weights := a.scoreWeights()
+
 	// Convert checker.ProxyResult to app.ProxyResult
-	a.results = make([]ProxyResult, len(managerResults))
+	results := make([]ProxyResult, len(managerResults))
 	for i, r := range managerResults {
-		a.results[i] = ProxyResult{
-			Proxy:      r.Proxy,
-			Type:       string(r.Type),
-			Status:     string(r.Status),
-			Latency:    float64(r.Latency),
-			OutgoingIP: r.OutgoingIP,
-			Geo:        r.Country,
-			Error:      r.Error,
+		shared := exits[r.OutgoingIP]
+		if shared < 2 || !strings.EqualFold(string(r.Status), string(checker.StatusLive)) {
+			shared = 0
+		}
+		results[i] = ProxyResult{
+			Proxy:         r.Proxy,
+			Type:          string(r.Type),
+			Status:        string(r.Status),
+			Latency:       float64(r.Latency),
+			OutgoingIP:    r.OutgoingIP,
+			IPVersion:     r.IPVersion,
+			Hostname:      r.Hostname,
+			DialedIP:      r.DialedIP,
+			ResolvedIPs:   r.ResolvedIPs,
+			IPChecks:      r.IPChecks,
+			Network:       r.Network,
+			Probes:        r.Probes,
+			ClockSkew:     r.ClockSkew,
+			StaleResponse: r.StaleResponse,
+			Cached:        r.Cached,
+			Geo:           r.Country,
+			Error:         r.Error,
+			ErrorKind:     r.ErrorKind,
+			Reply:         r.Reply,
+			Suspect:       r.Suspect,
+			Source:        r.Source,
+			Vantage:       r.Vantage,
+			SharedExit:    shared,
+			Score:         a.scoreOf(r, weights),
+			City:          r.City,
+			Region:        r.Region,
+			Timezone:      r.Timezone,
+			Latitude:      r.Latitude,
+			Longitude:     r.Longitude,
+		}
+		if md := r.Metadata; md != nil {
+			results[i].Provider = md.Provider
+			if !md.Expires.IsZero() {
+				results[i].Expires = md.Expires.Format("2006-01-02")
+			}
 		}
 	}
 
-	// Emit results update
-	runtime.EventsEmit(a.ctx, "results-update", a.results)
+	return results
 }
 
 // updateStats updates and emits the current stats
 func (a *App) updateStats() {
-	managerStats := a.manager.GetStats()
+	stats := a.manager.GetStats()
+	a.emit("stats-update", convertStats(stats))
+	a.emitTyped(event.NameStatsUpdate, MainRunID, event.StatsUpdate{Stats: stats})
+}
 
+// convertStats converts checker stats to app stats
+func convertStats(managerStats checker.Stats) Stats {
 	// Convert checker.Stats to app.Stats
 	stats := Stats{
-		Total:           managerStats.Total,
-		Live:            managerStats.Live,
-		Dead:            managerStats.Dead,
-		Pending:         managerStats.Pending,
-		Errors:          managerStats.Errors,
-		SuccessRate:     managerStats.SuccessRate,
-		AverageSpeed:    managerStats.AverageSpeed,
-		ChecksPerSecond: managerStats.ChecksPerSecond,
-		StartTime:       managerStats.StartTime,
-		TypeCounts:      make(map[string]int),
+		Total:            managerStats.Total,
+		Live:             managerStats.Live,
+		Slow:             managerStats.Slow,
+		Dead:             managerStats.Dead,
+		Blocked:          managerStats.Blocked,
+		Pending:          managerStats.Pending,
+		Errors:           managerStats.Errors,
+		Aborted:          managerStats.Aborted,
+		SuccessRate:      managerStats.SuccessRate,
+		AverageSpeed:     managerStats.AverageSpeed,
+		ChecksPerSecond:  managerStats.ChecksPerSecond,
+		StartTime:        managerStats.StartTime,
+		TypeCounts:       make(map[string]int),
+		SourceStats:      managerStats.SourceStats,
+		ErrorKinds:       managerStats.ErrorKinds,
+		BytesTransferred: managerStats.BytesTransferred,
 	}
 
 	// Convert type counts
@@ -418,5 +823,5 @@ func (a *App) updateStats() {
 		stats.TypeCounts[string(t)] = count
 	}
 
-	runtime.EventsEmit(a.ctx, "stats-update", stats)
+	return stats
 }
@@ -0,0 +1,221 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/vault"
+)
+
+const (
+	// appLockFile is the file in the data directory holding the hash of the app PIN
+	appLockFile = "app_lock.json"
+
+	// freePINAttempts is the number of wrong PINs accepted before unlocking is delayed
+	freePINAttempts = 5
+
+	// pinLockout is the delay after freePINAttempts wrong PINs, doubled for every
+	// further wrong PIN up to maxPINLockout
+	pinLockout    = 30 * time.Second
+	maxPINLockout = time.Hour
+)
+
+var (
+	ErrAppLocked       = errors.New("application is locked")
+	ErrWrongPIN        = errors.New("wrong PIN")
+	ErrTooManyAttempts = errors.New("too many wrong PINs, try again later")
+)
+
+// AppLockStatus describes the application lock
+type AppLockStatus struct {
+	Enabled bool `json:"enabled"`
+	Locked  bool `json:"locked"`
+	// RetryAfter is the number of seconds before another PIN may be tried
+	RetryAfter int `json:"retryAfter"`
+}
+
+// appLock protects the stored credentials, favorites and history behind a PIN
+type appLock struct {
+	mutex    sync.Mutex
+	pin      *vault.PIN
+	locked   bool
+	failures int
+	retryAt  time.Time
+}
+
+// load reads the PIN hash of the active profile; the app starts locked if there is one
+func (l *appLock) load(path string) error {
+	var pin *vault.PIN
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read app lock: %w", err)
+	default:
+		if err := json.Unmarshal(data, &pin); err != nil {
+			return fmt.Errorf("invalid app lock: %w", err)
+		}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.pin = pin
+	l.locked = pin != nil
+	l.failures = 0
+	l.retryAt = time.Time{}
+	return nil
+}
+
+// isLocked returns true while the PIN has not been entered
+func (l *appLock) isLocked() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.locked
+}
+
+// unlock checks the PIN, delaying further attempts after repeated wrong ones
+func (l *appLock) unlock(pin string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.locked {
+		return nil
+	}
+	if err := l.checkPIN(pin); err != nil {
+		return err
+	}
+
+	l.locked = false
+	return nil
+}
+
+// checkPIN verifies pin against the saved hash, counting wrong attempts towards the
+// lockout shared by every path that accepts the PIN. The caller holds the mutex
+func (l *appLock) checkPIN(pin string) error {
+	if time.Now().Before(l.retryAt) {
+		return ErrTooManyAttempts
+	}
+
+	if !l.pin.Verify(pin) {
+		l.failures++
+		if l.failures >= freePINAttempts {
+			delay := pinLockout * time.Duration(math.Pow(2, float64(l.failures-freePINAttempts)))
+			if delay <= 0 || delay > maxPINLockout {
+				delay = maxPINLockout
+			}
+			l.retryAt = time.Now().Add(delay)
+		}
+		return ErrWrongPIN
+	}
+
+	l.failures = 0
+	l.retryAt = time.Time{}
+	return nil
+}
+
+// appLockPath returns the file the PIN hash is saved to
+func (a *App) appLockPath() string {
+	return filepath.Join(a.config.DataDir(), appLockFile)
+}
+
+// requireUnlocked returns ErrAppLocked while the app is locked
+func (a *App) requireUnlocked() error {
+	if a.lock.isLocked() {
+		return ErrAppLocked
+	}
+	return nil
+}
+
+// GetAppLockStatus returns whether a PIN is set and the app is locked
+func (a *App) GetAppLockStatus() AppLockStatus {
+	a.lock.mutex.Lock()
+	defer a.lock.mutex.Unlock()
+
+	status := AppLockStatus{Enabled: a.lock.pin != nil, Locked: a.lock.locked}
+	if wait := time.Until(a.lock.retryAt); wait > 0 {
+		status.RetryAfter = int(math.Ceil(wait.Seconds()))
+	}
+	return status
+}
+
+// UnlockApp unlocks the app with its PIN
+func (a *App) UnlockApp(pin string) error {
+	if err := a.lock.unlock(pin); err != nil {
+		a.recordAudit("unlock-app", "", err.Error())
+		return err
+	}
+
+	a.recordAudit("unlock-app", "", "unlocked")
+	a.emit("app-lock", false)
+	return nil
+}
+
+// LockApp locks the app and the credential vault until the PIN is entered again
+func (a *App) LockApp() error {
+	a.lock.mutex.Lock()
+	enabled := a.lock.pin != nil
+	a.lock.locked = enabled
+	a.lock.mutex.Unlock()
+	if !enabled {
+		return errors.New("no PIN is set")
+	}
+
+	a.vault.Lock()
+	a.recordAudit("lock-app", "", "")
+	a.emit("app-lock", true)
+	return nil
+}
+
+// SetAppPIN sets, changes or (with an empty pin) removes the PIN locking the app at
+// startup. The current PIN must be given when one is set
+func (a *App) SetAppPIN(current string, pin string) error {
+	a.lock.mutex.Lock()
+	defer a.lock.mutex.Unlock()
+
+	if a.lock.pin != nil {
+		if err := a.lock.checkPIN(current); err != nil {
+			return err
+		}
+	}
+
+	if pin == "" {
+		if err := os.Remove(a.appLockPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		a.lock.pin = nil
+		a.lock.locked = false
+		a.emit("log", "Application PIN removed")
+		return nil
+	}
+
+	hash, err := vault.HashPIN(pin)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(hash)
+	if err != nil {
+		return err
+	}
+	if err := export.WriteFile(a.appLockPath(), data); err != nil {
+		return err
+	}
+	a.lock.pin = hash
+
+	a.emit("log", "Application PIN set")
+	return nil
+}
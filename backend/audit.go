@@ -0,0 +1,155 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// auditFile is the file in the data directory holding the audit trail, one JSON
+	// entry per line
+	auditFile = "audit.jsonl"
+
+	// maxAuditSize is the size after which the audit trail is rotated to auditFile.1
+	maxAuditSize = 5 << 20
+)
+
+// AuditEntry is a user action recorded in the audit trail
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	// Details are the parameters of the action, with secrets redacted
+	Details string `json:"details,omitempty"`
+	// Outcome is the message returned to the user, if any
+	Outcome string `json:"outcome,omitempty"`
+}
+
+// auditTrail appends user actions to a file
+type auditTrail struct {
+	mutex sync.Mutex
+}
+
+// append writes an entry to path, rotating the file when it grows too large
+func (t *auditTrail) append(path string, entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxAuditSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// read returns the entries of path, oldest first; a missing file has none
+func (t *auditTrail) read(path string) ([]AuditEntry, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit trail: %w", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// auditPath returns the file the audit trail is written to
+func (a *App) auditPath() string {
+	return filepath.Join(a.config.DataDir(), auditFile)
+}
+
+// recordAudit adds a user action to the audit trail
+func (a *App) recordAudit(action string, details string, outcome string) {
+	entry := AuditEntry{
+		Time:    time.Now(),
+		Action:  action,
+		Details: a.redact(details),
+		Outcome: a.redact(outcome),
+	}
+	if err := a.audit.append(a.auditPath(), entry); err != nil {
+		log.Printf("Failed to record audit entry: %v", err)
+	}
+}
+
+// checkDetails describes the parameters of a check for the audit trail
+func checkDetails(params CheckParams) string {
+	details := []string{
+		fmt.Sprintf("proxies=%d", len(params.ProxyList)),
+		"type=" + params.ProxyType,
+		"endpoint=" + params.Endpoint,
+		fmt.Sprintf("threads=%d", params.Threads),
+	}
+	if params.UpstreamProxy != "" {
+		details = append(details, "upstream="+params.UpstreamProxy)
+	}
+	if len(params.Countries) > 0 {
+		details = append(details, "countries="+strings.Join(params.Countries, ","))
+	}
+	return strings.Join(details, " ")
+}
+
+// GetAuditTrail returns the most recent user actions, newest first; a limit of 0 returns
+// all of them
+func (a *App) GetAuditTrail(limit int) ([]AuditEntry, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	entries, err := a.audit.read(a.auditPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// defaultBenchmarkSample is the number of known-good proxies a benchmark uses by default
+const defaultBenchmarkSample = 20
+
+// RunJudgeBenchmark checks a sample of known-good proxies, taken from the verified pool and
+// then the live results, against every configured endpoint and returns the endpoints ranked
+// by success rate and latency
+func (a *App) RunJudgeBenchmark(sampleSize int) ([]checker.EndpointBenchmark, error) {
+	if err := a.requireLive(); err != nil {
+		return nil, err
+	}
+	if a.checksRunning() {
+		return nil, errors.New("cannot benchmark while a check is running")
+	}
+	if sampleSize <= 0 {
+		sampleSize = defaultBenchmarkSample
+	}
+
+	types := make(map[string]checker.ProxyType)
+	var params CheckParams
+	candidates := append(a.verified.snapshot(), a.liveSnapshot()...)
+	for _, r := range candidates {
+		if _, ok := types[r.Proxy]; !ok && r.Type.IsValid() {
+			types[r.Proxy] = r.Type
+			params.ProxyList = append(params.ProxyList, r.Proxy)
+		}
+	}
+
+	// Blocklisted and out-of-scope proxies are not checked, known-good or not
+	if err := a.filterInput(&params); err != nil {
+		return nil, err
+	}
+	sample := make(map[string]checker.ProxyType)
+	for _, proxy := range params.ProxyList {
+		if len(sample) >= sampleSize {
+			break
+		}
+		sample[proxy] = types[proxy]
+	}
+	if len(sample) == 0 {
+		return nil, errors.New("no known-good proxies to benchmark with; check some proxies first")
+	}
+
+	cfg := a.config.GetConfig()
+	endpoints := diagnosticEndpoints(cfg.LastEndpoint, cfg.DefaultEndpoints)
+	a.emit("log", fmt.Sprintf("Benchmarking %d endpoints with %d proxies", len(endpoints), len(sample)))
+
+	benchmarks, err := checker.BenchmarkEndpoints(context.Background(), sample, endpoints,
+		checker.WithThreads(min(len(sample), checker.DefaultThreads)),
+		checker.WithUpstream(cfg.LastUpstreamProxy, cfg.LastUpstreamProxyType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(benchmarks) == 0 {
+		return nil, errors.New("no endpoints configured")
+	}
+
+	best := benchmarks[0]
+	a.emit("log", fmt.Sprintf("Best endpoint: %s (%.0f%% success, %dms median)", best.Endpoint, best.SuccessRate*100, best.MedianLatency))
+	return benchmarks, nil
+}
@@ -0,0 +1,65 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// GetBlocklist returns the current blocklist patterns
+func (a *App) GetBlocklist() []string {
+	return a.config.GetConfig().Blocklist
+}
+
+// AddToBlocklist adds ip:port, host or CIDR patterns to the blocklist
+func (a *App) AddToBlocklist(entries []string) error {
+	for _, entry := range entries {
+		if err := checker.ValidatePattern(entry); err != nil {
+			return err
+		}
+	}
+
+	return a.config.AddBlocklistEntries(entries)
+}
+
+// RemoveFromBlocklist removes patterns from the blocklist
+func (a *App) RemoveFromBlocklist(entries []string) error {
+	return a.config.RemoveBlocklistEntries(entries)
+}
+
+// ClearBlocklist removes all patterns from the blocklist
+func (a *App) ClearBlocklist() error {
+	return a.config.UpdateConfig(func(c *config.Config) {
+		c.Blocklist = []string{}
+	})
+}
+
+// applyBlocklist removes blocklisted proxies from the list before a check
+func (a *App) applyBlocklist(proxies []string) []string {
+	blocklist := a.config.GetConfig().Blocklist
+	if len(blocklist) == 0 {
+		return proxies
+	}
+
+	matcher, err := checker.NewAddressMatcher(blocklist)
+	if err != nil {
+		a.emit("log", fmt.Sprintf("Ignoring invalid blocklist: %v", err))
+		return proxies
+	}
+
+	kept, blocked := matcher.Filter(proxies)
+	if len(blocked) > 0 {
+		a.emit("log", fmt.Sprintf("Skipped %d blocklisted proxies", len(blocked)))
+	}
+
+	return kept
+}
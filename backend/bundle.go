@@ -0,0 +1,199 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+const (
+	// bundleVersion is the version of the run bundle layout written by this build
+	bundleVersion = 1
+
+	// bundleExt is the extension of run bundle files, gzip compressed JSON
+	bundleExt = ".soxybundle"
+
+	// maxBundleSize bounds the decompressed size of an opened bundle
+	maxBundleSize = 1 << 30
+)
+
+var (
+	ErrInvalidBundle = errors.New("invalid run bundle")
+	ErrViewerMode    = errors.New("a run bundle is open in viewer mode")
+)
+
+// RunBundle is a complete run saved as a single file, to be opened read-only elsewhere
+type RunBundle struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	// Params are the run parameters without the proxy list and secrets
+	Params  CheckParams           `json:"params"`
+	Stats   checker.Stats         `json:"stats"`
+	Results []checker.ProxyResult `json:"results"`
+	Log     string                `json:"log"`
+}
+
+// BundleView is an opened run bundle as shown by the viewer
+type BundleView struct {
+	Path    string        `json:"path"`
+	Created time.Time     `json:"created"`
+	Params  CheckParams   `json:"params"`
+	Stats   Stats         `json:"stats"`
+	Results []ProxyResult `json:"results"`
+	Log     string        `json:"log"`
+}
+
+// ExportRunBundle writes the results, stats, log and parameters of the main run to a
+// single bundle file in the export directory and returns its path
+func (a *App) ExportRunBundle() (string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return "", err
+	}
+	results := a.manager.GetResults()
+	if len(results) == 0 {
+		return "", errors.New("no results to bundle")
+	}
+
+	a.resultsMux.Lock()
+	params := a.lastParams
+	a.resultsMux.Unlock()
+	params.ProxyList = nil
+	params.Sources = nil
+	params.Metadata = nil
+	params.UpstreamProxy = checker.StripProxyAuth(params.UpstreamProxy)
+
+	bundle := RunBundle{
+		Version: bundleVersion,
+		Created: time.Now(),
+		Params:  params,
+		Stats:   a.manager.GetStats(),
+		Results: results,
+	}
+	a.runLogMux.Lock()
+	if a.runLog != nil {
+		bundle.Log = a.runLog.text()
+	}
+	a.runLogMux.Unlock()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(bundle); err != nil {
+		return "", fmt.Errorf("failed to encode run bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress run bundle: %w", err)
+	}
+
+	path := filepath.Join(a.config.ExportDir(), "run_"+time.Now().Format("20060102_150405")+bundleExt)
+	if err := export.WriteFile(path, buf.Bytes()); err != nil {
+		return "", err
+	}
+
+	a.recordAudit("export-bundle", fmt.Sprintf("results=%d", len(results)), path)
+	a.emit("log", "Run bundle exported to "+path)
+	return path, nil
+}
+
+// readBundle decodes a bundle file
+func readBundle(path string) (*RunBundle, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open run bundle: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	defer gz.Close()
+
+	var bundle RunBundle
+	if err := json.NewDecoder(io.LimitReader(gz, maxBundleSize)).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if bundle.Version < 1 || bundle.Version > bundleVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBundle, bundle.Version)
+	}
+	return &bundle, nil
+}
+
+// OpenRunBundle opens a run bundle in the read-only viewer. Checks cannot be started
+// until the bundle is closed; the results of the current run are left untouched
+func (a *App) OpenRunBundle(path string) (*BundleView, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, bundleExt) {
+		return nil, fmt.Errorf("%w: expected a %s file", ErrInvalidBundle, bundleExt)
+	}
+
+	bundle, err := readBundle(path)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &BundleView{
+		Path:    path,
+		Created: bundle.Created,
+		Params:  bundle.Params,
+		Stats:   convertStats(bundle.Stats),
+		Results: a.convertResults(bundle.Results),
+		Log:     bundle.Log,
+	}
+
+	a.viewerMux.Lock()
+	a.viewer = view
+	a.viewerMux.Unlock()
+
+	a.emit("log", fmt.Sprintf("Opened run bundle %s (%d results) in viewer mode", path, len(bundle.Results)))
+	a.emit("viewer-mode", true)
+	return view, nil
+}
+
+// GetOpenBundle returns the bundle open in the viewer, or nil
+func (a *App) GetOpenBundle() *BundleView {
+	a.viewerMux.Lock()
+	defer a.viewerMux.Unlock()
+	return a.viewer
+}
+
+// CloseRunBundle leaves the viewer mode
+func (a *App) CloseRunBundle() {
+	a.viewerMux.Lock()
+	a.viewer = nil
+	a.viewerMux.Unlock()
+
+	a.emit("viewer-mode", false)
+}
+
+// viewerMode returns true while a run bundle is open in the viewer
+func (a *App) viewerMode() bool {
+	return a.GetOpenBundle() != nil
+}
+
+// requireLive refuses anything that checks proxies while a run bundle is open in the viewer
+func (a *App) requireLive() error {
+	if a.viewerMode() {
+		return ErrViewerMode
+	}
+	return nil
+}
@@ -22,9 +22,15 @@ import (
 // DetectProxyType attempts to automatically detect the type of proxy
 // It tries each protocol in order: SOCKS5, SOCKS4, HTTPS, HTTP
 func DetectProxyType(proxy string, timeout time.Duration) (ProxyType, error) {
+	return DetectProxyTypeContext(context.Background(), proxy, timeout)
+}
+
+// DetectProxyTypeContext is like DetectProxyType but binds its connections to the source
+// address of ctx and gives up as soon as ctx is cancelled
+func DetectProxyTypeContext(ctx context.Context, proxy string, timeout time.Duration) (ProxyType, error) {
 	// Try each protocol in sequence
 	protocols := []struct {
-		checkFunc func(string, time.Duration) bool
+		checkFunc func(context.Context, string, time.Duration) bool
 		proxyType ProxyType
 	}{
 		{checkSOCKS5Quick, SOCKS5},
@@ -34,7 +40,7 @@ func DetectProxyType(proxy string, timeout time.Duration) (ProxyType, error) {
 	}
 
 	for _, protocol := range protocols {
-		if protocol.checkFunc(proxy, timeout) {
+		if protocol.checkFunc(ctx, proxy, timeout) {
 			return protocol.proxyType, nil
 		}
 	}
@@ -45,22 +51,16 @@ func DetectProxyType(proxy string, timeout time.Duration) (ProxyType, error) {
 // Quick check functions for auto-detection
 
 // checkHTTPQuick performs a quick check to see if a proxy supports HTTP
-func checkHTTPQuick(proxy string, timeout time.Duration) bool {
+func checkHTTPQuick(ctx context.Context, proxy string, timeout time.Duration) bool {
 	proxyURL, err := url.Parse("http://" + proxy)
 	if err != nil {
 		return false
 	}
 
 	// Create a transport with the proxy
-	transport := &http.Transport{
-		Proxy: http.ProxyURL(proxyURL),
-		DialContext: (&net.Dialer{
-			Timeout:   timeout,
-			KeepAlive: timeout,
-		}).DialContext,
-		TLSHandshakeTimeout: timeout,
-		IdleConnTimeout:     timeout,
-	}
+	transport := newOneShotTransport(timeout)
+	transport.Proxy = http.ProxyURL(proxyURL)
+	defer transport.CloseIdleConnections()
 
 	// Create a client with the transport
 	client := &http.Client{
@@ -75,7 +75,7 @@ func checkHTTPQuick(proxy string, timeout time.Duration) bool {
 	}
 
 	// Set a short timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	req = req.WithContext(ctx)
 
@@ -91,22 +91,16 @@ func checkHTTPQuick(proxy string, timeout time.Duration) bool {
 }
 
 // checkHTTPSQuick performs a quick check to see if a proxy supports HTTPS
-func checkHTTPSQuick(proxy string, timeout time.Duration) bool {
+func checkHTTPSQuick(ctx context.Context, proxy string, timeout time.Duration) bool {
 	proxyURL, err := url.Parse("http://" + proxy)
 	if err != nil {
 		return false
 	}
 
 	// Create a transport with the proxy
-	transport := &http.Transport{
-		Proxy: http.ProxyURL(proxyURL),
-		DialContext: (&net.Dialer{
-			Timeout:   timeout,
-			KeepAlive: timeout,
-		}).DialContext,
-		TLSHandshakeTimeout: timeout,
-		IdleConnTimeout:     timeout,
-	}
+	transport := newOneShotTransport(timeout)
+	transport.Proxy = http.ProxyURL(proxyURL)
+	defer transport.CloseIdleConnections()
 
 	// Create a client with the transport
 	client := &http.Client{
@@ -121,7 +115,7 @@ func checkHTTPSQuick(proxy string, timeout time.Duration) bool {
 	}
 
 	// Set a short timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	req = req.WithContext(ctx)
 
@@ -137,7 +131,7 @@ func checkHTTPSQuick(proxy string, timeout time.Duration) bool {
 }
 
 // checkSOCKS4Quick performs a quick check to see if a proxy supports SOCKS4
-func checkSOCKS4Quick(proxy string, timeout time.Duration) bool {
+func checkSOCKS4Quick(ctx context.Context, proxy string, timeout time.Duration) bool {
 	// Parse the proxy address (host, port, err)
 	_, _, err := net.SplitHostPort(proxy)
 	if err != nil {
@@ -145,12 +139,13 @@ func checkSOCKS4Quick(proxy string, timeout time.Duration) bool {
 	}
 
 	// Create a SOCKS4 dialer
-	dialer := &net.Dialer{
-		Timeout: timeout,
+	dialer, err := newDialer(ctx, timeout)
+	if err != nil {
+		return false
 	}
 
 	// Try to connect to the proxy
-	conn, err := dialer.Dial("tcp", proxy)
+	conn, err := dialer.DialContext(ctx, "tcp", proxy)
 	if err != nil {
 		return false
 	}
@@ -161,12 +156,9 @@ func checkSOCKS4Quick(proxy string, timeout time.Duration) bool {
 	// VN(1) | CD(1) | DSTPORT(2) | DSTIP(4) | USERID(variable) | NULL(1)
 	// VN = 4 (SOCKS version)
 	// CD = 1 (connect command)
-	request := []byte{
-		4,     // SOCKS version
-		1,     // CONNECT command
-		0, 80, // Port 80
-		8, 8, 8, 8, // IP (8.8.8.8)
-		0, // User ID (empty)
+	request, err := socks4Request("8.8.8.8:80", socks4Ident(ctx))
+	if err != nil {
+		return false
 	}
 
 	// Set a deadline for the connection
@@ -190,22 +182,24 @@ func checkSOCKS4Quick(proxy string, timeout time.Duration) bool {
 	// Check if the response indicates success
 	// SOCKS4 response format:
 	// VN(1) | CD(1) | DSTPORT(2) | DSTIP(4)
-	// CD = 90 (request granted)
-	return response[1] == 90
+	// CD = 90 (request granted); an ident rejection still proves the proxy speaks SOCKS4
+	return response[0] == 0 && (response[1] == SOCKS4Granted || response[1] == SOCKS4IdentUnreach || response[1] == SOCKS4IdentMismatch)
 }
 
 // checkSOCKS5Quick performs a quick check to see if a proxy supports SOCKS5
-func checkSOCKS5Quick(proxy string, timeout time.Duration) bool {
+func checkSOCKS5Quick(ctx context.Context, proxy string, timeout time.Duration) bool {
 	// Create a SOCKS5 dialer
-	dialer, err := socks.SOCKS5("tcp", proxy, nil, &net.Dialer{
-		Timeout: timeout,
-	})
+	baseDialer, err := newDialer(ctx, timeout)
+	if err != nil {
+		return false
+	}
+	dialer, err := socks.SOCKS5("tcp", proxy, nil, baseDialer)
 	if err != nil {
 		return false
 	}
 
 	// Try to connect to a known endpoint
-	conn, err := dialer.Dial("tcp", "www.google.com:80")
+	conn, err := dialContext(ctx, dialer, "tcp", "www.google.com:80")
 	if err != nil {
 		return false
 	}
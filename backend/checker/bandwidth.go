@@ -0,0 +1,67 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BandwidthLimiter is a token bucket shared by the connections of a run, capping their
+// combined traffic so checking does not saturate the uplink and distort latencies
+type BandwidthLimiter struct {
+	mutex  sync.Mutex
+	rate   float64 // bytes per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewBandwidthLimiter creates a limiter allowing bytesPerSecond across all connections
+func NewBandwidthLimiter(bytesPerSecond int64) *BandwidthLimiter {
+	rate := float64(bytesPerSecond)
+	return &BandwidthLimiter{
+		rate:   rate,
+		burst:  rate,
+		tokens: rate,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until n bytes may be transferred
+// The bytes are reserved right away, so concurrent callers are served in order
+func (l *BandwidthLimiter) Wait(n int) {
+	if n <= 0 {
+		return
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	debt := l.tokens
+	l.mutex.Unlock()
+
+	if debt < 0 {
+		time.Sleep(time.Duration(-debt / l.rate * float64(time.Second)))
+	}
+}
+
+// bandwidthLimiterKey is the context key of the bandwidth limiter of a run
+type bandwidthLimiterKey struct{}
+
+// withBandwidthLimiter returns a context throttling the connections dialed with it
+func withBandwidthLimiter(ctx context.Context, limiter *BandwidthLimiter) context.Context {
+	return context.WithValue(ctx, bandwidthLimiterKey{}, limiter)
+}
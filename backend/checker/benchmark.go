@@ -0,0 +1,91 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// EndpointBenchmark is how a sample of known-good proxies fared against one endpoint
+type EndpointBenchmark struct {
+	Endpoint      string  `json:"endpoint"`
+	Attempted     int     `json:"attempted"`
+	Succeeded     int     `json:"succeeded"`
+	SuccessRate   float64 `json:"successRate"` // between 0 and 1
+	MedianLatency int64   `json:"medianLatency"`
+	MeanLatency   int64   `json:"meanLatency"`
+	// Error is the most common failure, if any
+	Error string `json:"error,omitempty"`
+}
+
+// BenchmarkEndpoints checks the same proxies (proxy -> type) against every endpoint in turn
+// and returns the endpoints best first: highest success rate, then lowest median latency
+// opts apply to every run; the endpoint and proxy type are set by the benchmark
+func BenchmarkEndpoints(ctx context.Context, proxies map[string]ProxyType, endpoints []string, opts ...Option) ([]EndpointBenchmark, error) {
+	byType := make(map[ProxyType][]string)
+	for proxy, proxyType := range proxies {
+		byType[proxyType] = append(byType[proxyType], proxy)
+	}
+
+	benchmarks := make([]EndpointBenchmark, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		var results []ProxyResult
+		for proxyType, list := range byType {
+			runOpts := append(append([]Option(nil), opts...), WithEndpoint(endpoint), WithProxyType(proxyType))
+			r, _, err := Check(ctx, list, runOpts...)
+			results = append(results, r...)
+			if err != nil {
+				return benchmarks, err
+			}
+		}
+		benchmarks = append(benchmarks, summarizeBenchmark(endpoint, results))
+	}
+
+	sort.SliceStable(benchmarks, func(i, j int) bool {
+		if benchmarks[i].SuccessRate != benchmarks[j].SuccessRate {
+			return benchmarks[i].SuccessRate > benchmarks[j].SuccessRate
+		}
+		return benchmarks[i].MedianLatency < benchmarks[j].MedianLatency
+	})
+	return benchmarks, nil
+}
+
+// summarizeBenchmark aggregates the results of one endpoint
+func summarizeBenchmark(endpoint string, results []ProxyResult) EndpointBenchmark {
+	b := EndpointBenchmark{Endpoint: endpoint, Attempted: len(results)}
+
+	var latencies []int64
+	var total int64
+	failures := make(map[string]int)
+	for _, r := range results {
+		switch ProxyStatus(strings.ToLower(string(r.Status))) {
+		case StatusLive, StatusSlow:
+			latencies = append(latencies, r.Latency)
+			total += r.Latency
+		default:
+			failures[r.ErrorKind]++
+			if failures[r.ErrorKind] > failures[b.Error] {
+				b.Error = r.ErrorKind
+			}
+		}
+	}
+
+	b.Succeeded = len(latencies)
+	if b.Attempted > 0 {
+		b.SuccessRate = float64(b.Succeeded) / float64(b.Attempted)
+	}
+	if b.Succeeded > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		b.MedianLatency = latencies[len(latencies)/2]
+		b.MeanLatency = total / int64(b.Succeeded)
+	}
+	return b
+}
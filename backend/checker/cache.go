@@ -0,0 +1,86 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CacheBustParam is the query parameter carrying the unique token of a check
+const CacheBustParam = "_sc"
+
+// cacheBust returns endpoint with a unique token appended to its query, so every check
+// requests a URL no cache has seen before
+func cacheBust(endpoint string) (string, string) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return endpoint, ""
+	}
+	token := hex.EncodeToString(buf)
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint, ""
+	}
+	query := u.Query()
+	query.Set(CacheBustParam, token)
+	u.RawQuery = query.Encode()
+	return u.String(), token
+}
+
+// cacheDetector looks for evidence that a judge response came from a cache
+// The check may be abandoned by the watchdog while still running, so access is guarded
+type cacheDetector struct {
+	mutex    sync.Mutex
+	evidence string
+}
+
+// observe records cache hit headers of resp; the URL carries a fresh token,
+// so a hit means a middlebox ignores the query string or Cache-Control
+func (c *cacheDetector) observe(resp *http.Response) {
+	evidence := cacheEvidence(resp.Header)
+	if evidence == "" {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.evidence = evidence
+}
+
+// apply flags a result whose judge response was served from a cache
+func (c *cacheDetector) apply(result *ProxyResult) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.evidence != "" {
+		result.Cached = true
+		result.CacheEvidence = c.evidence
+	}
+}
+
+// cacheEvidence returns the header showing a response is a cache hit, or an empty string
+func cacheEvidence(header http.Header) string {
+	if age, err := strconv.Atoi(strings.TrimSpace(header.Get("Age"))); err == nil && age > 0 {
+		return "Age: " + header.Get("Age")
+	}
+	for _, name := range []string{"X-Cache", "X-Cache-Status", "CF-Cache-Status", "X-Proxy-Cache"} {
+		value := header.Get(name)
+		if strings.Contains(strings.ToUpper(value), "HIT") {
+			return name + ": " + value
+		}
+	}
+	return ""
+}
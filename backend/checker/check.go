@@ -0,0 +1,174 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"time"
+)
+
+// Defaults used by Check and CheckStream when no option overrides them
+const (
+	DefaultThreads  = 10
+	DefaultEndpoint = "https://api.ipify.org"
+)
+
+// DefaultCheckTimeout bounds the connection and the judge request of a single check
+const DefaultCheckTimeout = 10 * time.Second
+
+// checkOptions holds the settings of a Check call
+type checkOptions struct {
+	req      ProxyCheckRequest
+	logf     func(string)
+	onResult []func(ProxyResult)
+}
+
+// Option configures a check
+type Option func(*checkOptions)
+
+// WithProxyType sets the type of the proxies (default HTTP; Auto detects each proxy's type)
+func WithProxyType(proxyType ProxyType) Option {
+	return func(o *checkOptions) { o.req.ProxyType = proxyType }
+}
+
+// WithEndpoint sets the URL the proxies must reach; it must return the caller's IP as plain text
+func WithEndpoint(endpoint string) Option {
+	return func(o *checkOptions) { o.req.Endpoint = endpoint }
+}
+
+// WithThreads sets the number of concurrent checks
+func WithThreads(threads int) Option {
+	return func(o *checkOptions) { o.req.Threads = threads }
+}
+
+// WithUpstream routes every check through an upstream proxy
+func WithUpstream(proxy string, proxyType ProxyType) Option {
+	return func(o *checkOptions) {
+		o.req.UpstreamProxy = proxy
+		o.req.UpstreamType = proxyType
+	}
+}
+
+// WithSources labels proxies with their origin; results carry the label and stats group by it
+func WithSources(sources map[string]string) Option {
+	return func(o *checkOptions) { o.req.Sources = sources }
+}
+
+// WithPriorities checks proxies with a higher priority first
+func WithPriorities(priorities map[string]int) Option {
+	return func(o *checkOptions) { o.req.Priorities = priorities }
+}
+
+// WithOrder sets the order of proxies with equal priority
+func WithOrder(order OrderMode) Option {
+	return func(o *checkOptions) { o.req.Order = order }
+}
+
+// WithMaxLatency classifies live proxies slower than maxLatency milliseconds as slow
+func WithMaxLatency(maxLatency int64) Option {
+	return func(o *checkOptions) { o.req.MaxLatency = maxLatency }
+}
+
+// WithWatchdogFactor abandons checks running longer than the check timeout times factor
+func WithWatchdogFactor(factor float64) Option {
+	return func(o *checkOptions) { o.req.WatchdogFactor = factor }
+}
+
+// WithTypeCache remembers the types detected in Auto mode, skipping detection of cached proxies
+func WithTypeCache(cache *TypeCache) Option {
+	return func(o *checkOptions) { o.req.TypeCache = cache }
+}
+
+// WithLogger receives progress messages
+func WithLogger(logf func(string)) Option {
+	return func(o *checkOptions) { o.logf = logf }
+}
+
+// WithResultHandler is called with every completed check, from the checking goroutines
+// It may be given more than once; handlers are called in order
+func WithResultHandler(handler func(ProxyResult)) Option {
+	return func(o *checkOptions) { o.onResult = append(o.onResult, handler) }
+}
+
+// newCheckOptions applies options over the defaults
+func newCheckOptions(proxies []string, opts []Option) *checkOptions {
+	o := &checkOptions{
+		req: ProxyCheckRequest{
+			ProxyList: proxies,
+			ProxyType: HTTP,
+			Endpoint:  DefaultEndpoint,
+			Threads:   DefaultThreads,
+		},
+		logf: func(string) {},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Check checks proxies and blocks until all are checked or ctx is cancelled
+// On cancellation the checks in flight finish, the rest are skipped, and ctx.Err() is
+// returned together with the results gathered so far
+// Invalid options are reported before any check starts
+func Check(ctx context.Context, proxies []string, opts ...Option) ([]ProxyResult, Stats, error) {
+	o := newCheckOptions(proxies, opts)
+	if err := o.req.Validate(); err != nil {
+		return nil, Stats{}, err
+	}
+
+	m := NewManager()
+	done := make(chan struct{})
+	m.SetCompletionHandler(func() { close(done) })
+	if len(o.onResult) > 0 {
+		m.SetResultHandler(func(r ProxyResult) {
+			for _, handler := range o.onResult {
+				handler(r)
+			}
+		})
+	}
+
+	m.Start(o.req, o.logf, func() {})
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		m.Stop(false)
+		<-done
+		err = ctx.Err()
+	}
+
+	return m.GetResults(), m.GetStats(), err
+}
+
+// CheckStream checks proxies in the background and delivers each result on the returned
+// channel, which is closed when the run completes or ctx is cancelled
+// Invalid options are reported before any check starts
+// The caller must drain the channel; checking blocks while it is full
+func CheckStream(ctx context.Context, proxies []string, opts ...Option) (<-chan ProxyResult, error) {
+	if err := newCheckOptions(proxies, opts).req.Validate(); err != nil {
+		return nil, err
+	}
+
+	out := make(chan ProxyResult, DefaultThreads)
+
+	go func() {
+		defer close(out)
+
+		Check(ctx, proxies, append(opts, WithResultHandler(func(r ProxyResult) {
+			select {
+			case out <- r:
+			case <-ctx.Done():
+			}
+		}))...)
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	live := newTestProxy(t, 0)
+	dead := closedAddr(t)
+
+	var seen int
+	results, stats, err := Check(context.Background(), []string{live, dead},
+		WithEndpoint("http://judge.invalid/"),
+		WithThreads(1),
+		WithResultHandler(func(ProxyResult) { seen++ }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || seen != 2 {
+		t.Fatalf("got %d results and %d callbacks, want 2", len(results), seen)
+	}
+	if stats.Live != 1 || stats.Dead != 1 {
+		t.Fatalf("got %+v, want 1 live and 1 dead", stats)
+	}
+}
+
+func TestCheckCancel(t *testing.T) {
+	proxy := newTestProxy(t, 100*time.Millisecond)
+
+	list := make([]string, 20)
+	for i := range list {
+		list[i] = proxy
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	results, stats, err := Check(ctx, list, WithEndpoint("http://judge.invalid/"), WithThreads(2))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want deadline exceeded", err)
+	}
+	if len(results) == len(list) || stats.Pending == 0 {
+		t.Fatalf("cancelled check ran to completion: %+v", stats)
+	}
+}
+
+func TestCheckStream(t *testing.T) {
+	live := newTestProxy(t, 0)
+
+	results, err := CheckStream(context.Background(), []string{live, live, live}, WithEndpoint("http://judge.invalid/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for r := range results {
+		if r.OutgoingIP != "203.0.113.7" {
+			t.Errorf("unexpected outgoing IP %q", r.OutgoingIP)
+		}
+		count++
+	}
+
+	if count != 3 {
+		t.Fatalf("got %d streamed results, want 3", count)
+	}
+}
+
+func TestNewCheckRequestValidation(t *testing.T) {
+	if _, err := NewCheckRequest([]string{"127.0.0.1:8080"}, WithProxyType(SOCKS5)); err != nil {
+		t.Fatalf("valid request rejected: %v", err)
+	}
+
+	_, err := NewCheckRequest(nil,
+		WithThreads(0),
+		WithProxyType("ftp"),
+		WithEndpoint("api.ipify.org"),
+		WithUpstream("no-port", "ftp"),
+	)
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("got %v, want ErrInvalidRequest", err)
+	}
+
+	for _, want := range []string{"proxy list is empty", "threads", `proxy type "ftp"`, "scheme", "upstream proxy", "upstream proxy type"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestCheckUsesTypeCache(t *testing.T) {
+	live := newTestProxy(t, 0)
+	dead := closedAddr(t)
+
+	cache := NewTypeCache(time.Hour)
+	cache.Put(live, HTTP)
+	cache.Put(dead, HTTP)
+
+	results, _, err := Check(context.Background(), []string{live, dead},
+		WithProxyType(Auto),
+		WithEndpoint("http://judge.invalid/"),
+		WithTypeCache(cache),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range results {
+		if r.Proxy == live && (r.Status != "LIVE" || r.Type != HTTP) {
+			t.Errorf("got %s %s for the cached live proxy, want LIVE http", r.Status, r.Type)
+		}
+	}
+	if _, ok := cache.Get(live); !ok {
+		t.Error("live proxy was dropped from the cache")
+	}
+	if _, ok := cache.Get(dead); ok {
+		t.Error("dead proxy is still cached")
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StaleResponseThreshold is how far in the past a judge response may be dated before it is
+// flagged as stale; a caching middlebox replaying an old response can fake a live verdict
+const StaleResponseThreshold = 2 * time.Minute
+
+// responseObserverKey is the context key of the judge response observer
+type responseObserverKey struct{}
+
+// WithResponseObserver returns a context making the checks pass the judge response to fn
+// before its body is read
+func WithResponseObserver(ctx context.Context, fn func(resp *http.Response)) context.Context {
+	return context.WithValue(ctx, responseObserverKey{}, fn)
+}
+
+// observeResponse passes a judge response to the observer of ctx, if any
+func observeResponse(ctx context.Context, resp *http.Response) {
+	if fn, ok := ctx.Value(responseObserverKey{}).(func(resp *http.Response)); ok {
+		fn(resp)
+	}
+}
+
+// judgeClock records the Date header of a judge response
+// The check may be abandoned by the watchdog while still running, so access is guarded
+type judgeClock struct {
+	mutex    sync.Mutex
+	date     time.Time
+	received time.Time
+}
+
+// observe records the Date header of resp
+func (c *judgeClock) observe(resp *http.Response) {
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.date = date
+	c.received = time.Now()
+}
+
+// apply fills the clock fields of a result from the recorded response
+func (c *judgeClock) apply(result *ProxyResult) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.date.IsZero() {
+		return
+	}
+
+	date := c.date
+	result.JudgeDate = &date
+	result.ClockSkew = int64(c.date.Sub(c.received).Round(time.Second) / time.Second)
+	result.StaleResponse = c.received.Sub(c.date) > StaleResponseThreshold
+}
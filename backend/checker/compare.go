@@ -0,0 +1,109 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"sort"
+	"strings"
+)
+
+// VantageOutcome is the result of a proxy as seen from one vantage point
+type VantageOutcome struct {
+	Vantage string      `json:"vantage"`
+	Status  ProxyStatus `json:"status"`
+	Latency int64       `json:"latency"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// ComparisonRow holds the outcomes of one proxy across all vantage points
+type ComparisonRow struct {
+	Proxy    string           `json:"proxy"`
+	Outcomes []VantageOutcome `json:"outcomes"`
+
+	// Divergent is set when the proxy is live from some vantage points and not from others
+	Divergent bool `json:"divergent"`
+
+	// Complete is set when every vantage point has reported the proxy
+	Complete bool `json:"complete"`
+}
+
+// Comparison is the result of checking the same proxies from several vantage points
+type Comparison struct {
+	Vantages       []string        `json:"vantages"`
+	Rows           []ComparisonRow `json:"rows"`
+	Divergent      int             `json:"divergent"`
+	LiveEverywhere int             `json:"liveEverywhere"`
+	DeadEverywhere int             `json:"deadEverywhere"`
+	Incomplete     int             `json:"incomplete"`
+}
+
+// CompareVantages compares the results of the same proxies checked from each vantage point
+// Rows are ordered with divergent proxies first
+func CompareVantages(vantages []string, results map[string][]ProxyResult) *Comparison {
+	byProxy := make(map[string]map[string]ProxyResult)
+	for vantage, list := range results {
+		for _, r := range list {
+			if byProxy[r.Proxy] == nil {
+				byProxy[r.Proxy] = make(map[string]ProxyResult)
+			}
+			byProxy[r.Proxy][vantage] = r
+		}
+	}
+
+	c := &Comparison{Vantages: vantages, Rows: make([]ComparisonRow, 0, len(byProxy))}
+	for proxy, seen := range byProxy {
+		row := ComparisonRow{Proxy: proxy, Complete: true}
+		live, notLive := 0, 0
+
+		for _, vantage := range vantages {
+			r, ok := seen[vantage]
+			if !ok {
+				row.Complete = false
+				row.Outcomes = append(row.Outcomes, VantageOutcome{Vantage: vantage, Status: StatusPending})
+				continue
+			}
+
+			status := ProxyStatus(strings.ToLower(string(r.Status)))
+			if status == StatusLive {
+				live++
+			} else {
+				notLive++
+			}
+			row.Outcomes = append(row.Outcomes, VantageOutcome{
+				Vantage: vantage,
+				Status:  status,
+				Latency: r.Latency,
+				Error:   r.Error,
+			})
+		}
+
+		row.Divergent = live > 0 && notLive > 0
+		switch {
+		case row.Divergent:
+			c.Divergent++
+		case !row.Complete:
+			c.Incomplete++
+		case live > 0:
+			c.LiveEverywhere++
+		default:
+			c.DeadEverywhere++
+		}
+
+		c.Rows = append(c.Rows, row)
+	}
+
+	sort.Slice(c.Rows, func(i, j int) bool {
+		if c.Rows[i].Divergent != c.Rows[j].Divergent {
+			return c.Rows[i].Divergent
+		}
+		return c.Rows[i].Proxy < c.Rows[j].Proxy
+	})
+
+	return c
+}
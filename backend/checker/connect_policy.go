@@ -0,0 +1,140 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultConnectPorts are the ports the CONNECT policy probe tries when none are configured
+var DefaultConnectPorts = []int{443, 80, 8443, 22}
+
+// CONNECT policies of an HTTP proxy
+const (
+	// ConnectAny tunnels to every tried port
+	ConnectAny = "any"
+	// Connect443Only tunnels to 443 and refuses every other tried port
+	Connect443Only = "443-only"
+	// ConnectRestricted refuses some of the tried ports
+	ConnectRestricted = "restricted"
+	// ConnectNone refuses every tried port
+	ConnectNone = "none"
+	// ConnectUnknown is reported when no port gave a conclusive answer
+	ConnectUnknown = "unknown"
+)
+
+// ConnectPolicyOutcome is the outcome of the CONNECT policy probe
+type ConnectPolicyOutcome struct {
+	// Host is the target the ports were tried on
+	Host string `json:"host"`
+
+	// Allowed are the ports the proxy tunneled to and Denied the ones it refused itself;
+	// Unreachable are the ports it accepted but could not reach (502, 504, timeouts), which
+	// say nothing about its policy
+	Allowed     []int `json:"allowed"`
+	Denied      []int `json:"denied"`
+	Unreachable []int `json:"unreachable"`
+
+	// Policy summarises the outcome, see ConnectAny and the other policies
+	Policy string `json:"policy"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// clone returns a copy of the CONNECT policy outcome, or nil
+func (o *ConnectPolicyOutcome) clone() *ConnectPolicyOutcome {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	c.Allowed = slices.Clone(o.Allowed)
+	c.Denied = slices.Clone(o.Denied)
+	c.Unreachable = slices.Clone(o.Unreachable)
+	return &c
+}
+
+// ProbeConnectPolicy asks an HTTP proxy to CONNECT to every port of host and records which
+// ones its policy allows; many proxies only tunnel 443 while passing an HTTPS check
+func ProbeConnectPolicy(ctx context.Context, proxyAddr string, proxyType ProxyType, host string, ports []int, timeout time.Duration) ConnectPolicyOutcome {
+	if host == "" {
+		host = DefaultPortMatrixHost
+	}
+	if len(ports) == 0 {
+		ports = DefaultConnectPorts
+	}
+	outcome := ConnectPolicyOutcome{Host: host, Allowed: []int{}, Denied: []int{}, Unreachable: []int{}}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, port := range ports {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+
+			conn, err := Tunnel(ctx, proxyAddr, proxyType, net.JoinHostPort(host, strconv.Itoa(port)), timeout)
+			if err == nil {
+				conn.Close()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch {
+			case err == nil:
+				outcome.Allowed = append(outcome.Allowed, port)
+			case connectDenied(err):
+				outcome.Denied = append(outcome.Denied, port)
+			default:
+				outcome.Unreachable = append(outcome.Unreachable, port)
+			}
+		}(port)
+	}
+	wg.Wait()
+
+	slices.Sort(outcome.Allowed)
+	slices.Sort(outcome.Denied)
+	slices.Sort(outcome.Unreachable)
+	outcome.Policy = connectPolicy(outcome.Allowed, outcome.Denied)
+	return outcome
+}
+
+// connectDenied reports whether a failed CONNECT was refused by the proxy itself rather
+// than because it could not reach the target
+func connectDenied(err error) bool {
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.Code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return false
+	}
+	return true
+}
+
+// connectPolicy summarises the allowed and denied ports
+func connectPolicy(allowed []int, denied []int) string {
+	switch {
+	case len(allowed) == 0 && len(denied) == 0:
+		return ConnectUnknown
+	case len(denied) == 0:
+		return ConnectAny
+	case len(allowed) == 0:
+		return ConnectNone
+	case len(allowed) == 1 && allowed[0] == 443:
+		return Connect443Only
+	}
+	return ConnectRestricted
+}
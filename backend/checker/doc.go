@@ -0,0 +1,25 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package checker is the proxy checking engine of SoxyChecker.
+//
+// It has no GUI dependencies and can be imported by any Go program. The
+// simplest entry point is Check, which blocks until every proxy is checked or
+// the context is cancelled:
+//
+//	results, stats, err := checker.Check(ctx, proxies,
+//		checker.WithProxyType(checker.SOCKS5),
+//		checker.WithEndpoint("https://api.ipify.org"),
+//		checker.WithThreads(50),
+//	)
+//
+// CheckStream delivers results on a channel as they complete instead. For
+// long-running, pausable runs use a Manager directly: Start a
+// ProxyCheckRequest, then Pause, Resume or Stop it and read GetResults and
+// GetStats at any time.
+package checker
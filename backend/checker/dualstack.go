@@ -0,0 +1,111 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Echo endpoints resolving to a single IP family, so a proxy can only reach them over it
+const (
+	DefaultIPv4Echo = "https://api.ipify.org"
+	DefaultIPv6Echo = "https://api6.ipify.org"
+)
+
+// DualStackOutcome is the outcome of the dual-stack probe
+type DualStackOutcome struct {
+	// IPv4 and IPv6 are the exit addresses of the proxy over each family, if it has one
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+
+	// DualStack is true if the proxy exits over both families
+	DualStack bool `json:"dualStack"`
+
+	// IPv4Error and IPv6Error tell why a family could not be reached
+	IPv4Error string `json:"ipv4Error,omitempty"`
+	IPv6Error string `json:"ipv6Error,omitempty"`
+}
+
+// ProbeDualStack asks an IPv4-only and an IPv6-only echo endpoint for the exit address of
+// the proxy; both requests run at the same time. Empty endpoints use the defaults
+func ProbeDualStack(ctx context.Context, proxyAddr string, proxyType ProxyType, ipv4Endpoint string, ipv6Endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) DualStackOutcome {
+	if ipv4Endpoint == "" {
+		ipv4Endpoint = DefaultIPv4Echo
+	}
+	if ipv6Endpoint == "" {
+		ipv6Endpoint = DefaultIPv6Echo
+	}
+
+	var outcome DualStackOutcome
+	var ipv4Err, ipv6Err error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		outcome.IPv4, ipv4Err = echoExit(ctx, proxyAddr, proxyType, ipv4Endpoint, 4, timeout, upstreamProxy, upstreamType)
+	}()
+	go func() {
+		defer wg.Done()
+		outcome.IPv6, ipv6Err = echoExit(ctx, proxyAddr, proxyType, ipv6Endpoint, 6, timeout, upstreamProxy, upstreamType)
+	}()
+	wg.Wait()
+
+	if ipv4Err != nil {
+		outcome.IPv4Error = ipv4Err.Error()
+	}
+	if ipv6Err != nil {
+		outcome.IPv6Error = ipv6Err.Error()
+	}
+	outcome.DualStack = outcome.IPv4 != "" && outcome.IPv6 != ""
+	return outcome
+}
+
+// echoExit returns the exit address reported by an echo endpoint, which must belong to the
+// given IP version
+func echoExit(ctx context.Context, proxyAddr string, proxyType ProxyType, endpoint string, version int, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
+	client, closeIdle, err := newProxyClient(ctx, proxyAddr, proxyType, timeout, upstreamProxy, upstreamType)
+	if err != nil {
+		return "", err
+	}
+	defer closeIdle()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	req.Close = true
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", classify(fmt.Sprintf("IPv%d echo failed", version), err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return "", err
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		return "", err
+	}
+	ip, err := parseJudgeBody(body)
+	if err != nil {
+		return "", err
+	}
+	if IPVersion(ip) != version {
+		return "", fmt.Errorf("%w: IPv%d echo answered with %s", ErrBadJudgeResponse, version, ip)
+	}
+	return ip, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Failure categories of a proxy check; use errors.Is to branch on them
+var (
+	ErrTimeout              = errors.New("timeout")
+	ErrConnRefused          = errors.New("connection refused")
+	ErrConnReset            = errors.New("connection reset")
+	ErrDNS                  = errors.New("dns failure")
+	ErrAuthRequired         = errors.New("proxy authentication required")
+	ErrIdentRejected        = errors.New("socks4 ident rejected")
+	ErrBadJudgeResponse     = errors.New("bad judge response")
+	ErrHijacked             = errors.New("hijacked judge response")
+	ErrUpstreamNotSupported = errors.New("upstream proxy not supported")
+	ErrCheckPanic           = errors.New("check panicked")
+	ErrAborted              = errors.New("check aborted")
+	ErrDestinationBlocked   = errors.New("destination blocked by proxy ACL")
+)
+
+// CheckError is a check failure tagged with its category
+// errors.Is matches both the category sentinel and the underlying error
+type CheckError struct {
+	// Op is the step of the check that failed, e.g. "connect" or "read response"
+	Op string
+
+	// Kind is the category sentinel, e.g. ErrTimeout
+	Kind error
+
+	// Err is the underlying error
+	Err error
+}
+
+// Error returns the error message
+func (e *CheckError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+// Unwrap returns the category and the underlying error
+func (e *CheckError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// classify wraps err in a CheckError carrying its category
+func classify(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CheckError{Op: op, Kind: kindOf(err), Err: err}
+}
+
+// kindOf returns the category sentinel of a network or protocol error
+func kindOf(err error) error {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var statusErr *HTTPStatusError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrAborted
+	case errors.As(err, &statusErr):
+		return statusErr.Kind
+	case errors.Is(err, ErrIdentRejected):
+		return ErrIdentRejected
+	case errors.As(err, &dnsErr) && !dnsErr.IsTimeout:
+		return ErrDNS
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded):
+		return ErrTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrConnRefused
+	case errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrConnReset
+	}
+
+	// The SOCKS client of golang.org/x/net reports authentication failures as plain strings
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "authentication"):
+		return ErrAuthRequired
+	case strings.Contains(msg, "connection refused"):
+		return ErrConnRefused
+	case strings.Contains(msg, "connection reset"):
+		return ErrConnReset
+	}
+
+	return ErrProxyConnectionFailed
+}
+
+// ErrorKind returns the category of a check error as a short label, or an empty string for nil
+func ErrorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrDestinationBlocked):
+		return "destination blocked"
+	case errors.Is(err, ErrTimeout):
+		return "timeout"
+	case errors.Is(err, ErrConnRefused):
+		return "connection refused"
+	case errors.Is(err, ErrConnReset):
+		return "connection reset"
+	case errors.Is(err, ErrDNS):
+		return "dns failure"
+	case errors.Is(err, ErrAuthRequired):
+		return "auth required"
+	case errors.Is(err, ErrIdentRejected):
+		return "ident rejected"
+	case errors.Is(err, ErrHijacked):
+		return "hijacked"
+	case errors.Is(err, ErrBadJudgeResponse):
+		return "bad judge response"
+	case errors.Is(err, ErrUnsupportedProxyType), errors.Is(err, ErrUpstreamNotSupported):
+		return "unsupported"
+	case errors.Is(err, ErrInvalidProxyFormat):
+		return "invalid proxy"
+	case errors.Is(err, ErrCheckPanic):
+		return "panic"
+	case errors.Is(err, ErrAborted):
+		return "aborted"
+	default:
+		return "other"
+	}
+}
+
+// checkStatus rejects responses that cannot carry the outgoing IP
+func checkStatus(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusProxyAuthRequired:
+		return &HTTPStatusError{Kind: ErrAuthRequired, Code: resp.StatusCode, Status: resp.Status}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return &HTTPStatusError{Kind: ErrBadJudgeResponse, Code: resp.StatusCode, Status: resp.Status}
+	}
+	return nil
+}
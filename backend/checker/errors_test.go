@@ -0,0 +1,139 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckErrorKinds(t *testing.T) {
+	_, err := CheckHTTP(closedAddr(t), "http://example.com/", time.Second, "", "")
+	if !errors.Is(err, ErrConnRefused) {
+		t.Fatalf("closed port: got %v, want ErrConnRefused", err)
+	}
+	if kind := ErrorKind(err); kind != "connection refused" {
+		t.Errorf("closed port: kind %q", kind)
+	}
+
+	authProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusProxyAuthRequired)
+		w.Write([]byte("login required"))
+	}))
+	defer authProxy.Close()
+
+	_, err = CheckHTTP(strings.TrimPrefix(authProxy.URL, "http://"), "http://example.com/", time.Second, "", "")
+	if !errors.Is(err, ErrAuthRequired) {
+		t.Fatalf("407: got %v, want ErrAuthRequired", err)
+	}
+	if reply := ReplyOf(err); reply == nil || reply.Protocol != "http" || reply.Code != http.StatusProxyAuthRequired {
+		t.Errorf("407: got reply %+v", reply)
+	}
+
+	slowProxy := newTestProxy(t, 500*time.Millisecond)
+	_, err = CheckHTTP(slowProxy, "http://example.com/", 100*time.Millisecond, "", "")
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("slow proxy: got %v, want ErrTimeout", err)
+	}
+
+	hijackProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<!DOCTYPE html><html><head><title>Top up your data plan</title></head></html>"))
+	}))
+	defer hijackProxy.Close()
+
+	_, err = CheckHTTP(strings.TrimPrefix(hijackProxy.URL, "http://"), "http://example.com/", time.Second, "", "")
+	if !errors.Is(err, ErrHijacked) || !strings.Contains(err.Error(), "Top up your data plan") {
+		t.Fatalf("login page: got %v, want ErrHijacked", err)
+	}
+	if kind := ErrorKind(err); kind != "hijacked" {
+		t.Errorf("login page: kind %q", kind)
+	}
+
+	garbageProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Service temporarily unavailable"))
+	}))
+	defer garbageProxy.Close()
+
+	_, err = CheckHTTP(strings.TrimPrefix(garbageProxy.URL, "http://"), "http://example.com/", time.Second, "", "")
+	if !errors.Is(err, ErrInvalidOutgoingIP) || ErrorKind(err) != "bad judge response" {
+		t.Fatalf("garbage body: got %v, want ErrInvalidOutgoingIP", err)
+	}
+	if IPVersion("2001:db8::1") != 6 || IPVersion("203.0.113.7") != 4 || IPVersion("garbage") != 0 {
+		t.Error("wrong IP versions")
+	}
+
+	if !errors.Is(ErrEmptyResponse, ErrBadJudgeResponse) {
+		t.Error("ErrEmptyResponse should be a bad judge response")
+	}
+	if kind := ErrorKind(nil); kind != "" {
+		t.Errorf("nil error: kind %q", kind)
+	}
+}
+
+func TestCheckProxyErrorKind(t *testing.T) {
+	m := NewManager()
+	req := ProxyCheckRequest{ProxyType: HTTP, Endpoint: "http://example.com/"}
+	result := m.checkProxy(context.Background(), req, closedAddr(t), func(string) {})
+	if result.Status != "DEAD" || result.ErrorKind != "connection refused" {
+		t.Errorf("got status %q kind %q", result.Status, result.ErrorKind)
+	}
+
+	result = m.checkProxy(context.Background(), req, "no-port", func(string) {})
+	if result.Status != "ERROR" || result.ErrorKind != "invalid proxy" {
+		t.Errorf("got status %q kind %q", result.Status, result.ErrorKind)
+	}
+}
+
+func TestReplyOfSOCKS(t *testing.T) {
+	reply := ReplyOf(classify("connect", errors.New("socks connect tcp 127.0.0.1:1080->example.com:80: unknown error connection not allowed by ruleset")))
+	if reply == nil || reply.Protocol != "socks5" || reply.Code != 0x02 {
+		t.Errorf("socks5: got %+v", reply)
+	}
+
+	reply = ReplyOf(classify("connect", &SOCKS4ReplyError{Code: SOCKS4Rejected}))
+	if reply == nil || reply.Protocol != "socks4" || reply.Code != 91 {
+		t.Errorf("socks4: got %+v", reply)
+	}
+
+	if reply := ReplyOf(classify("connect", context.DeadlineExceeded)); reply != nil {
+		t.Errorf("timeout: got %+v", reply)
+	}
+}
+
+func TestCheckProxyDestinationBlocked(t *testing.T) {
+	aclProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host == "blocked.invalid" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("203.0.113.7"))
+	}))
+	defer aclProxy.Close()
+	addr := strings.TrimPrefix(aclProxy.URL, "http://")
+
+	m := NewManager()
+	req := ProxyCheckRequest{ProxyType: HTTP, Endpoint: "http://blocked.invalid/", ReferenceEndpoint: "http://judge.invalid/"}
+	result := m.checkProxy(context.Background(), req, addr, func(string) {})
+	if result.Status != "BLOCKED" || result.ErrorKind != "destination blocked" || result.OutgoingIP != "203.0.113.7" {
+		t.Errorf("got status %q kind %q ip %q", result.Status, result.ErrorKind, result.OutgoingIP)
+	}
+	if result.Reply == nil || result.Reply.Code != http.StatusForbidden {
+		t.Errorf("got reply %+v", result.Reply)
+	}
+
+	req.ReferenceEndpoint = ""
+	if result = m.checkProxy(context.Background(), req, addr, func(string) {}); result.Status != "DEAD" {
+		t.Errorf("without a reference judge: got status %q", result.Status)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"sort"
+)
+
+// ExitGroup is a set of live proxies that share the same outgoing IP
+type ExitGroup struct {
+	OutgoingIP string        `json:"outgoingIp"`
+	Proxies    []ProxyResult `json:"proxies"`
+}
+
+// Fastest returns the proxy of the group with the lowest latency
+func (g ExitGroup) Fastest() ProxyResult {
+	return g.Proxies[0]
+}
+
+// GroupByExit groups results with a known outgoing IP by that IP
+// Proxies in a group are sorted by latency; groups are sorted by size, largest first
+func GroupByExit(results []ProxyResult) []ExitGroup {
+	index := make(map[string]int)
+	var groups []ExitGroup
+
+	for _, r := range results {
+		if r.OutgoingIP == "" {
+			continue
+		}
+		i, ok := index[r.OutgoingIP]
+		if !ok {
+			i = len(groups)
+			index[r.OutgoingIP] = i
+			groups = append(groups, ExitGroup{OutgoingIP: r.OutgoingIP})
+		}
+		groups[i].Proxies = append(groups[i].Proxies, r)
+	}
+
+	for _, g := range groups {
+		sort.SliceStable(g.Proxies, func(i, j int) bool { return g.Proxies[i].Latency < g.Proxies[j].Latency })
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(groups[i].Proxies) > len(groups[j].Proxies) })
+
+	return groups
+}
+
+// CollapseByExit keeps only the fastest proxy of every outgoing IP
+// Results without an outgoing IP are kept; the original order is preserved
+func CollapseByExit(results []ProxyResult) []ProxyResult {
+	fastest := make(map[string]ProxyResult)
+	for _, r := range results {
+		if r.OutgoingIP == "" {
+			continue
+		}
+		if best, ok := fastest[r.OutgoingIP]; !ok || r.Latency < best.Latency {
+			fastest[r.OutgoingIP] = r
+		}
+	}
+
+	collapsed := make([]ProxyResult, 0, len(results))
+	for _, r := range results {
+		if r.OutgoingIP != "" && fastest[r.OutgoingIP].Proxy != r.Proxy {
+			continue
+		}
+		collapsed = append(collapsed, r)
+	}
+	return collapsed
+}
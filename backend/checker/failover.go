@@ -0,0 +1,105 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"strings"
+	"sync"
+)
+
+// DefaultFailoverThreshold is the number of consecutive judge failures after which a run
+// fails over to the next endpoint
+const DefaultFailoverThreshold = 10
+
+// endpointFailover switches a run to the next endpoint when the current one keeps failing
+// Only failures pointing at the judge count (bad responses, unresolvable judge); a live
+// result proves the endpoint works and resets the streak, other failures are neutral
+type endpointFailover struct {
+	mutex     sync.Mutex
+	endpoints []string
+	current   int
+	threshold int
+	streak    []ProxyResult
+}
+
+// newEndpointFailover creates the failover of a run; without fallbacks it never switches
+func newEndpointFailover(primary string, fallbacks []string, threshold int) *endpointFailover {
+	if threshold <= 0 {
+		threshold = DefaultFailoverThreshold
+	}
+
+	endpoints := []string{primary}
+	for _, endpoint := range fallbacks {
+		if endpoint != "" && endpoint != primary {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return &endpointFailover{endpoints: endpoints, threshold: threshold}
+}
+
+// endpoint returns the endpoint new checks use
+func (f *endpointFailover) endpoint() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.endpoints[f.current]
+}
+
+// record accounts for a result checked against endpoint. It returns the results to
+// recheck and the endpoint switched to, if the run failed over. Judge failures against
+// an endpoint already abandoned are returned for a recheck right away
+func (f *endpointFailover) record(endpoint string, result ProxyResult) (recheck []ProxyResult, next string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	judgeFailure := isJudgeFailure(result)
+	if endpoint != f.endpoints[f.current] {
+		if judgeFailure {
+			return []ProxyResult{result}, ""
+		}
+		return nil, ""
+	}
+
+	switch {
+	case judgeFailure:
+		f.streak = append(f.streak, result)
+	case isWorking(result):
+		f.streak = nil
+		return nil, ""
+	default:
+		return nil, ""
+	}
+
+	if len(f.streak) < f.threshold {
+		return nil, ""
+	}
+	if f.current+1 >= len(f.endpoints) {
+		// Nowhere to fail over to; keep counting from the most recent failures
+		f.streak = f.streak[1:]
+		return nil, ""
+	}
+
+	f.current++
+	recheck = f.streak
+	f.streak = nil
+	return recheck, f.endpoints[f.current]
+}
+
+// isJudgeFailure returns true if a result failed because of the judge rather than the proxy
+func isJudgeFailure(result ProxyResult) bool {
+	if !strings.EqualFold(string(result.Status), string(StatusDead)) {
+		return false
+	}
+	return result.ErrorKind == ErrorKind(ErrBadJudgeResponse) || result.ErrorKind == ErrorKind(ErrDNS)
+}
+
+// isWorking returns true for live and slow results
+func isWorking(result ProxyResult) bool {
+	status := ProxyStatus(strings.ToLower(string(result.Status)))
+	return status == StatusLive || status == StatusSlow
+}
@@ -0,0 +1,68 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnexpectedGreeting = fmt.Errorf("%w: unexpected FTP greeting", ErrProxyConnectionFailed)
+)
+
+// ProbeFTP connects to an FTP server (host:port) through the proxy and waits for its
+// greeting; only the control channel is used, no data connection is opened
+func ProbeFTP(ctx context.Context, proxyAddr string, proxyType ProxyType, server string, timeout time.Duration) ProbeOutcome {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "21")
+	}
+
+	start := time.Now()
+	conn, err := Tunnel(ctx, proxyAddr, proxyType, server, timeout)
+	if err != nil {
+		return ProbeOutcome{Latency: time.Since(start).Milliseconds(), Error: err.Error()}
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if err := readFTPGreeting(bufio.NewReader(conn)); err != nil {
+		return ProbeOutcome{Latency: time.Since(start).Milliseconds(), Error: err.Error()}
+	}
+	latency := time.Since(start).Milliseconds()
+
+	// Say goodbye politely; the outcome does not depend on it
+	fmt.Fprintf(conn, "QUIT\r\n")
+
+	return ProbeOutcome{Passed: true, Latency: latency}
+}
+
+// readFTPGreeting reads the (possibly multi-line) greeting of an FTP server and checks
+// that it is a 220 service ready reply
+func readFTPGreeting(r *bufio.Reader) error {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return classify("failed to read FTP greeting", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if len(line) < 4 || !strings.HasPrefix(line, "220") {
+			return fmt.Errorf("%w: %q", ErrUnexpectedGreeting, line)
+		}
+		// "220-" continues the reply, "220 " ends it
+		if line[3] == ' ' {
+			return nil
+		}
+	}
+}
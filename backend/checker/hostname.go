@@ -0,0 +1,135 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// MaxIPChecks caps the resolved addresses of a hostname proxy checked separately
+const MaxIPChecks = 16
+
+// IPCheck is the outcome of checking one resolved address of a hostname proxy
+type IPCheck struct {
+	IP         string      `json:"ip"`
+	Status     ProxyStatus `json:"status"`
+	Latency    int64       `json:"latency"`
+	OutgoingIP string      `json:"outgoingIp,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// dialRecorderKey is the context key of the dial recorder
+type dialRecorderKey struct{}
+
+// dialRecorder remembers the last address connections were dialed to
+// Fallback addresses are only tried once the previous one failed, so the last one is the
+// address the check went through
+type dialRecorder struct {
+	mutex sync.Mutex
+	addr  string
+}
+
+// withDialRecorder returns a context making the dialers of newDialer report to rec
+func withDialRecorder(ctx context.Context, rec *dialRecorder) context.Context {
+	return context.WithValue(ctx, dialRecorderKey{}, rec)
+}
+
+// control records the address of a connection about to be dialed
+func (r *dialRecorder) control(ctx context.Context, network string, address string, c syscall.RawConn) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.addr = address
+	return nil
+}
+
+// ip returns the IP of the last dialed address
+func (r *dialRecorder) ip() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	host, _, err := net.SplitHostPort(r.addr)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// proxyHostname returns the host of a proxy address if it is a name rather than an IP
+func proxyHostname(proxy string) string {
+	host, _, err := net.SplitHostPort(StripProxyAuth(proxy))
+	if err != nil || net.ParseIP(host) != nil {
+		return ""
+	}
+	return host
+}
+
+// resolveProxy returns every address a proxy hostname resolves to
+func resolveProxy(ctx context.Context, host string, timeout time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP.String())
+	}
+	return ips, nil
+}
+
+// withIP replaces the host of a [user:pass@]host:port proxy address with ip
+func withIP(proxy string, ip string) string {
+	addr := StripProxyAuth(proxy)
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return proxy
+	}
+	return proxy[:len(proxy)-len(addr)] + net.JoinHostPort(ip, port)
+}
+
+// checkFunc checks a proxy address against an endpoint
+type checkFunc func(ctx context.Context, addr string, endpoint string) (string, error)
+
+// resolveResult records the addresses a hostname proxy resolves to and, if eachIP is set,
+// checks every one of them separately through check
+func resolveResult(ctx context.Context, result *ProxyResult, hostname string, addr string, endpoint string, check checkFunc, eachIP bool, limit time.Duration, timeout time.Duration) {
+	ips, err := resolveProxy(ctx, hostname, timeout)
+	if err != nil {
+		return
+	}
+	result.ResolvedIPs = ips
+	if !eachIP {
+		return
+	}
+
+	if len(ips) > MaxIPChecks {
+		ips = ips[:MaxIPChecks]
+	}
+	for _, ip := range ips {
+		if ctx.Err() != nil {
+			return
+		}
+		start := time.Now()
+		outgoingIP, _, err := runWatched(ctx, limit, func(ctx context.Context) (string, error) {
+			return check(ctx, withIP(addr, ip), endpoint)
+		})
+		ipCheck := IPCheck{IP: ip, Status: StatusLive, Latency: time.Since(start).Milliseconds(), OutgoingIP: outgoingIP}
+		if err != nil {
+			ipCheck.Status = StatusDead
+			ipCheck.OutgoingIP = ""
+			ipCheck.Error = err.Error()
+		}
+		result.IPChecks = append(result.IPChecks, ipCheck)
+	}
+}
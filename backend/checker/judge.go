@@ -0,0 +1,91 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// markupTags are tags a plain-text judge never returns; a body containing one is a page
+// injected along the way, typically an ISP login or upsell page on mobile carriers
+var markupTags = []string{"<html", "<!doctype", "<head", "<body", "<title", "<meta", "<script", "<form", "<iframe"}
+
+var (
+	ErrInvalidOutgoingIP = fmt.Errorf("%w: invalid outgoing IP", ErrBadJudgeResponse)
+)
+
+// titlePattern extracts the title of a hijacked page for the error message
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// parseJudgeBody returns the outgoing IP from the body of a judge response
+func parseJudgeBody(body []byte) (string, error) {
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		return "", ErrEmptyResponse
+	}
+
+	if isHijackPage(text) {
+		return "", fmt.Errorf("%w: %s", ErrHijacked, pageLabel(text))
+	}
+
+	// Anything else that is not an address is garbage, not a live proxy
+	ip := net.ParseIP(text)
+	if ip == nil {
+		return "", fmt.Errorf("%w: %q is not an IP address", ErrInvalidOutgoingIP, truncate(text, 40))
+	}
+
+	return ip.String(), nil
+}
+
+// IPVersion returns 4 or 6 for an IPv4 or IPv6 address, or 0 if ip is not an address
+// IPv4-mapped IPv6 addresses count as IPv4
+func IPVersion(ip string) int {
+	parsed := net.ParseIP(ip)
+	switch {
+	case parsed == nil:
+		return 0
+	case parsed.To4() != nil:
+		return 4
+	default:
+		return 6
+	}
+}
+
+// isHijackPage reports whether a judge body is an HTML page instead of an IP
+func isHijackPage(text string) bool {
+	lower := strings.ToLower(text)
+	for _, tag := range markupTags {
+		if strings.Contains(lower, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageLabel describes a hijacked page by its title, or its first characters
+func pageLabel(text string) string {
+	if m := titlePattern.FindStringSubmatch(text); m != nil {
+		if title := strings.Join(strings.Fields(m[1]), " "); title != "" {
+			return fmt.Sprintf("page titled %q", truncate(title, 80))
+		}
+	}
+	return fmt.Sprintf("page starting with %q", truncate(strings.Join(strings.Fields(text), " "), 80))
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "..."
+}
@@ -9,7 +9,12 @@
 package checker
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,12 +34,61 @@ const (
 
 // ProxyCheckRequest represents a request to check proxies
 type ProxyCheckRequest struct {
-	ProxyList     []string  // List of proxies to check (ip:port format)
-	ProxyType     ProxyType // Type of proxies to check
-	Endpoint      string    // Endpoint to check against
-	Threads       int       // Number of threads to use
-	UpstreamProxy string    // Optional upstream proxy (ip:port format)
-	UpstreamType  ProxyType // Type of upstream proxy
+	ProxyList     []string          // List of proxies to check (ip:port format)
+	ProxyType     ProxyType         // Type of proxies to check
+	Endpoint      string            // Endpoint to check against
+	Threads       int               // Number of threads to use
+	UpstreamProxy string            // Optional upstream proxy (ip:port format)
+	UpstreamType  ProxyType         // Type of upstream proxy
+	Sources       map[string]string // Optional origin of each proxy (proxy -> source label)
+	Priorities    map[string]int    // Optional queue priority of each proxy (higher is checked first)
+	Order         OrderMode         // Order of proxies with equal priority
+	MaxLatency    int64             // Live proxies slower than this (ms) are classified as slow; 0 disables
+	// DefaultSource is the source of proxies missing from Sources, e.g. the file a streamed list is read from
+	DefaultSource string
+	// WatchdogFactor abandons a check running longer than the check timeout times this factor; 0 uses DefaultWatchdogFactor
+	WatchdogFactor float64
+	// Merge keeps the results of previous runs, replacing the rows of rechecked proxies in place
+	Merge bool
+	// Metadata is the optional purchase information of each proxy, copied into its result
+	Metadata map[string]Metadata
+	// Geolocate optionally fills the location fields of a result from its outgoing IP,
+	// or the proxy host for proxies that did not answer
+	Geolocate func(result *ProxyResult)
+	// Enrich optionally adds information about the exit of live and slow results
+	Enrich func(ctx context.Context, result *ProxyResult)
+	// Probes enables advanced diagnostics run against live and slow proxies
+	Probes ProbeOptions
+	// SourceAddress binds the connections of the checks to an IP address or network interface
+	SourceAddress string
+	// BandwidthLimit caps the combined traffic of the checks (bytes per second); 0 is unlimited
+	BandwidthLimit int64
+	// Credentials optionally returns the login of a proxy, used by the checks but never
+	// stored in its result
+	Credentials func(proxy string) (username string, password string, ok bool)
+	// CacheBust appends a unique token to the endpoint of every check and flags responses
+	// served from a cache anyway
+	CacheBust bool
+	// TypeCache optionally remembers the types detected in Auto mode across runs
+	TypeCache *TypeCache
+	// FallbackEndpoints are switched to in turn when the endpoint keeps failing mid-run;
+	// the proxies that failed because of it are checked again
+	FallbackEndpoints []string
+	// FailoverThreshold is the number of consecutive judge failures that trigger a failover;
+	// 0 uses DefaultFailoverThreshold
+	FailoverThreshold int
+	// CheckEachIP checks every address a hostname proxy resolves to separately, in addition
+	// to the proxy itself
+	CheckEachIP bool
+	// ReferenceEndpoint is the judge a proxy refusing the endpoint is checked against; a proxy
+	// reaching it is classified as blocked instead of dead. Empty disables the comparison
+	ReferenceEndpoint string
+	// ExpectedRate is the checks per second of past runs at the same thread count, blended
+	// into the time estimate while the run ramps up; 0 estimates from the run alone
+	ExpectedRate float64
+	// SOCKS4Ident is the userid sent to SOCKS4 proxies; SOCKS4Idents overrides it per proxy
+	SOCKS4Ident  string
+	SOCKS4Idents map[string]string
 }
 
 // ProxyResult represents the result of a proxy check (result.go)
@@ -64,14 +118,30 @@ type Manager struct {
 	workingMutex      sync.Mutex
 	running           bool
 	paused            bool
+	stopping          bool
+	cancel            context.CancelFunc
+	jobs              *JobQueue
 	results           []ProxyResult
+	merged            map[string]int // position of each proxy in results while merging
 	working           []string
-	stats             Stats
+	tracker           *StatsTracker
 	stopChan          chan struct{}
 	pauseChan         chan struct{}
 	resumeChan        chan struct{}
 	workerCount       int
 	pausedWorkerCount int32
+	activeLimit       int           // workers allowed to take new proxies, 0 for all of them
+	throttleChan      chan struct{} // closed when activeLimit changes
+	onComplete        func()
+	onResult          func(ProxyResult)
+	onPanic           func(PanicInfo)
+}
+
+// PanicInfo describes a panic recovered while checking a single proxy
+type PanicInfo struct {
+	Proxy string `json:"proxy"`
+	Value string `json:"value"`
+	Stack string `json:"stack"`
 }
 
 // NewManager creates a new proxy checker manager
@@ -110,170 +180,185 @@ func (m *Manager) ResetPausedWorkerCount() {
 // NewManager creates a new proxy checker manager
 func NewManager() *Manager {
 	return &Manager{
-		stopChan:   make(chan struct{}),
-		pauseChan:  make(chan struct{}),
-		resumeChan: make(chan struct{}),
-		stats: Stats{
-			TypeCounts: make(map[ProxyType]int),
-		},
-		results: make([]ProxyResult, 0),
-		mutex:   sync.Mutex{},
+		stopChan:     make(chan struct{}),
+		pauseChan:    make(chan struct{}),
+		resumeChan:   make(chan struct{}),
+		throttleChan: make(chan struct{}),
+		tracker:      NewStatsTracker(),
+		results:      make([]ProxyResult, 0),
+		mutex:        sync.Mutex{},
 	}
 }
 
 // Start begins checking proxies with the given request
 func (m *Manager) Start(req ProxyCheckRequest, logCb func(string), updateCb func()) {
+	m.TryStart(req, logCb, updateCb)
+}
+
+// TryStart begins checking proxies like Start and reports whether the run started; it
+// does not if another one is in progress. The workers run in the background
+func (m *Manager) TryStart(req ProxyCheckRequest, logCb func(string), updateCb func()) bool {
+	// Create work queue, highest priority first, then in the requested order
+	jobs := NewJobQueue(OrderProxies(req.ProxyList, req.Order, req.Sources), req.Priorities)
+
+	ctx, ok := m.begin(req, jobs, logCb)
+	if !ok {
+		return false
+	}
+
+	m.run(ctx, req, jobs, logCb, updateCb)
+	return true
+}
+
+// StartStream begins checking proxies while feed is still producing them, so a huge
+// list never has to be held in memory. feed calls add for every proxy and should return
+// once add reports false, which means the run was stopped. req.ProxyList and req.Order
+// are ignored; the total grows as proxies are added. It reports whether the run started;
+// it does not if another one is in progress
+func (m *Manager) StartStream(req ProxyCheckRequest, feed func(add func(proxy string) bool) error, logCb func(string), updateCb func()) bool {
+	req.ProxyList = nil
+
+	// Only a few proxies per worker are buffered; the feed waits for room
+	jobs := NewStreamingQueue(streamBufferPerThread * req.Threads)
+
+	ctx, ok := m.begin(req, jobs, logCb)
+	if !ok {
+		return false
+	}
+
+	go func() {
+		err := feed(func(proxy string) bool {
+			m.tracker.AddPending(req.sourceOf(proxy))
+			return jobs.Push(proxy, req.Priorities[proxy])
+		})
+		jobs.Close()
+		if err != nil {
+			logCb("Streaming input failed: " + err.Error())
+		}
+		updateCb()
+	}()
+
+	m.run(ctx, req, jobs, logCb, updateCb)
+	return true
+}
+
+// streamBufferPerThread is the number of proxies buffered per worker in a streamed run
+const streamBufferPerThread = 4
+
+// begin resets the manager for a new run; ok is false if a run is already in progress
+func (m *Manager) begin(req ProxyCheckRequest, jobs *JobQueue, logCb func(string)) (ctx context.Context, ok bool) {
 	m.mutex.Lock()
 	if m.running {
 		m.mutex.Unlock()
 		logCb("Check already in progress")
-		return
+		return nil, false
 	}
 
 	// Reset state
+	ctx, cancel := context.WithCancel(context.Background())
 	m.running = true
 	m.paused = false
-	m.results = []ProxyResult{}
-	m.working = []string{}
-	m.stats = Stats{
-		Total:       len(req.ProxyList),
-		Pending:     len(req.ProxyList),
-		TypeCounts:  make(map[ProxyType]int),
-		ThreadCount: req.Threads,
-	}
+	m.stopping = false
+	m.cancel = cancel
+	m.jobs = jobs
+	m.resetLocked(req)
 	m.workerCount = req.Threads
 	m.stopChan = make(chan struct{})
 	m.pauseChan = make(chan struct{})
 	m.resumeChan = make(chan struct{})
+	m.activeLimit = 0
+	m.throttleChan = make(chan struct{})
 	m.ResetPausedWorkerCount()
 	m.mutex.Unlock()
+
+	if req.SourceAddress != "" {
+		ctx = withSourceAddr(ctx, req.SourceAddress)
+	}
+
+	// All workers share one limiter, so the cap applies to the run as a whole
+	if req.BandwidthLimit > 0 {
+		ctx = withBandwidthLimiter(ctx, NewBandwidthLimiter(req.BandwidthLimit))
+	}
 	logThgreadCount := fmt.Sprintf("Total worker threads: %d", req.Threads)
 
 	logCb(logThgreadCount)
 	logCb("Starting proxy check with " + string(req.ProxyType) + " type")
+	return ctx, true
+}
 
-	// Create work queue
-	jobs := make(chan string, len(req.ProxyList))
-	for _, proxy := range req.ProxyList {
-		jobs <- proxy
-	}
-	close(jobs)
+// run starts the workers checking the proxies of jobs and the completion watcher
+func (m *Manager) run(ctx context.Context, req ProxyCheckRequest, jobs *JobQueue, logCb func(string), updateCb func()) {
+	failover := newEndpointFailover(req.Endpoint, req.FallbackEndpoints, req.FailoverThreshold)
+
+	// Closed once the queue runs dry so that workers parked by the active worker limit exit too
+	drained := make(chan struct{})
+	var drainOnce sync.Once
 
 	// Create wait group for workers
 	var wg sync.WaitGroup
 	wg.Add(req.Threads)
 
-	// Track total latency for average calculation
-	var totalLatency int64
-	var liveCount int
-	var latencyMutex sync.Mutex
-
 	// Start worker goroutines
 	for i := 0; i < req.Threads; i++ {
 		go func(id int) {
 			defer wg.Done()
 
-			for proxy := range jobs {
+			for {
+				if !m.waitActive(id, drained) {
+					return
+				}
+
+				proxy, ok := jobs.Pop()
+				if !ok {
+					drainOnce.Do(func() { close(drained) })
+					return
+				}
+
+				stopChan, pauseChan, resumeChan := m.channels()
 				select {
-				case <-m.stopChan:
+				case <-stopChan:
 					return
-				case <-m.pauseChan:
+				case <-pauseChan:
+					m.IncrementPausedWorkerCount()
 					logCb(fmt.Sprintf("Worker %d paused", id))
 					select {
-					case <-m.resumeChan:
+					case <-resumeChan:
 						logCb(fmt.Sprintf("Worker %d resumed", id))
-					case <-m.stopChan:
+					case <-stopChan:
 						return
 					}
 				default:
-					// Check proxy
-					logCb("Checking proxy: " + proxy)
-
-					// Determine proxy type
-					proxyType := req.ProxyType
-					defaultTimeout := 10 * time.Second
-					if proxyType == Auto {
-						// Auto-detect proxy type
-						detectedType, err := DetectProxyType(proxy, defaultTimeout)
-						if err != nil {
-							logCb("Auto-detection failed for " + proxy + ": " + err.Error())
-							proxyType = HTTP
-						} else {
-							proxyType = detectedType
-							logCb("Auto-detected " + proxy + " as " + string(proxyType))
-						}
-					}
-
-					// Perform the check
-					start := time.Now()
-					result := ProxyResult{
-						Proxy: proxy,
-						Type:  proxyType,
-					}
-
-					// Check the proxy based on its type
-					var err error
-					var outgoingIP string
-
-					switch proxyType {
-					case HTTP:
-						outgoingIP, err = CheckHTTP(proxy, req.Endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
-					case HTTPS:
-						outgoingIP, err = CheckHTTPS(proxy, req.Endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
-					case SOCKS4:
-						outgoingIP, err = CheckSOCKS4(proxy, req.Endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
-					case SOCKS5:
-						outgoingIP, err = CheckSOCKS5(proxy, req.Endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
-					default:
-						err = fmt.Errorf("unsupported proxy type: %s", proxyType)
-					}
-
-					// Calculate latency
-					result.Latency = time.Since(start).Milliseconds()
-
-					// Set result status based on check outcome
-					if err != nil {
-						result.Status = "DEAD"
-						result.Error = err.Error()
-					} else {
-						result.Status = "LIVE"
-						result.OutgoingIP = outgoingIP
-
-						// Update latency stats
-						latencyMutex.Lock()
-						totalLatency += result.Latency
-						liveCount++
-						latencyMutex.Unlock()
-					}
-
-					// Update results and stats
-					m.mutex.Lock()
-					m.results = append(m.results, result)
-
-					// Update stats
-					if result.Status == "LIVE" {
-						m.stats.Live++
-						m.workingMutex.Lock()
-						m.working = append(m.working, proxy)
-						m.workingMutex.Unlock()
-					} else if result.Status == "DEAD" {
-						m.stats.Dead++
-					} else {
-						m.stats.Errors++
-					}
+				}
 
-					m.stats.TypeCounts[proxyType]++
+				// The proxy taken before pausing is checked after resuming, never dropped
+				m.tracker.UpdateWithResult(&ProxyResult{Proxy: proxy, Status: StatusChecking})
+				checkReq := req
+				checkReq.Endpoint = failover.endpoint()
+				result := m.safeCheckProxy(ctx, checkReq, proxy, logCb)
+
+				// Update results and stats
+				m.mutex.Lock()
+				m.recordLocked(result)
+				m.mutex.Unlock()
+
+				if result.Status == "LIVE" {
+					m.workingMutex.Lock()
+					m.working = append(m.working, proxy)
+					m.workingMutex.Unlock()
+				}
+				m.tracker.UpdateWithResult(&result)
+				m.notifyResult(result)
 
-					// Calculate average speed
-					if liveCount > 0 {
-						m.stats.AverageSpeed = totalLatency / int64(liveCount)
+				// Proxies that failed because of the endpoint are checked again against the next one
+				if recheck, next := failover.record(checkReq.Endpoint, result); len(recheck) > 0 {
+					if next != "" {
+						logCb(fmt.Sprintf("Endpoint %s failed for %d proxies in a row, failing over to %s", checkReq.Endpoint, len(recheck), next))
 					}
-
-					m.mutex.Unlock()
-
-					// Notify UI
-					updateCb()
+					m.requeue(jobs, req.Priorities, recheck)
 				}
+
+				// Notify UI
+				updateCb()
 			}
 		}(i)
 	}
@@ -282,15 +367,589 @@ func (m *Manager) Start(req ProxyCheckRequest, logCb func(string), updateCb func
 	go func() {
 		wg.Wait()
 		m.mutex.Lock()
+		cancel := m.cancel
+		m.mutex.Unlock()
+		cancel()
+		m.tracker.Finish()
+		m.mutex.Lock()
 		m.running = false
 		m.paused = false
+		m.stopping = false
 		m.mutex.Unlock()
 		logCb("Proxy check completed")
 		updateCb()
+
+		m.mutex.Lock()
+		onComplete := m.onComplete
+		m.mutex.Unlock()
+		if onComplete != nil {
+			onComplete()
+		}
 	}()
 }
 
+// sourceOf returns the import source of a proxy
+func (req ProxyCheckRequest) sourceOf(proxy string) string {
+	if source, ok := req.Sources[proxy]; ok {
+		return source
+	}
+	return req.DefaultSource
+}
+
+// identOf returns the SOCKS4 userid of a proxy
+func (req ProxyCheckRequest) identOf(proxy string) string {
+	if ident, ok := req.SOCKS4Idents[proxy]; ok {
+		return ident
+	}
+	return req.SOCKS4Ident
+}
+
+// checkProxy checks a single proxy and returns its result
+// Proxies that cannot be checked at all (unsupported type, malformed address) get an error status
+func (m *Manager) checkProxy(ctx context.Context, req ProxyCheckRequest, proxy string, logCb func(string)) ProxyResult {
+	logCb("Checking proxy: " + proxy)
+	if ident := req.identOf(proxy); ident != "" {
+		ctx = WithSOCKS4Ident(ctx, ident)
+	}
+
+	// Determine proxy type
+	proxyType := req.ProxyType
+	defaultTimeout := DefaultCheckTimeout
+	cachedType := false
+	if proxyType == Auto && req.TypeCache != nil {
+		proxyType, cachedType = req.TypeCache.Get(proxy)
+		if cachedType {
+			logCb("Using the cached type of " + proxy + ": " + string(proxyType))
+		} else {
+			proxyType = Auto
+		}
+	}
+	if proxyType == Auto {
+		// Auto-detect proxy type
+		detectedType, err := DetectProxyTypeContext(ctx, proxy, defaultTimeout)
+		if err != nil {
+			logCb("Auto-detection failed for " + proxy + ": " + err.Error())
+			proxyType = HTTP
+		} else {
+			proxyType = detectedType
+			logCb("Auto-detected " + proxy + " as " + string(proxyType))
+			if req.TypeCache != nil {
+				req.TypeCache.Put(proxy, proxyType)
+			}
+		}
+	}
+
+	// Perform the check
+	start := time.Now()
+	result := ProxyResult{
+		Proxy:  proxy,
+		Type:   proxyType,
+		Source: req.sourceOf(proxy),
+	}
+	if md, ok := req.Metadata[proxy]; ok {
+		result.Metadata = &md
+	}
+
+	// Check the proxy based on its type
+	// The watchdog abandons checks that hang despite their timeouts
+	// The Date header of the judge response tells the clock skew along the proxy path,
+	// its cache headers whether a middlebox answered instead of the judge
+	limit := watchdogLimit(defaultTimeout, req.WatchdogFactor)
+	clock := &judgeClock{}
+	cache := &cacheDetector{}
+	traffic := &byteCounter{}
+	ctx = withByteCounter(ctx, traffic)
+	endpoint := req.Endpoint
+	if req.CacheBust {
+		endpoint, _ = cacheBust(endpoint)
+	}
+	checkAddr := proxy
+	if req.Credentials != nil {
+		if username, password, ok := req.Credentials(proxy); ok {
+			checkAddr = WithProxyAuth(proxy, username, password)
+		}
+	}
+	// The address a hostname proxy was dialed at is recorded, unless an upstream is dialed instead
+	hostname := proxyHostname(proxy)
+	var dialed *dialRecorder
+	if hostname != "" && req.UpstreamProxy == "" {
+		dialed = &dialRecorder{}
+		ctx = withDialRecorder(ctx, dialed)
+	}
+	check := func(ctx context.Context, checkAddr string, endpoint string) (string, error) {
+		switch proxyType {
+		case HTTP:
+			return CheckHTTPContext(ctx, checkAddr, endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
+		case HTTPS:
+			return CheckHTTPSContext(ctx, checkAddr, endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
+		case SOCKS4:
+			return CheckSOCKS4Context(ctx, checkAddr, endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
+		case SOCKS5:
+			return CheckSOCKS5Context(ctx, checkAddr, endpoint, defaultTimeout, req.UpstreamProxy, req.UpstreamType)
+		default:
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedProxyType, proxyType)
+		}
+	}
+	outgoingIP, fired, err := runWatched(ctx, limit, func(ctx context.Context) (string, error) {
+		ctx = WithResponseObserver(ctx, func(resp *http.Response) {
+			clock.observe(resp)
+			cache.observe(resp)
+		})
+		return check(ctx, checkAddr, endpoint)
+	})
+	if fired {
+		logCb(fmt.Sprintf("Watchdog abandoned the check of %s after %s", proxy, limit))
+	}
+	if dialed != nil {
+		result.DialedIP = dialed.ip()
+	}
+
+	// A proxy refusing the endpoint may only block that destination; it is told apart from
+	// a dead proxy by checking it against the reference judge as well
+	if err != nil && refusedDestination(err) && req.ReferenceEndpoint != "" && req.ReferenceEndpoint != req.Endpoint {
+		refIP, _, refErr := runWatched(ctx, limit, func(ctx context.Context) (string, error) {
+			return check(ctx, checkAddr, req.ReferenceEndpoint)
+		})
+		if refErr == nil {
+			logCb(fmt.Sprintf("%s refused the endpoint but reached the reference judge, its ACL blocks the destination", proxy))
+			err = fmt.Errorf("%w: %w", ErrDestinationBlocked, err)
+			outgoingIP = refIP
+		}
+	}
+
+	// Calculate latency; the timestamp tells merged rows of different runs apart
+	result.Latency = time.Since(start).Milliseconds()
+	result.Timestamp = time.Now()
+
+	// Set result status based on check outcome
+	switch {
+	case err != nil && errors.Is(err, ErrAborted):
+		result.Status = "ABORTED"
+		result.Error = err.Error()
+		result.ErrorKind = ErrorKind(err)
+	case err != nil && errors.Is(err, ErrDestinationBlocked):
+		result.Status = "BLOCKED"
+		result.Error = err.Error()
+		result.ErrorKind = ErrorKind(err)
+		result.Reply = ReplyOf(err)
+		result.OutgoingIP = outgoingIP
+		result.IPVersion = IPVersion(outgoingIP)
+	case err != nil && isCheckError(err):
+		result.Status = "ERROR"
+		result.Error = err.Error()
+		result.ErrorKind = ErrorKind(err)
+	case err != nil:
+		result.Status = "DEAD"
+		result.Error = err.Error()
+		result.ErrorKind = ErrorKind(err)
+		result.Reply = ReplyOf(err)
+		// The proxy may have changed protocol since its type was cached
+		if cachedType {
+			req.TypeCache.Forget(proxy)
+		}
+	default:
+		result.Status = "LIVE"
+		result.OutgoingIP = outgoingIP
+		result.IPVersion = IPVersion(outgoingIP)
+		clock.apply(&result)
+		cache.apply(&result)
+		if result.StaleResponse {
+			logCb(fmt.Sprintf("Judge response through %s is dated %ds in the past, it may come from a cache", proxy, -result.ClockSkew))
+		}
+		if result.Cached {
+			logCb(fmt.Sprintf("Judge response through %s was served from a cache (%s)", proxy, result.CacheEvidence))
+		}
+		if req.MaxLatency > 0 && result.Latency > req.MaxLatency {
+			result.Status = "SLOW"
+		}
+	}
+
+	if hostname != "" && !errors.Is(err, ErrAborted) {
+		resolveResult(ctx, &result, hostname, checkAddr, endpoint, check, req.CheckEachIP, limit, defaultTimeout)
+	}
+
+	if req.Geolocate != nil {
+		req.Geolocate(&result)
+	}
+	if req.Enrich != nil && (result.Status == "LIVE" || result.Status == "SLOW") {
+		req.Enrich(ctx, &result)
+	}
+	if req.Probes.enabled() && (result.Status == "LIVE" || result.Status == "SLOW") {
+		result.Probes = runProbes(ctx, req, checkAddr, proxyType, defaultTimeout)
+	}
+	result.Bytes = traffic.total()
+
+	return result
+}
+
+// safeCheckProxy runs checkProxy, turning a panic into an error result so one
+// misbehaving check does not take down the whole run
+func (m *Manager) safeCheckProxy(ctx context.Context, req ProxyCheckRequest, proxy string, logCb func(string)) (result ProxyResult) {
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+
+		stack := debug.Stack()
+		if p, ok := value.(*checkPanic); ok {
+			value, stack = p.value, p.stack
+		}
+
+		err := fmt.Errorf("%w: %v", ErrCheckPanic, value)
+		result = ProxyResult{
+			Proxy:     proxy,
+			Type:      req.ProxyType,
+			Status:    "ERROR",
+			Error:     err.Error(),
+			ErrorKind: ErrorKind(err),
+			Source:    req.sourceOf(proxy),
+		}
+		logCb(fmt.Sprintf("Check of %s panicked: %v", proxy, value))
+
+		m.mutex.Lock()
+		onPanic := m.onPanic
+		m.mutex.Unlock()
+		if onPanic != nil {
+			onPanic(PanicInfo{Proxy: proxy, Value: fmt.Sprint(value), Stack: string(stack)})
+		}
+	}()
+
+	return m.checkProxy(ctx, req, proxy, logCb)
+}
+
+// refusedDestination returns true if the proxy answered a check with a refusal that may be
+// specific to the destination, as opposed to a login or ident problem
+func refusedDestination(err error) bool {
+	return ReplyOf(err) != nil && !errors.Is(err, ErrAuthRequired) && !errors.Is(err, ErrIdentRejected)
+}
+
+// isCheckError reports whether a check failed before the proxy could be contacted,
+// as opposed to the proxy not working
+func isCheckError(err error) bool {
+	return errors.Is(err, ErrInvalidProxyFormat) || errors.Is(err, ErrUnsupportedProxyType) ||
+		errors.Is(err, ErrUpstreamNotSupported) || errors.Is(err, ErrSourceAddress)
+}
+
+// channels returns the current stop, pause and resume channels
+func (m *Manager) channels() (stop, pause, resume chan struct{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.stopChan, m.pauseChan, m.resumeChan
+}
+
+// waitActive parks worker id while it is above the active worker limit; it reports false
+// if the run stopped meanwhile. Parked workers count as paused when the run is paused
+func (m *Manager) waitActive(id int, drained chan struct{}) bool {
+	for {
+		m.mutex.Lock()
+		limit, changed := m.activeLimit, m.throttleChan
+		m.mutex.Unlock()
+		if limit == 0 || id < limit {
+			return true
+		}
+
+		stopChan, pauseChan, resumeChan := m.channels()
+		select {
+		case <-stopChan:
+			return false
+		case <-drained:
+			return true
+		case <-pauseChan:
+			m.IncrementPausedWorkerCount()
+			select {
+			case <-resumeChan:
+			case <-stopChan:
+				return false
+			}
+		case <-changed:
+		}
+	}
+}
+
+// SetActiveWorkers limits the workers taking new proxies to n, e.g. to back off under CPU
+// pressure; the others finish their current check and wait. 0, or n at or above the thread
+// count, activates all workers again
+func (m *Manager) SetActiveWorkers(n int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if n < 0 || n >= m.workerCount {
+		n = 0
+	}
+	if n == m.activeLimit {
+		return
+	}
+	m.activeLimit = n
+	close(m.throttleChan)
+	m.throttleChan = make(chan struct{})
+}
+
+// ActiveWorkers returns the number of workers taking new proxies
+func (m *Manager) ActiveWorkers() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.activeLimit == 0 {
+		return m.workerCount
+	}
+	return m.activeLimit
+}
+
+// resetLocked clears results and prepares statistics for a new run (must be called with mutex locked)
+// With req.Merge, the previous results are kept and only the statistics start over
+func (m *Manager) resetLocked(req ProxyCheckRequest) {
+	m.merged = nil
+	if req.Merge {
+		m.merged = make(map[string]int, len(m.results))
+		for i, r := range m.results {
+			m.merged[r.Proxy] = i
+		}
+	} else {
+		m.results = []ProxyResult{}
+	}
+	m.working = []string{}
+	m.tracker.Reset(len(req.ProxyList))
+	m.tracker.SetThreadCount(req.Threads)
+	m.tracker.SetExpectedRate(req.ExpectedRate)
+	for _, proxy := range req.ProxyList {
+		m.tracker.AddSourceTotal(req.sourceOf(proxy))
+	}
+}
+
+// recordLocked adds a result, replacing the previous row of the proxy when merging
+// (must be called with mutex locked)
+func (m *Manager) recordLocked(result ProxyResult) {
+	if m.merged != nil {
+		if i, ok := m.merged[result.Proxy]; ok {
+			m.results[i] = result
+			return
+		}
+		m.merged[result.Proxy] = len(m.results)
+	}
+	m.results = append(m.results, result)
+}
+
+// RequeueSince returns the proxies of the running check completed at or after since to
+// its queue, undoing their results, e.g. when they were checked while the network changed
+// It returns the number of proxies to check again
+func (m *Manager) RequeueSince(since time.Time) int {
+	return m.requeueWhere(func(r ProxyResult) bool { return !r.Timestamp.Before(since) })
+}
+
+// RequeueSuspect returns the proxies of the running check whose results are suspect to its
+// queue, undoing their results. It returns the number of proxies to check again
+func (m *Manager) RequeueSuspect() int {
+	return m.requeueWhere(func(r ProxyResult) bool { return r.Suspect })
+}
+
+// requeueWhere returns the completed proxies of the running check matching keep to its queue
+func (m *Manager) requeueWhere(keep func(r ProxyResult) bool) int {
+	m.mutex.Lock()
+	if !m.running || m.jobs == nil {
+		m.mutex.Unlock()
+		return 0
+	}
+	jobs := m.jobs
+	var recheck []ProxyResult
+	for _, r := range m.results {
+		status := ProxyStatus(strings.ToLower(string(r.Status)))
+		if status != StatusPending && status != StatusChecking && keep(r) {
+			recheck = append(recheck, r)
+		}
+	}
+	m.mutex.Unlock()
+
+	if !m.requeue(jobs, nil, recheck) {
+		return 0
+	}
+	return len(recheck)
+}
+
+// MarkSuspect flags the failed results completed at or after since as suspect, e.g. when
+// they were checked while the system went to sleep. It returns the number of flagged results
+func (m *Manager) MarkSuspect(since time.Time) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	n := 0
+	for i := range m.results {
+		r := &m.results[i]
+		status := ProxyStatus(strings.ToLower(string(r.Status)))
+		if status == StatusDead || status == StatusError {
+			if !r.Suspect && !r.Timestamp.Before(since) {
+				r.Suspect = true
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// requeue returns checked proxies to the queue with their priorities, undoing their results
+// Once the run is stopping the workers no longer take proxies from the queue, so the
+// results are kept and false is returned
+func (m *Manager) requeue(jobs *JobQueue, priorities map[string]int, results []ProxyResult) bool {
+	m.mutex.Lock()
+	if m.stopping {
+		m.mutex.Unlock()
+		return false
+	}
+	for _, result := range results {
+		m.unrecordLocked(result)
+	}
+	m.mutex.Unlock()
+
+	m.workingMutex.Lock()
+	for _, result := range results {
+		if result.Status != "LIVE" {
+			continue
+		}
+		for i, proxy := range m.working {
+			if proxy == result.Proxy {
+				m.working = append(m.working[:i], m.working[i+1:]...)
+				break
+			}
+		}
+	}
+	m.workingMutex.Unlock()
+
+	for i := range results {
+		m.tracker.Requeue(&results[i])
+		jobs.Requeue(results[i].Proxy, priorities[results[i].Proxy])
+	}
+	return true
+}
+
+// unrecordLocked removes the row of a result about to be checked again; when merging, the
+// row is kept as pending since the previous result it replaced is gone
+// (must be called with mutex locked)
+func (m *Manager) unrecordLocked(result ProxyResult) {
+	if m.merged != nil {
+		if i, ok := m.merged[result.Proxy]; ok {
+			m.results[i] = *NewPendingResult(result.Proxy, result.Type)
+		}
+		return
+	}
+
+	for i := len(m.results) - 1; i >= 0; i-- {
+		if m.results[i].Proxy == result.Proxy {
+			m.results = append(m.results[:i], m.results[i+1:]...)
+			return
+		}
+	}
+}
+
+// PrepareExternalRun resets results and statistics for a run whose results are
+// delivered with AppendResults (e.g. by remote agents) instead of local workers
+func (m *Manager) PrepareExternalRun(req ProxyCheckRequest) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.running {
+		return false
+	}
+
+	m.resetLocked(req)
+	return true
+}
+
+// AppendResults records results produced outside of the local workers
+func (m *Manager) AppendResults(results []ProxyResult) {
+	m.mutex.Lock()
+	for _, result := range results {
+		m.recordLocked(result)
+
+		if strings.EqualFold(string(result.Status), "LIVE") {
+			m.workingMutex.Lock()
+			m.working = append(m.working, result.Proxy)
+			m.workingMutex.Unlock()
+		}
+
+		m.tracker.UpdateWithResult(&result)
+	}
+	m.mutex.Unlock()
+
+	for _, result := range results {
+		m.notifyResult(result)
+	}
+}
+
+// Reclassify applies a new latency threshold to the existing results without rechecking
+// Live proxies slower than maxLatency become slow and slow proxies within it become live
+// again; a maxLatency of 0 marks every slow proxy live. It returns the number of changed results
+func (m *Manager) Reclassify(maxLatency int64) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	changed := 0
+	working := []string{}
+	for i := range m.results {
+		r := &m.results[i]
+
+		status := strings.ToUpper(string(r.Status))
+		if status != "LIVE" && status != "SLOW" {
+			continue
+		}
+
+		next := ProxyStatus("LIVE")
+		if maxLatency > 0 && r.Latency > maxLatency {
+			next = "SLOW"
+		}
+		if ProxyStatus(status) != next {
+			r.Status = next
+			changed++
+			m.tracker.MoveStatus(ProxyStatus(status), next)
+		}
+
+		if next == "LIVE" {
+			working = append(working, r.Proxy)
+		}
+	}
+
+	m.workingMutex.Lock()
+	m.working = working
+	m.workingMutex.Unlock()
+
+	return changed
+}
+
+// SetResultHandler sets a function called with every completed check
+func (m *Manager) SetResultHandler(handler func(ProxyResult)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.onResult = handler
+}
+
+// notifyResult passes a completed check to the result handler, if any
+func (m *Manager) notifyResult(result ProxyResult) {
+	m.mutex.Lock()
+	onResult := m.onResult
+	m.mutex.Unlock()
+
+	if onResult != nil {
+		onResult(result)
+	}
+}
+
+// SetPanicHandler sets a function called with every panic recovered from a check
+func (m *Manager) SetPanicHandler(handler func(PanicInfo)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.onPanic = handler
+}
+
+// SetCompletionHandler sets a function called once all workers of a run have finished
+func (m *Manager) SetCompletionHandler(handler func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.onComplete = handler
+}
+
 // Stop stops the current check operation
+// A graceful stop (force false) stops handing out proxies and lets the checks in flight
+// finish, so the final stats are accurate. A forced stop also cancels the checks in
+// flight, which are recorded as aborted. The run counts as running until every worker
+// has returned; the completion handler is called as usual
 func (m *Manager) Stop(force bool) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -299,19 +958,22 @@ func (m *Manager) Stop(force bool) {
 		return
 	}
 
-	// Check if stopChan is already closed
-	select {
-	case <-m.stopChan:
-		// Channel is already closed, create a new one for future use
-		m.stopChan = make(chan struct{})
-	default:
-		// Channel is still open, close it to signal workers to stop
+	if !m.stopping {
+		m.stopping = true
 		close(m.stopChan)
+		// Wake workers waiting for streamed input and refuse further input
+		m.jobs.Close()
 	}
+	if force {
+		m.cancel()
+	}
+}
 
-	m.running = false
-
-	// For graceful stop, the running flag will be set to false when all workers finish
+// IsStopping returns whether a stop was requested and the workers are winding down
+func (m *Manager) IsStopping() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.stopping
 }
 
 // Pause pauses the current check operation
@@ -319,7 +981,7 @@ func (m *Manager) Pause() bool {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if !m.running || m.paused {
+	if !m.running || m.paused || m.stopping {
 		return false
 	}
 
@@ -359,27 +1021,9 @@ func (m *Manager) IsPaused() bool {
 	return m.paused
 }
 
-// ForceStop immediately terminates all proxy checking operations
+// ForceStop immediately terminates all proxy checking operations, aborting the checks in flight
 func (m *Manager) ForceStop() {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	if !m.running {
-		return
-	}
-
-	// Close the stop channel to signal all workers to stop
-	close(m.stopChan)
-
-	// Reset channels
-	m.stopChan = make(chan struct{})
-	m.pauseChan = make(chan struct{})
-	m.resumeChan = make(chan struct{})
-
-	// Reset state
-	m.running = false
-	m.paused = false
-	atomic.StoreInt32(&m.pausedWorkerCount, 0)
+	m.Stop(true)
 }
 
 // ForcePause immediately pauses all proxy checking operations
@@ -387,7 +1031,7 @@ func (m *Manager) ForcePause() bool {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if !m.running || m.paused {
+	if !m.running || m.paused || m.stopping {
 		return false
 	}
 
@@ -395,11 +1039,9 @@ func (m *Manager) ForcePause() bool {
 	m.paused = true
 
 	// Close the pause channel to signal all workers to pause
+	// Resume replaces it with a fresh channel
 	close(m.pauseChan)
 
-	// Reset the pause channel for future use
-	m.pauseChan = make(chan struct{})
-
 	// Reset the paused worker count
 	atomic.StoreInt32(&m.pausedWorkerCount, int32(m.workerCount))
 
@@ -429,12 +1071,11 @@ func (m *Manager) ClearResults() {
 
 	// Clear results and working proxies
 	m.results = []ProxyResult{}
+	m.merged = nil
 	m.working = []string{}
 
 	// Reset statistics
-	m.stats = Stats{
-		TypeCounts: make(map[ProxyType]int),
-	}
+	m.tracker.Reset(0)
 }
 
 // GetWorkingProxies returns the list of working proxies
@@ -450,28 +1091,10 @@ func (m *Manager) ClearResults() {
 
 // GetStats returns the current statistics
 func (m *Manager) GetStats() Stats {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	// Return a copy to avoid race conditions
-	stats := Stats{
-		Total:        m.stats.Total,
-		Pending:      m.stats.Pending,
-		Live:         m.stats.Live,
-		Dead:         m.stats.Dead,
-		Errors:       m.stats.Errors,
-		AverageSpeed: m.stats.AverageSpeed,
-		TypeCounts:   make(map[ProxyType]int),
+	if m.IsRunning() {
+		m.tracker.UpdateElapsedTime()
 	}
-
-	for k, v := range m.stats.TypeCounts {
-		stats.TypeCounts[k] = v
-	}
-
-	// Recalculate pending count to ensure accuracy
-	stats.Pending = stats.Total - stats.Live - stats.Dead - stats.Errors
-
-	return stats
+	return m.tracker.GetStats()
 }
 
 // IsRunning returns whether a check is currently running
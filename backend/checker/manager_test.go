@@ -0,0 +1,359 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestProxy starts an HTTP proxy stand-in that answers every request with an IP after delay
+func newTestProxy(t *testing.T, delay time.Duration) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Write([]byte("203.0.113.7"))
+	}))
+	t.Cleanup(srv.Close)
+
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+// closedAddr returns a local address nothing is listening on
+func closedAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+// runCheck starts a check and returns a channel closed when it completes
+func runCheck(m *Manager, req ProxyCheckRequest) <-chan struct{} {
+	done := make(chan struct{})
+	m.SetCompletionHandler(func() { close(done) })
+	m.Start(req, func(string) {}, func() {})
+	return done
+}
+
+// waitDone waits for a run to complete
+func waitDone(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(20 * time.Second):
+		t.Fatal("check did not complete")
+	}
+}
+
+// assertConsistent checks that the counters add up to the total
+func assertConsistent(t *testing.T, stats Stats) {
+	t.Helper()
+
+	sum := stats.Live + stats.Slow + stats.Dead + stats.Errors + stats.Aborted + stats.Pending + stats.Checking
+	if sum != stats.Total {
+		t.Fatalf("counters do not add up: %+v", stats)
+	}
+}
+
+func TestManagerCompletedRun(t *testing.T) {
+	live := newTestProxy(t, 0)
+	dead := closedAddr(t)
+
+	m := NewManager()
+	done := runCheck(m, ProxyCheckRequest{
+		ProxyList: []string{live, live, dead, "not-a-proxy"},
+		ProxyType: HTTP,
+		Endpoint:  "http://judge.invalid/",
+		Threads:   2,
+	})
+	waitDone(t, done)
+
+	stats := m.GetStats()
+	assertConsistent(t, stats)
+	if stats.Live != 2 || stats.Dead != 1 || stats.Errors != 1 || stats.Pending != 0 {
+		t.Fatalf("got %+v, want 2 live, 1 dead, 1 error, 0 pending", stats)
+	}
+	if stats.AverageSpeed < 0 || stats.ElapsedTime <= 0 {
+		t.Errorf("unexpected timing stats %+v", stats)
+	}
+	if len(m.GetResults()) != 4 {
+		t.Errorf("got %d results, want 4", len(m.GetResults()))
+	}
+}
+
+func TestManagerUnsupportedTypeIsError(t *testing.T) {
+	m := NewManager()
+	done := runCheck(m, ProxyCheckRequest{
+		ProxyList: []string{"127.0.0.1:1"},
+		ProxyType: "bogus",
+		Threads:   1,
+	})
+	waitDone(t, done)
+
+	if stats := m.GetStats(); stats.Errors != 1 || stats.Dead != 0 {
+		t.Fatalf("got %+v, want 1 error", stats)
+	}
+}
+
+func TestManagerStopLeavesRestPending(t *testing.T) {
+	proxy := newTestProxy(t, 100*time.Millisecond)
+
+	list := make([]string, 20)
+	for i := range list {
+		list[i] = proxy
+	}
+
+	m := NewManager()
+	done := runCheck(m, ProxyCheckRequest{ProxyList: list, ProxyType: HTTP, Endpoint: "http://judge.invalid/", Threads: 2})
+
+	time.Sleep(250 * time.Millisecond)
+	m.Stop(false)
+	waitDone(t, done)
+
+	stats := m.GetStats()
+	assertConsistent(t, stats)
+	if stats.Live == 0 || stats.Pending == 0 {
+		t.Fatalf("expected some checked and some pending proxies, got %+v", stats)
+	}
+	if stats.Checking != 0 {
+		t.Errorf("no check should be in flight after stop, got %d", stats.Checking)
+	}
+	if stats.Live != len(m.GetResults()) {
+		t.Errorf("live count %d does not match %d results", stats.Live, len(m.GetResults()))
+	}
+	if stats.AverageSpeed < 100 {
+		t.Errorf("latency totals lost on stop, average %d ms", stats.AverageSpeed)
+	}
+}
+
+func TestManagerForceStopAbortsInFlight(t *testing.T) {
+	proxy := newTestProxy(t, 2*time.Second)
+
+	list := make([]string, 10)
+	for i := range list {
+		list[i] = proxy
+	}
+
+	m := NewManager()
+	done := runCheck(m, ProxyCheckRequest{ProxyList: list, ProxyType: HTTP, Endpoint: "http://judge.invalid/", Threads: 3})
+
+	time.Sleep(100 * time.Millisecond)
+	m.Stop(false)
+	if !m.IsRunning() || !m.IsStopping() {
+		t.Fatal("a graceful stop should wait for the checks in flight")
+	}
+
+	start := time.Now()
+	m.Stop(true)
+	waitDone(t, done)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("forced stop waited %s for checks in flight", elapsed)
+	}
+
+	stats := m.GetStats()
+	assertConsistent(t, stats)
+	if stats.Aborted != 3 || stats.Live != 0 || stats.Checking != 0 {
+		t.Fatalf("got %+v, want 3 aborted checks", stats)
+	}
+	for _, r := range m.GetResults() {
+		if r.ErrorKind != "aborted" {
+			t.Errorf("result %+v not marked aborted", r)
+		}
+	}
+}
+
+func TestManagerPauseResumeChecksEveryProxy(t *testing.T) {
+	proxy := newTestProxy(t, 50*time.Millisecond)
+
+	list := make([]string, 12)
+	for i := range list {
+		list[i] = proxy
+	}
+
+	m := NewManager()
+	done := runCheck(m, ProxyCheckRequest{ProxyList: list, ProxyType: HTTP, Endpoint: "http://judge.invalid/", Threads: 3})
+
+	time.Sleep(80 * time.Millisecond)
+	if !m.Pause() {
+		t.Fatal("pause failed")
+	}
+
+	// Let in-flight checks finish; no new checks may start while paused
+	time.Sleep(200 * time.Millisecond)
+	paused := m.GetStats()
+	assertConsistent(t, paused)
+	if paused.Checking != 0 {
+		t.Fatalf("checks still running while paused: %+v", paused)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if again := m.GetStats(); again.Live != paused.Live {
+		t.Fatalf("checks completed while paused: %d -> %d", paused.Live, again.Live)
+	}
+
+	if !m.Resume() {
+		t.Fatal("resume failed")
+	}
+	waitDone(t, done)
+
+	stats := m.GetStats()
+	assertConsistent(t, stats)
+	if stats.Live != len(list) || stats.Pending != 0 {
+		t.Fatalf("got %+v, want all %d proxies live after resume", stats, len(list))
+	}
+}
+
+func TestManagerStreamChecksEveryProxy(t *testing.T) {
+	proxy := newTestProxy(t, 0)
+
+	m := NewManager()
+	done := make(chan struct{})
+	m.SetCompletionHandler(func() { close(done) })
+
+	feed := func(add func(string) bool) error {
+		for i := 0; i < 50; i++ {
+			if !add(proxy) {
+				return nil
+			}
+		}
+		return nil
+	}
+	m.StartStream(ProxyCheckRequest{ProxyType: HTTP, Endpoint: "http://judge.invalid/", Threads: 3, DefaultSource: "file:dump.txt"},
+		feed, func(string) {}, func() {})
+	waitDone(t, done)
+
+	stats := m.GetStats()
+	assertConsistent(t, stats)
+	if stats.Total != 50 || stats.Live != 50 {
+		t.Fatalf("got %+v, want 50 live proxies", stats)
+	}
+	if ss := stats.SourceStats["file:dump.txt"]; ss.Total != 50 || ss.Live != 50 {
+		t.Errorf("source stats %+v", ss)
+	}
+}
+
+func TestManagerStopEndsStream(t *testing.T) {
+	proxy := newTestProxy(t, 20*time.Millisecond)
+
+	m := NewManager()
+	done := make(chan struct{})
+	m.SetCompletionHandler(func() { close(done) })
+
+	fed := make(chan int, 1)
+	feed := func(add func(string) bool) error {
+		n := 0
+		for add(proxy) {
+			n++
+		}
+		fed <- n
+		return nil
+	}
+	m.StartStream(ProxyCheckRequest{ProxyType: HTTP, Endpoint: "http://judge.invalid/", Threads: 2}, feed, func(string) {}, func() {})
+
+	time.Sleep(100 * time.Millisecond)
+	m.Stop(false)
+	waitDone(t, done)
+
+	select {
+	case n := <-fed:
+		if n > 100 {
+			t.Errorf("feed ran ahead of the workers: %d proxies queued", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("feed was not stopped")
+	}
+	assertConsistent(t, m.GetStats())
+}
+
+func TestManagerMergeKeepsPreviousResults(t *testing.T) {
+	live := newTestProxy(t, 0)
+	dead := closedAddr(t)
+
+	m := NewManager()
+	waitDone(t, runCheck(m, ProxyCheckRequest{
+		ProxyList: []string{live, dead},
+		ProxyType: HTTP,
+		Endpoint:  "http://judge.invalid/",
+		Threads:   2,
+	}))
+	first := m.GetResults()
+
+	waitDone(t, runCheck(m, ProxyCheckRequest{
+		ProxyList: []string{live},
+		ProxyType: HTTP,
+		Endpoint:  "http://judge.invalid/",
+		Threads:   1,
+		Merge:     true,
+	}))
+
+	results := m.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for i, r := range results {
+		if r.Proxy != first[i].Proxy {
+			t.Errorf("row %d moved: got %s, want %s", i, r.Proxy, first[i].Proxy)
+		}
+		if r.Proxy == live && !r.Timestamp.After(first[i].Timestamp) {
+			t.Errorf("row of %s was not updated", live)
+		}
+	}
+
+	if stats := m.GetStats(); stats.Total != 1 || stats.Live != 1 {
+		t.Errorf("got %+v, want stats of the merged run only", stats)
+	}
+}
+
+func TestManagerFailsOverToNextEndpoint(t *testing.T) {
+	// The proxy stand-in reaches the fallback judge but the primary one is down
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "down.invalid" {
+			http.Error(w, "judge unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("203.0.113.7"))
+	}))
+	t.Cleanup(srv.Close)
+	proxy := strings.TrimPrefix(srv.URL, "http://")
+
+	proxies := make([]string, 5)
+	for i := range proxies {
+		proxies[i] = proxy
+	}
+
+	m := NewManager()
+	done := runCheck(m, ProxyCheckRequest{
+		ProxyList:         proxies,
+		ProxyType:         HTTP,
+		Endpoint:          "http://down.invalid/",
+		FallbackEndpoints: []string{"http://up.invalid/"},
+		FailoverThreshold: 2,
+		Threads:           1,
+	})
+	waitDone(t, done)
+
+	stats := m.GetStats()
+	assertConsistent(t, stats)
+	if stats.Live != 5 || stats.Dead != 0 || stats.Pending != 0 {
+		t.Fatalf("got %+v, want 5 live after failing over", stats)
+	}
+	if len(m.GetResults()) != 5 {
+		t.Errorf("got %d results, want 5", len(m.GetResults()))
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+var (
+	ErrInvalidPattern = errors.New("invalid address pattern")
+)
+
+// AddressMatcher matches proxy addresses against a set of patterns
+// Supported patterns are exact ip:port, bare hosts (any port) and CIDR ranges
+type AddressMatcher struct {
+	exact    map[string]bool
+	hosts    map[string]bool
+	networks []*net.IPNet
+}
+
+// NewAddressMatcher creates a matcher from a list of patterns
+func NewAddressMatcher(patterns []string) (*AddressMatcher, error) {
+	m := &AddressMatcher{
+		exact: make(map[string]bool),
+		hosts: make(map[string]bool),
+	}
+
+	for _, pattern := range patterns {
+		if err := m.add(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ValidatePattern checks whether a pattern can be used in an AddressMatcher
+func ValidatePattern(pattern string) error {
+	return (&AddressMatcher{
+		exact: make(map[string]bool),
+		hosts: make(map[string]bool),
+	}).add(pattern)
+}
+
+// add parses a single pattern and adds it to the matcher
+func (m *AddressMatcher) add(pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return fmt.Errorf("%w: empty pattern", ErrInvalidPattern)
+	}
+
+	// CIDR range
+	if strings.Contains(pattern, "/") {
+		_, network, err := net.ParseCIDR(pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPattern, pattern)
+		}
+		m.networks = append(m.networks, network)
+		return nil
+	}
+
+	// Exact host:port
+	if host, port, err := net.SplitHostPort(pattern); err == nil {
+		if host == "" || port == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidPattern, pattern)
+		}
+		m.exact[strings.ToLower(pattern)] = true
+		return nil
+	}
+
+	// Bare host or IP, matching any port
+	m.hosts[strings.ToLower(strings.Trim(pattern, "[]"))] = true
+	return nil
+}
+
+// Empty returns true if the matcher has no patterns
+func (m *AddressMatcher) Empty() bool {
+	return len(m.exact) == 0 && len(m.hosts) == 0 && len(m.networks) == 0
+}
+
+// Match returns true if the proxy address matches any of the patterns
+func (m *AddressMatcher) Match(proxy string) bool {
+	addr := strings.ToLower(StripProxyAuth(proxy))
+
+	if m.exact[addr] {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	if m.hosts[host] {
+		return true
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range m.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Filter splits a proxy list into the proxies that do not match and the ones that do
+func (m *AddressMatcher) Filter(proxies []string) (kept []string, matched []string) {
+	for _, proxy := range proxies {
+		if m.Match(proxy) {
+			matched = append(matched, proxy)
+		} else {
+			kept = append(kept, proxy)
+		}
+	}
+	return kept, matched
+}
+
+// StripProxyAuth removes a user:pass@ prefix from a proxy address
+func StripProxyAuth(proxy string) string {
+	if i := strings.LastIndex(proxy, "@"); i >= 0 {
+		return proxy[i+1:]
+	}
+	return proxy
+}
+
+// ProxyHost returns the host part of a proxy address, without credentials or port
+func ProxyHost(proxy string) string {
+	addr := StripProxyAuth(proxy)
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// FilterByCountry splits proxies by the country of the proxy host itself
+// With exclude unset, proxies in countries are kept; with exclude set, they are dropped.
+// Proxies whose country cannot be resolved are dropped unless exclude is set
+func FilterByCountry(proxies []string, countries []string, exclude bool, countryOf func(host string) string) (kept []string, dropped []string) {
+	wanted := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		wanted[strings.ToUpper(strings.TrimSpace(country))] = true
+	}
+
+	for _, proxy := range proxies {
+		code := countryOf(ProxyHost(proxy))
+		match := code != "" && wanted[code]
+		if match != exclude {
+			kept = append(kept, proxy)
+		} else {
+			dropped = append(dropped, proxy)
+		}
+	}
+	return kept, dropped
+}
+
+// Allowlist restricts checking to proxies inside approved ranges or countries
+type Allowlist struct {
+	ranges    *AddressMatcher
+	countries map[string]bool
+	countryOf func(host string) string
+}
+
+// NewAllowlist creates an allowlist from range patterns and ISO country codes
+// countryOf resolves the country code of a proxy host; it may be nil if no GeoIP data is available
+func NewAllowlist(ranges []string, countries []string, countryOf func(host string) string) (*Allowlist, error) {
+	matcher, err := NewAddressMatcher(ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	al := &Allowlist{
+		ranges:    matcher,
+		countries: make(map[string]bool, len(countries)),
+		countryOf: countryOf,
+	}
+	for _, country := range countries {
+		al.countries[strings.ToUpper(strings.TrimSpace(country))] = true
+	}
+
+	return al, nil
+}
+
+// Allowed returns true if the proxy is inside an approved range or country
+// Proxies whose country cannot be determined are refused
+func (al *Allowlist) Allowed(proxy string) bool {
+	if al.ranges.Match(proxy) {
+		return true
+	}
+
+	if len(al.countries) == 0 || al.countryOf == nil {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(StripProxyAuth(proxy))
+	if err != nil {
+		return false
+	}
+
+	code := al.countryOf(host)
+	return code != "" && al.countries[code]
+}
+
+// Filter splits a proxy list into allowed and refused proxies
+func (al *Allowlist) Filter(proxies []string) (allowed []string, refused []string) {
+	for _, proxy := range proxies {
+		if al.Allowed(proxy) {
+			allowed = append(allowed, proxy)
+		} else {
+			refused = append(refused, proxy)
+		}
+	}
+	return allowed, refused
+}
@@ -0,0 +1,62 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import "time"
+
+// Metadata is the purchase information of a paid proxy, imported with the proxy list
+type Metadata struct {
+	// Provider is the seller of the proxy
+	Provider string `json:"provider,omitempty"`
+
+	// Purchased is when the proxy was bought (zero if unknown)
+	Purchased time.Time `json:"purchased"`
+
+	// Expires is when the proxy subscription ends (zero if unknown)
+	Expires time.Time `json:"expires"`
+}
+
+// clone returns a copy of the metadata, or nil
+func (m *Metadata) clone() *Metadata {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	return &c
+}
+
+// ExpiresBefore returns true if the proxy has a known expiry before t
+func (m *Metadata) ExpiresBefore(t time.Time) bool {
+	return m != nil && !m.Expires.IsZero() && m.Expires.Before(t)
+}
+
+// NetworkInfo is the registration of the network an exit IP belongs to, from RDAP
+type NetworkInfo struct {
+	// Name and Handle identify the network block (e.g. AMAZON-IAD, NET-3-80-0-0-1)
+	Name   string `json:"name,omitempty"`
+	Handle string `json:"handle,omitempty"`
+
+	// Org is the registrant organisation
+	Org string `json:"org,omitempty"`
+
+	// AbuseEmail is the abuse contact of the network
+	AbuseEmail string `json:"abuseEmail,omitempty"`
+
+	// Country is the registration country
+	Country string `json:"country,omitempty"`
+}
+
+// clone returns a copy of the network information, or nil
+func (n *NetworkInfo) clone() *NetworkInfo {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	return &c
+}
@@ -0,0 +1,92 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"math/rand"
+	"net"
+	"strings"
+)
+
+// OrderMode controls the order in which proxies of equal priority are checked
+type OrderMode string
+
+const (
+	// OrderOriginal checks proxies in list order
+	OrderOriginal OrderMode = "original"
+
+	// OrderShuffled checks proxies in random order
+	OrderShuffled OrderMode = "shuffled"
+
+	// OrderBySubnet groups proxies by subnet (/24 for IPv4, /48 for IPv6) and takes one
+	// proxy from each group in turn, so consecutive checks never hit the same range
+	OrderBySubnet OrderMode = "subnet"
+
+	// OrderInterleaveSource takes one proxy from each source in turn
+	OrderInterleaveSource OrderMode = "interleave-source"
+)
+
+// OrderProxies returns a copy of proxies arranged according to mode
+// Unknown modes keep the original order
+func OrderProxies(proxies []string, mode OrderMode, sources map[string]string) []string {
+	ordered := make([]string, len(proxies))
+	copy(ordered, proxies)
+
+	switch mode {
+	case OrderShuffled:
+		rand.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+		return ordered
+	case OrderBySubnet:
+		return interleave(ordered, subnetKey)
+	case OrderInterleaveSource:
+		return interleave(ordered, func(proxy string) string { return sources[proxy] })
+	default:
+		return ordered
+	}
+}
+
+// interleave groups proxies by key and takes one proxy from each group in turn
+// Groups are visited in the order they first appear
+func interleave(proxies []string, key func(string) string) []string {
+	var keys []string
+	groups := make(map[string][]string)
+	for _, proxy := range proxies {
+		k := key(proxy)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], proxy)
+	}
+
+	result := make([]string, 0, len(proxies))
+	for len(result) < len(proxies) {
+		for _, k := range keys {
+			if group := groups[k]; len(group) > 0 {
+				result = append(result, group[0])
+				groups[k] = group[1:]
+			}
+		}
+	}
+	return result
+}
+
+// subnetKey returns the subnet of a proxy address, or its host for hostnames
+func subnetKey(proxy string) string {
+	host := ProxyHost(proxy)
+
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return strings.ToLower(host)
+	case ip.To4() != nil:
+		return ip.Mask(net.CIDRMask(24, 32)).String()
+	default:
+		return ip.Mask(net.CIDRMask(48, 128)).String()
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultPortMatrixHost answers on every TCP port, so a failed connection means the proxy
+// (or its network) blocks the port
+const DefaultPortMatrixHost = "portquiz.net"
+
+// PortOutcome is the outcome of the port matrix probe
+type PortOutcome struct {
+	// Host is the target the ports were tried on
+	Host string `json:"host"`
+
+	// Open maps every tried port to whether the proxy could connect to it
+	Open map[int]bool `json:"open"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// clone returns a copy of the port outcome, or nil
+func (o *PortOutcome) clone() *PortOutcome {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	c.Open = make(map[int]bool, len(o.Open))
+	for port, open := range o.Open {
+		c.Open[port] = open
+	}
+	return &c
+}
+
+// ProbePorts tries to connect to every port of host through the proxy, connect-only,
+// and records which ones the proxy can reach
+func ProbePorts(ctx context.Context, proxyAddr string, proxyType ProxyType, host string, ports []int, timeout time.Duration) PortOutcome {
+	if host == "" {
+		host = DefaultPortMatrixHost
+	}
+	outcome := PortOutcome{Host: host, Open: make(map[int]bool, len(ports))}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, port := range ports {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+
+			conn, err := Tunnel(ctx, proxyAddr, proxyType, net.JoinHostPort(host, strconv.Itoa(port)), timeout)
+			if err == nil {
+				conn.Close()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			outcome.Open[port] = err == nil
+		}(port)
+	}
+	wg.Wait()
+
+	return outcome
+}
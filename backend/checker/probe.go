@@ -0,0 +1,460 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProbeOptions enables the advanced diagnostics run against live proxies after the check
+// The zero value runs no probes
+type ProbeOptions struct {
+	// MTUBytes is the size of the padding sent by the MTU probe; 0 disables the probe
+	MTUBytes int
+
+	// SoakDuration keeps a connection through the proxy open this long; 0 disables the soak test
+	SoakDuration time.Duration
+
+	// SoakInterval is the time between pings of the soak test; 0 uses DefaultSoakInterval
+	SoakInterval time.Duration
+
+	// Connections is the number of parallel connections opened by the capacity probe; 0 disables it
+	Connections int
+
+	// FTPServer is the FTP server (host:port) whose greeting the FTP probe waits for; empty disables it
+	FTPServer string
+
+	// Ports are tried connect-only on PortHost through the proxy; empty disables the port matrix
+	Ports []int
+
+	// PortHost is the target of the port matrix; empty uses DefaultPortMatrixHost
+	PortHost string
+
+	// DualStack asks an IPv4-only and an IPv6-only echo endpoint for the exit addresses
+	DualStack bool
+
+	// IPv4Echo and IPv6Echo are the endpoints of the dual-stack probe; empty uses the defaults
+	IPv4Echo string
+	IPv6Echo string
+
+	// ConnectPolicy asks HTTP proxies to CONNECT to ConnectPorts on PortHost, recording
+	// which ports they allow; empty ConnectPorts uses DefaultConnectPorts
+	ConnectPolicy bool
+	ConnectPorts  []int
+
+	// Callback is the listener the round-trip probe asks the proxy to reach; nil disables it
+	Callback *CallbackListener
+}
+
+// DefaultSoakInterval is the time between pings of the soak test
+const DefaultSoakInterval = 5 * time.Second
+
+// enabled returns true if at least one probe is enabled
+func (o ProbeOptions) enabled() bool {
+	return o.MTUBytes > 0 || o.SoakDuration > 0 || o.Connections > 0 || o.FTPServer != "" || len(o.Ports) > 0 || o.DualStack ||
+		o.ConnectPolicy || o.Callback != nil
+}
+
+// Probes holds the outcome of the advanced diagnostics of a live proxy
+// A nil field means the probe did not run
+type Probes struct {
+	// MTU is the outcome of the oversized request probe
+	MTU *ProbeOutcome `json:"mtu,omitempty"`
+
+	// Soak is the outcome of the sustained connection test
+	Soak *SoakOutcome `json:"soak,omitempty"`
+
+	// Capacity is the outcome of the parallel connection probe
+	Capacity *CapacityOutcome `json:"capacity,omitempty"`
+
+	// FTP is the outcome of the FTP control channel probe
+	FTP *ProbeOutcome `json:"ftp,omitempty"`
+
+	// Ports is the outcome of the port matrix probe
+	Ports *PortOutcome `json:"ports,omitempty"`
+
+	// DualStack is the outcome of the IPv4/IPv6 exit probe
+	DualStack *DualStackOutcome `json:"dualStack,omitempty"`
+
+	// ConnectPolicy is the outcome of the CONNECT port policy probe of an HTTP proxy
+	ConnectPolicy *ConnectPolicyOutcome `json:"connectPolicy,omitempty"`
+
+	// RoundTrip is the outcome of the callback reachability probe
+	RoundTrip *RoundTripOutcome `json:"roundTrip,omitempty"`
+}
+
+// ProbeOutcome is the outcome of a pass/fail probe
+type ProbeOutcome struct {
+	Passed  bool   `json:"passed"`
+	Latency int64  `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SoakOutcome is the outcome of the sustained connection test
+type SoakOutcome struct {
+	// Passed is true if every ping succeeded over the same connection
+	Passed bool `json:"passed"`
+
+	// Pings is the number of pings sent and Failed the number that did not get a response
+	Pings  int `json:"pings"`
+	Failed int `json:"failed"`
+
+	// Reconnects is the number of times the connection was closed and had to be reopened
+	Reconnects int `json:"reconnects"`
+
+	// Survived is how long (ms) the first connection stayed usable
+	Survived int64 `json:"survived"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// CapacityOutcome is the outcome of the parallel connection probe
+type CapacityOutcome struct {
+	// Attempted is the number of parallel connections opened and Succeeded the number answered
+	Attempted int `json:"attempted"`
+	Succeeded int `json:"succeeded"`
+
+	// Error is the most common failure of the connections that did not succeed
+	Error string `json:"error,omitempty"`
+}
+
+// clone returns a copy of the probe results, or nil
+func (p *Probes) clone() *Probes {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	if p.MTU != nil {
+		mtu := *p.MTU
+		c.MTU = &mtu
+	}
+	if p.Soak != nil {
+		soak := *p.Soak
+		c.Soak = &soak
+	}
+	if p.Capacity != nil {
+		capacity := *p.Capacity
+		c.Capacity = &capacity
+	}
+	if p.FTP != nil {
+		ftp := *p.FTP
+		c.FTP = &ftp
+	}
+	c.Ports = p.Ports.clone()
+	if p.DualStack != nil {
+		dualStack := *p.DualStack
+		c.DualStack = &dualStack
+	}
+	c.ConnectPolicy = p.ConnectPolicy.clone()
+	if p.RoundTrip != nil {
+		roundTrip := *p.RoundTrip
+		c.RoundTrip = &roundTrip
+	}
+	return &c
+}
+
+// runProbes runs the enabled probes against a live proxy
+func runProbes(ctx context.Context, req ProxyCheckRequest, proxyAddr string, proxyType ProxyType, timeout time.Duration) *Probes {
+	probes := &Probes{}
+	if req.Probes.MTUBytes > 0 {
+		outcome := ProbeMTU(ctx, proxyAddr, proxyType, req.Endpoint, req.Probes.MTUBytes, timeout, req.UpstreamProxy, req.UpstreamType)
+		probes.MTU = &outcome
+	}
+	if req.Probes.SoakDuration > 0 {
+		outcome := ProbeSoak(ctx, proxyAddr, proxyType, req.Endpoint, req.Probes.SoakDuration, req.Probes.SoakInterval, timeout, req.UpstreamProxy, req.UpstreamType)
+		probes.Soak = &outcome
+	}
+	if req.Probes.Connections > 0 {
+		outcome := ProbeCapacity(ctx, proxyAddr, proxyType, req.Endpoint, req.Probes.Connections, timeout, req.UpstreamProxy, req.UpstreamType)
+		probes.Capacity = &outcome
+	}
+	if req.Probes.FTPServer != "" {
+		outcome := ProbeOutcome{Error: fmt.Sprintf("%v for tunnel probes", ErrUpstreamNotSupported)}
+		if req.UpstreamProxy == "" {
+			outcome = ProbeFTP(ctx, proxyAddr, proxyType, req.Probes.FTPServer, timeout)
+		}
+		probes.FTP = &outcome
+	}
+	if len(req.Probes.Ports) > 0 {
+		outcome := PortOutcome{Error: fmt.Sprintf("%v for tunnel probes", ErrUpstreamNotSupported)}
+		if req.UpstreamProxy == "" {
+			outcome = ProbePorts(ctx, proxyAddr, proxyType, req.Probes.PortHost, req.Probes.Ports, timeout)
+		}
+		probes.Ports = &outcome
+	}
+	if req.Probes.DualStack {
+		outcome := ProbeDualStack(ctx, proxyAddr, proxyType, req.Probes.IPv4Echo, req.Probes.IPv6Echo, timeout, req.UpstreamProxy, req.UpstreamType)
+		probes.DualStack = &outcome
+	}
+	if req.Probes.ConnectPolicy && (proxyType == HTTP || proxyType == HTTPS) {
+		outcome := ConnectPolicyOutcome{Error: fmt.Sprintf("%v for tunnel probes", ErrUpstreamNotSupported)}
+		if req.UpstreamProxy == "" {
+			outcome = ProbeConnectPolicy(ctx, proxyAddr, proxyType, req.Probes.PortHost, req.Probes.ConnectPorts, timeout)
+		}
+		probes.ConnectPolicy = &outcome
+	}
+	if req.Probes.Callback != nil {
+		outcome := ProbeRoundTrip(ctx, proxyAddr, proxyType, req.Probes.Callback, timeout, req.UpstreamProxy, req.UpstreamType)
+		probes.RoundTrip = &outcome
+	}
+	return probes
+}
+
+// ProbeMTU sends a request padded beyond a typical 1500 byte MTU through the proxy
+// Proxies with broken path MTU discovery pass small IP-echo checks but stall once a
+// request spans several full-sized segments; any response within the timeout passes
+func ProbeMTU(ctx context.Context, proxyAddr string, proxyType ProxyType, endpoint string, size int, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) ProbeOutcome {
+	client, closeIdle, err := newProxyClient(ctx, proxyAddr, proxyType, timeout, upstreamProxy, upstreamType)
+	if err != nil {
+		return ProbeOutcome{Error: err.Error()}
+	}
+	defer closeIdle()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return ProbeOutcome{Error: fmt.Sprintf("failed to create request: %v", err)}
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	req.Header.Set("X-Padding", strings.Repeat("x", size))
+	req.Close = true
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return ProbeOutcome{Latency: time.Since(start).Milliseconds(), Error: classify("oversized request failed", err).Error()}
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024)); err != nil {
+		return ProbeOutcome{Latency: time.Since(start).Milliseconds(), Error: classify("oversized request stalled", err).Error()}
+	}
+
+	return ProbeOutcome{Passed: true, Latency: time.Since(start).Milliseconds()}
+}
+
+// ProbeSoak keeps a connection through the proxy open for duration, pinging the endpoint
+// over it every interval, and records premature disconnects
+// Proxies that die under sustained use pass the one-shot check but drop or reset long-lived connections
+func ProbeSoak(ctx context.Context, proxyAddr string, proxyType ProxyType, endpoint string, duration time.Duration, interval time.Duration, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) SoakOutcome {
+	if interval <= 0 {
+		interval = DefaultSoakInterval
+	}
+
+	transport, err := newProxyTransport(ctx, proxyAddr, proxyType, timeout, upstreamProxy, upstreamType)
+	if err != nil {
+		return SoakOutcome{Error: err.Error()}
+	}
+	// The soak test is about the connection staying up, so keep it alive between pings
+	transport.DisableKeepAlives = false
+	transport.MaxIdleConnsPerHost = 1
+	transport.IdleConnTimeout = interval + timeout
+	defer transport.CloseIdleConnections()
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+
+	var outcome SoakOutcome
+	start := time.Now()
+	deadline := start.Add(duration)
+	firstDown := false
+
+	for {
+		reused, err := soakPing(ctx, client, endpoint)
+		outcome.Pings++
+		if outcome.Pings > 1 && !reused {
+			outcome.Reconnects++
+		}
+		if err != nil {
+			outcome.Failed++
+			outcome.Error = err.Error()
+		}
+		if !firstDown && (err != nil || (outcome.Pings > 1 && !reused)) {
+			firstDown = true
+			outcome.Survived = time.Since(start).Milliseconds()
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			outcome.Error = ctx.Err().Error()
+			return outcome
+		case <-time.After(interval):
+		}
+	}
+
+	if !firstDown {
+		outcome.Survived = time.Since(start).Milliseconds()
+	}
+	outcome.Passed = outcome.Failed == 0 && outcome.Reconnects == 0
+	return outcome
+}
+
+// soakPing sends one request of the soak test and reports whether it reused the open connection
+func soakPing(ctx context.Context, client *http.Client, endpoint string) (bool, error) {
+	reused := false
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			reused = info.Reused
+		},
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), "GET", endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return reused, classify("soak ping failed", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused by the next ping
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024)); err != nil {
+		return reused, classify("soak ping failed", err)
+	}
+	return reused, nil
+}
+
+// ProbeCapacity opens n connections through the proxy at the same time and counts how many
+// get a response, estimating the concurrency limit of the proxy
+func ProbeCapacity(ctx context.Context, proxyAddr string, proxyType ProxyType, endpoint string, n int, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) CapacityOutcome {
+	outcome := CapacityOutcome{Attempted: n}
+
+	// Every connection gets its own one-shot transport so none of them are shared
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = capacityRequest(ctx, proxyAddr, proxyType, endpoint, timeout, upstreamProxy, upstreamType)
+		}(i)
+	}
+	wg.Wait()
+
+	failures := make(map[string]int)
+	for _, err := range errs {
+		if err == nil {
+			outcome.Succeeded++
+			continue
+		}
+		failures[err.Error()]++
+		if failures[err.Error()] > failures[outcome.Error] {
+			outcome.Error = err.Error()
+		}
+	}
+	return outcome
+}
+
+// capacityRequest sends one request of the capacity probe over a fresh connection
+func capacityRequest(ctx context.Context, proxyAddr string, proxyType ProxyType, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) error {
+	client, closeIdle, err := newProxyClient(ctx, proxyAddr, proxyType, timeout, upstreamProxy, upstreamType)
+	if err != nil {
+		return err
+	}
+	defer closeIdle()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	req.Close = true
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return classify("parallel request failed", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024)); err != nil {
+		return classify("parallel request failed", err)
+	}
+	return nil
+}
+
+// newProxyClient returns an HTTP client routed through a proxy of the given type,
+// along with a function releasing its connections
+func newProxyClient(ctx context.Context, proxyAddr string, proxyType ProxyType, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (*http.Client, func(), error) {
+	transport, err := newProxyTransport(ctx, proxyAddr, proxyType, timeout, upstreamProxy, upstreamType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+	return client, transport.CloseIdleConnections, nil
+}
+
+// newProxyTransport returns a one-shot transport routed through a proxy of the given type
+func newProxyTransport(ctx context.Context, proxyAddr string, proxyType ProxyType, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (*http.Transport, error) {
+	if !strings.Contains(proxyAddr, ":") {
+		return nil, ErrInvalidProxyFormat
+	}
+
+	transport := newOneShotTransport(timeout)
+
+	switch proxyType {
+	case HTTP, HTTPS:
+		proxyURL, err := url.Parse(string(proxyType) + "://" + proxyAddr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy address: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+
+		if upstreamProxy != "" {
+			upstreamDialer, err := createUpstreamDialer(ctx, upstreamProxy, upstreamType, timeout)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create upstream connection: %w", err)
+			}
+			transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialContext(ctx, upstreamDialer, network, addr)
+			}
+		}
+
+	case SOCKS4, SOCKS5:
+		if upstreamProxy != "" {
+			return nil, fmt.Errorf("%w for %s probes", ErrUpstreamNotSupported, proxyType)
+		}
+
+		socksDialer, err := newSOCKSDialer(ctx, proxyAddr, proxyType, timeout)
+		if err != nil {
+			return nil, err
+		}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialContext(ctx, socksDialer, network, addr)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProxyType, proxyType)
+	}
+
+	countTraffic(transport)
+	return transport, nil
+}
@@ -0,0 +1,209 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbeMTU(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("X-Padding")) != 2000 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("203.0.113.7"))
+	}))
+	defer srv.Close()
+
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	outcome := ProbeMTU(context.Background(), addr, HTTP, "http://judge.invalid/", 2000, time.Second, "", "")
+	if !outcome.Passed {
+		t.Fatalf("probe failed: %s", outcome.Error)
+	}
+
+	outcome = ProbeMTU(context.Background(), closedAddr(t), HTTP, "http://judge.invalid/", 2000, time.Second, "", "")
+	if outcome.Passed || outcome.Error == "" {
+		t.Errorf("probe through a closed port passed: %+v", outcome)
+	}
+}
+
+func TestProbeSoakReusesConnection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("203.0.113.7"))
+	}))
+	defer srv.Close()
+
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	outcome := ProbeSoak(context.Background(), addr, HTTP, "http://judge.invalid/", 300*time.Millisecond, 100*time.Millisecond, time.Second, "", "")
+	if !outcome.Passed || outcome.Pings < 3 || outcome.Reconnects != 0 {
+		t.Fatalf("unexpected outcome: %+v", outcome)
+	}
+
+	// A proxy closing the connection after every response must be flagged
+	srv.Config.SetKeepAlivesEnabled(false)
+	outcome = ProbeSoak(context.Background(), addr, HTTP, "http://judge.invalid/", 300*time.Millisecond, 100*time.Millisecond, time.Second, "", "")
+	if outcome.Passed || outcome.Reconnects == 0 {
+		t.Errorf("dropped connections not detected: %+v", outcome)
+	}
+}
+
+// newConnectProxy starts a proxy answering CONNECT requests with status and then,
+// on success, playing the server by writing greeting
+func newConnectProxy(t *testing.T, status string, greeting string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+				fmt.Fprintf(conn, "HTTP/1.1 %s\r\n\r\n%s", status, greeting)
+				io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestProbeFTP(t *testing.T) {
+	addr := newConnectProxy(t, "200 Connection established", "220-Welcome\r\n220 Ready\r\n")
+	outcome := ProbeFTP(context.Background(), addr, HTTP, "ftp.invalid", time.Second)
+	if !outcome.Passed {
+		t.Fatalf("probe failed: %s", outcome.Error)
+	}
+
+	addr = newConnectProxy(t, "403 Forbidden", "")
+	outcome = ProbeFTP(context.Background(), addr, HTTP, "ftp.invalid", time.Second)
+	if outcome.Passed {
+		t.Error("refused CONNECT passed")
+	}
+
+	addr = newConnectProxy(t, "200 Connection established", "<html>blocked</html>\r\n")
+	outcome = ProbeFTP(context.Background(), addr, HTTP, "ftp.invalid", time.Second)
+	if outcome.Passed {
+		t.Error("non-FTP greeting passed")
+	}
+}
+
+func TestProbeRoundTrip(t *testing.T) {
+	callback, err := NewCallbackListener("127.0.0.1:0", "")
+	if err == nil {
+		callback.Close()
+		t.Fatal("listener without a public URL was accepted")
+	}
+	callback, err = NewCallbackListener("127.0.0.1:0", "http://placeholder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer callback.Close()
+	callback.publicURL = "http://" + callback.Addr()
+
+	// A forwarding proxy reaches the listener
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.RequestURI = ""
+		resp, err := http.DefaultTransport.RoundTrip(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+	defer forward.Close()
+
+	outcome := ProbeRoundTrip(context.Background(), strings.TrimPrefix(forward.URL, "http://"), HTTP, callback, time.Second, "", "")
+	if !outcome.Passed || outcome.CallerIP != "" {
+		t.Fatalf("round trip through a forwarding proxy failed: %+v", outcome)
+	}
+
+	// A proxy answering on its own never reaches the listener
+	outcome = ProbeRoundTrip(context.Background(), newTestProxy(t, 0), HTTP, callback, time.Second, "", "")
+	if outcome.Passed || outcome.Error != ErrCallbackMissing.Error() {
+		t.Errorf("missing callback not detected: %+v", outcome)
+	}
+}
+
+func TestProbeConnectPolicy(t *testing.T) {
+	// A proxy tunneling to 443 only, and failing to reach 8443
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+				switch {
+				case strings.HasSuffix(req.Host, ":443"):
+					fmt.Fprint(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+				case strings.HasSuffix(req.Host, ":8443"):
+					fmt.Fprint(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+				default:
+					fmt.Fprint(conn, "HTTP/1.1 403 Forbidden\r\n\r\n")
+				}
+			}()
+		}
+	}()
+
+	outcome := ProbeConnectPolicy(context.Background(), listener.Addr().String(), HTTP, "target.invalid", []int{443, 8443, 22, 80}, time.Second)
+	if outcome.Policy != Connect443Only || fmt.Sprint(outcome.Denied) != "[22 80]" || fmt.Sprint(outcome.Unreachable) != "[8443]" {
+		t.Errorf("unexpected outcome: %+v", outcome)
+	}
+}
+
+func TestCallbackListenerRefusesUnknownTokens(t *testing.T) {
+	callback, err := NewCallbackListener("127.0.0.1:0", "http://placeholder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer callback.Close()
+
+	resp, err := http.Get("http://" + callback.Addr() + callbackPath + "unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || len(callback.pending) != 0 {
+		t.Errorf("unknown token accepted: status %d, %d pending", resp.StatusCode, len(callback.pending))
+	}
+}
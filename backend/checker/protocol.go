@@ -12,7 +12,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
 	"net/url"
@@ -26,12 +25,17 @@ var (
 	ErrInvalidProxyFormat    = errors.New("invalid proxy format")
 	ErrUnsupportedProxyType  = errors.New("unsupported proxy type")
 	ErrProxyConnectionFailed = errors.New("proxy connection failed")
-	ErrEmptyResponse         = errors.New("empty response from endpoint")
+	ErrEmptyResponse         = fmt.Errorf("%w: empty response from endpoint", ErrBadJudgeResponse)
 )
 
 // CheckHTTP checks if an HTTP proxy is working
 // If upstreamProxy is provided, the check will be routed through it
 func CheckHTTP(proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
+	return CheckHTTPContext(context.Background(), proxyAddr, endpoint, timeout, upstreamProxy, upstreamType)
+}
+
+// CheckHTTPContext is like CheckHTTP but gives up as soon as ctx is cancelled
+func CheckHTTPContext(ctx context.Context, proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
 	// Validate proxy format
 	if !strings.Contains(proxyAddr, ":") {
 		return "", ErrInvalidProxyFormat
@@ -43,74 +47,72 @@ func CheckHTTP(proxyAddr string, endpoint string, timeout time.Duration, upstrea
 		return "", fmt.Errorf("invalid proxy address: %w", err)
 	}
 
-	// Create transport and client
-	transport := &http.Transport{
-		Proxy: http.ProxyURL(proxyURL),
-		DialContext: (&net.Dialer{
-			Timeout:   timeout,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		TLSHandshakeTimeout:   timeout,
-		ResponseHeaderTimeout: timeout,
-		ExpectContinueTimeout: 1 * time.Second,
-		MaxIdleConns:          10,
-		IdleConnTimeout:       90 * time.Second,
-	}
+	// Create a one-shot transport and client
+	transport := newOneShotTransport(timeout)
+	transport.Proxy = http.ProxyURL(proxyURL)
+	defer transport.CloseIdleConnections()
 
 	// If upstream proxy is specified, route through it
 	if upstreamProxy != "" {
-		upstreamDialer, err := createUpstreamDialer(upstreamProxy, upstreamType, timeout)
+		upstreamDialer, err := createUpstreamDialer(ctx, upstreamProxy, upstreamType, timeout)
 		if err != nil {
 			return "", fmt.Errorf("failed to create upstream connection: %w", err)
 		}
 
 		// Replace the dialer with one that uses the upstream proxy
 		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return upstreamDialer.Dial(network, addr)
+			return dialContext(ctx, upstreamDialer, network, addr)
 		}
 	}
 
+	countTraffic(transport)
 	client := &http.Client{
 		Transport: transport,
 		Timeout:   timeout,
 	}
 
 	// Make the request
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add common headers to appear more like a browser
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
-	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Upgrade-Insecure-Requests", "1")
+	req.Close = true
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("proxy connection failed: %w", err)
+		return "", classify("proxy connection failed", err)
 	}
 	defer resp.Body.Close()
+	observeResponse(ctx, resp)
+
+	if err := checkStatus(resp); err != nil {
+		return "", err
+	}
 
 	// Read response body to get the IP
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
 
 	// The response should contain the outgoing IP
-	outgoingIP := strings.TrimSpace(string(body))
-	if outgoingIP == "" {
-		return "", ErrEmptyResponse
-	}
-
-	return outgoingIP, nil
+	return parseJudgeBody(body)
 }
 
 // CheckHTTPS checks if an HTTPS proxy is working
 func CheckHTTPS(proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
+	return CheckHTTPSContext(context.Background(), proxyAddr, endpoint, timeout, upstreamProxy, upstreamType)
+}
+
+// CheckHTTPSContext is like CheckHTTPS but gives up as soon as ctx is cancelled
+func CheckHTTPSContext(ctx context.Context, proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
 	// Validate proxy format
 	if !strings.Contains(proxyAddr, ":") {
 		return "", ErrInvalidProxyFormat
@@ -122,96 +124,88 @@ func CheckHTTPS(proxyAddr string, endpoint string, timeout time.Duration, upstre
 		return "", fmt.Errorf("invalid proxy address: %w", err)
 	}
 
-	// Create transport and client
-	transport := &http.Transport{
-		Proxy: http.ProxyURL(proxyURL),
-		DialContext: (&net.Dialer{
-			Timeout:   timeout,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		TLSHandshakeTimeout:   timeout,
-		ResponseHeaderTimeout: timeout,
-		ExpectContinueTimeout: 1 * time.Second,
-		MaxIdleConns:          10,
-		IdleConnTimeout:       90 * time.Second,
-	}
+	// Create a one-shot transport and client
+	transport := newOneShotTransport(timeout)
+	transport.Proxy = http.ProxyURL(proxyURL)
+	defer transport.CloseIdleConnections()
 
 	// If upstream proxy is specified, route through it
 	if upstreamProxy != "" {
-		upstreamDialer, err := createUpstreamDialer(upstreamProxy, upstreamType, timeout)
+		upstreamDialer, err := createUpstreamDialer(ctx, upstreamProxy, upstreamType, timeout)
 		if err != nil {
 			return "", fmt.Errorf("failed to create upstream connection: %w", err)
 		}
 
 		// Replace the dialer with one that uses the upstream proxy
 		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return upstreamDialer.Dial(network, addr)
+			return dialContext(ctx, upstreamDialer, network, addr)
 		}
 	}
 
+	countTraffic(transport)
 	client := &http.Client{
 		Transport: transport,
 		Timeout:   timeout,
 	}
 
 	// Make the request
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add common headers to appear more like a browser
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
-	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Upgrade-Insecure-Requests", "1")
+	req.Close = true
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("proxy connection failed: %w", err)
+		return "", classify("proxy connection failed", err)
 	}
 	defer resp.Body.Close()
+	observeResponse(ctx, resp)
+
+	if err := checkStatus(resp); err != nil {
+		return "", err
+	}
 
 	// Read response body to get the IP
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
 
 	// The response should contain the outgoing IP
-	outgoingIP := strings.TrimSpace(string(body))
-	if outgoingIP == "" {
-		return "", ErrEmptyResponse
-	}
-
-	return outgoingIP, nil
+	return parseJudgeBody(body)
 }
 
 // CheckSOCKS4 checks if a SOCKS4 proxy is working
 func CheckSOCKS4(proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
+	return CheckSOCKS4Context(context.Background(), proxyAddr, endpoint, timeout, upstreamProxy, upstreamType)
+}
+
+// CheckSOCKS4Context is like CheckSOCKS4 but gives up as soon as ctx is cancelled
+func CheckSOCKS4Context(ctx context.Context, proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
 	// Validate proxy format
 	if !strings.Contains(proxyAddr, ":") {
 		return "", ErrInvalidProxyFormat
 	}
 
-	// Create SOCKS4 dialer
-	dialer := &net.Dialer{Timeout: timeout}
-
 	// If upstream proxy is specified, route through it
 	if upstreamProxy != "" {
 		// Note: Chaining SOCKS proxies is complex and not fully implemented here
-		return "", fmt.Errorf("upstream proxy not supported for SOCKS4 checks")
+		return "", fmt.Errorf("%w for SOCKS4 checks", ErrUpstreamNotSupported)
 	}
 
 	// Create SOCKS4 client
-	// Note: Go's proxy package doesn't directly support SOCKS4, so we use SOCKS5 with special handling
-	auth := &proxy.Auth{
-		User: "socks4", // This is a marker for SOCKS4 protocol
-	}
-	socks4Dialer, err := proxy.SOCKS5("tcp", proxyAddr, auth, dialer)
+	// The userid is the username of the proxy address, or the ident of ctx
+	socks4Dialer, err := newSOCKSDialer(ctx, proxyAddr, SOCKS4, timeout)
 	if err != nil {
-		return "", fmt.Errorf("failed to create SOCKS4 client: %w", err)
+		return "", err
 	}
 
 	// Parse the endpoint URL to get the host and port
@@ -232,52 +226,58 @@ func CheckSOCKS4(proxyAddr string, endpoint string, timeout time.Duration, upstr
 	}
 
 	// Connect to the endpoint through the SOCKS4 proxy
-	conn, err := socks4Dialer.Dial("tcp", host+":"+port)
+	conn, err := dialContext(ctx, socks4Dialer, "tcp", host+":"+port)
 	if err != nil {
-		return "", fmt.Errorf("SOCKS4 connection failed: %w", err)
+		return "", classify("SOCKS4 connection failed", err)
 	}
-	defer conn.Close()
+	// The tunnel is up; release it right away instead of holding it during the request
+	conn.Close()
 
 	// For HTTP(S) endpoints, we need to make an HTTP request
 	if endpointURL.Scheme == "http" || endpointURL.Scheme == "https" {
 		// Create a client that uses our SOCKS4 connection
+		transport := newOneShotTransport(timeout)
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialContext(ctx, socks4Dialer, network, addr)
+		}
+		defer transport.CloseIdleConnections()
+
+		countTraffic(transport)
 		client := &http.Client{
-			Transport: &http.Transport{
-				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-					return socks4Dialer.Dial(network, addr)
-				},
-			},
-			Timeout: timeout,
+			Transport: transport,
+			Timeout:   timeout,
 		}
 
 		// Make the request
-		req, err := http.NewRequest("GET", endpoint, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return "", fmt.Errorf("failed to create request: %w", err)
 		}
 
 		// Add common headers
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		req.Close = true
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return "", fmt.Errorf("HTTP request through SOCKS4 failed: %w", err)
+			return "", classify("HTTP request through SOCKS4 failed", err)
 		}
 		defer resp.Body.Close()
+		observeResponse(ctx, resp)
+
+		if err := checkStatus(resp); err != nil {
+			return "", err
+		}
 
 		// Read response body to get the IP
-		body, err := io.ReadAll(resp.Body)
+		body, err := readBody(resp)
 		if err != nil {
-			return "", fmt.Errorf("failed to read response: %w", err)
+			return "", err
 		}
 
 		// The response should contain the outgoing IP
-		outgoingIP := strings.TrimSpace(string(body))
-		if outgoingIP == "" {
-			return "", ErrEmptyResponse
-		}
-
-		return outgoingIP, nil
+		return parseJudgeBody(body)
 	}
 
 	// For non-HTTP endpoints, we would need a different approach
@@ -286,22 +286,31 @@ func CheckSOCKS4(proxyAddr string, endpoint string, timeout time.Duration, upstr
 
 // CheckSOCKS5 checks if a SOCKS5 proxy is working
 func CheckSOCKS5(proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
+	return CheckSOCKS5Context(context.Background(), proxyAddr, endpoint, timeout, upstreamProxy, upstreamType)
+}
+
+// CheckSOCKS5Context is like CheckSOCKS5 but gives up as soon as ctx is cancelled
+func CheckSOCKS5Context(ctx context.Context, proxyAddr string, endpoint string, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) (string, error) {
 	// Validate proxy format
 	if !strings.Contains(proxyAddr, ":") {
 		return "", ErrInvalidProxyFormat
 	}
 
 	// Create SOCKS5 dialer
-	dialer := &net.Dialer{Timeout: timeout}
+	dialer, err := newDialer(ctx, timeout)
+	if err != nil {
+		return "", err
+	}
 
 	// If upstream proxy is specified, route through it
 	if upstreamProxy != "" {
 		// Note: Chaining SOCKS proxies is complex and not fully implemented here
-		return "", fmt.Errorf("upstream proxy not supported for SOCKS5 checks")
+		return "", fmt.Errorf("%w for SOCKS5 checks", ErrUpstreamNotSupported)
 	}
 
-	// Create SOCKS5 client
-	socks5Dialer, err := proxy.SOCKS5("tcp", proxyAddr, nil, dialer)
+	// Create SOCKS5 client, authenticating with the credentials of a user:pass@ address
+	proxyAddr, auth := splitProxyAuth(proxyAddr)
+	socks5Dialer, err := proxy.SOCKS5("tcp", proxyAddr, auth, dialer)
 	if err != nil {
 		return "", fmt.Errorf("failed to create SOCKS5 client: %w", err)
 	}
@@ -324,61 +333,147 @@ func CheckSOCKS5(proxyAddr string, endpoint string, timeout time.Duration, upstr
 	}
 
 	// Connect to the endpoint through the SOCKS5 proxy
-	conn, err := socks5Dialer.Dial("tcp", host+":"+port)
+	conn, err := dialContext(ctx, socks5Dialer, "tcp", host+":"+port)
 	if err != nil {
-		return "", fmt.Errorf("SOCKS5 connection failed: %w", err)
+		return "", classify("SOCKS5 connection failed", err)
 	}
-	defer conn.Close()
+	// The tunnel is up; release it right away instead of holding it during the request
+	conn.Close()
 
 	// For HTTP(S) endpoints, we need to make an HTTP request
 	if endpointURL.Scheme == "http" || endpointURL.Scheme == "https" {
 		// Create a client that uses our SOCKS5 connection
+		transport := newOneShotTransport(timeout)
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialContext(ctx, socks5Dialer, network, addr)
+		}
+		defer transport.CloseIdleConnections()
+
+		countTraffic(transport)
 		client := &http.Client{
-			Transport: &http.Transport{
-				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-					return socks5Dialer.Dial(network, addr)
-				},
-			},
-			Timeout: timeout,
+			Transport: transport,
+			Timeout:   timeout,
 		}
 
 		// Make the request
-		req, err := http.NewRequest("GET", endpoint, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return "", fmt.Errorf("failed to create request: %w", err)
 		}
 
 		// Add common headers
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		req.Close = true
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return "", fmt.Errorf("HTTP request through SOCKS5 failed: %w", err)
+			return "", classify("HTTP request through SOCKS5 failed", err)
 		}
 		defer resp.Body.Close()
+		observeResponse(ctx, resp)
+
+		if err := checkStatus(resp); err != nil {
+			return "", err
+		}
 
 		// Read response body to get the IP
-		body, err := io.ReadAll(resp.Body)
+		body, err := readBody(resp)
 		if err != nil {
-			return "", fmt.Errorf("failed to read response: %w", err)
+			return "", err
 		}
 
 		// The response should contain the outgoing IP
-		outgoingIP := strings.TrimSpace(string(body))
-		if outgoingIP == "" {
-			return "", ErrEmptyResponse
-		}
-
-		return outgoingIP, nil
+		return parseJudgeBody(body)
 	}
 
 	// For non-HTTP endpoints, we would need a different approach
 	return "Connection successful", nil
 }
 
+// newOneShotTransport returns a transport for a single request
+// Every proxy is checked once, so connections are never kept alive for reuse; this keeps
+// the number of open sockets close to the number of checks in flight
+func newOneShotTransport(timeout time.Duration) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialer, err := newDialer(ctx, timeout)
+			if err != nil {
+				return nil, err
+			}
+			dialer.KeepAlive = -1
+			return dialer.DialContext(ctx, network, addr)
+		},
+		OnProxyConnectResponse: rejectConnect,
+		TLSHandshakeTimeout:    timeout,
+		ResponseHeaderTimeout:  timeout,
+		ExpectContinueTimeout:  1 * time.Second,
+		DisableKeepAlives:      true,
+	}
+}
+
+// newSOCKSDialer returns a dialer connecting through a SOCKS4 or SOCKS5 proxy
+func newSOCKSDialer(ctx context.Context, proxyAddr string, proxyType ProxyType, timeout time.Duration) (proxy.Dialer, error) {
+	dialer, err := newDialer(ctx, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyAddr, auth := splitProxyAuth(proxyAddr)
+	if proxyType == SOCKS4 {
+		return newSOCKS4Dialer(proxyAddr, identOf(ctx, auth), dialer, timeout), nil
+	}
+
+	socksDialer, err := proxy.SOCKS5("tcp", proxyAddr, auth, dialer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", proxyType, err)
+	}
+	return socksDialer, nil
+}
+
+// identOf returns the SOCKS4 userid of a proxy: the username of its address if it has one,
+// the ident of ctx otherwise
+func identOf(ctx context.Context, auth *proxy.Auth) string {
+	if auth != nil && auth.User != "" {
+		return auth.User
+	}
+	return socks4Ident(ctx)
+}
+
+// splitProxyAuth splits a [user:pass@]host:port address into the address and its credentials
+func splitProxyAuth(proxyAddr string) (string, *proxy.Auth) {
+	addr := StripProxyAuth(proxyAddr)
+	if addr == proxyAddr {
+		return addr, nil
+	}
+
+	u, err := url.Parse("socks5://" + proxyAddr)
+	if err != nil || u.User == nil {
+		return addr, nil
+	}
+	password, _ := u.User.Password()
+	return addr, &proxy.Auth{User: u.User.Username(), Password: password}
+}
+
+// WithProxyAuth prefixes a proxy address with credentials, escaping them as in a URL
+func WithProxyAuth(proxyAddr string, username string, password string) string {
+	return url.UserPassword(username, password).String() + "@" + StripProxyAuth(proxyAddr)
+}
+
+// dialContext dials addr through d, honouring ctx if the dialer supports it
+func dialContext(ctx context.Context, d proxy.Dialer, network string, addr string) (net.Conn, error) {
+	if cd, ok := d.(proxy.ContextDialer); ok {
+		return cd.DialContext(ctx, network, addr)
+	}
+	return d.Dial(network, addr)
+}
+
 // Helper function to create an upstream dialer based on proxy type
-func createUpstreamDialer(upstreamProxy string, upstreamType ProxyType, timeout time.Duration) (proxy.Dialer, error) {
-	dialer := &net.Dialer{Timeout: timeout}
+func createUpstreamDialer(ctx context.Context, upstreamProxy string, upstreamType ProxyType, timeout time.Duration) (proxy.Dialer, error) {
+	dialer, err := newDialer(ctx, timeout)
+	if err != nil {
+		return nil, err
+	}
 
 	switch upstreamType {
 	case HTTP, HTTPS:
@@ -391,11 +486,7 @@ func createUpstreamDialer(upstreamProxy string, upstreamType ProxyType, timeout
 
 	case SOCKS4:
 		// For SOCKS4 upstream proxies
-		// Use SOCKS5 with SOCKS4 flag since golang.org/x/net/proxy doesn't have a direct SOCKS4 constructor
-		auth := &proxy.Auth{
-			User: "socks4", // This is a marker for SOCKS4 protocol
-		}
-		return proxy.SOCKS5("tcp", upstreamProxy, auth, dialer)
+		return newSOCKS4Dialer(upstreamProxy, socks4Ident(ctx), dialer, timeout), nil
 
 	case SOCKS5:
 		// For SOCKS5 upstream proxies
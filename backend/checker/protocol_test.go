@@ -0,0 +1,179 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckHTTPClosesConnection(t *testing.T) {
+	var mutex sync.Mutex
+	closed := make(chan struct{}, 1)
+	open := 0
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("203.0.113.7"))
+	}))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch state {
+		case http.StateNew:
+			open++
+		case http.StateClosed, http.StateHijacked:
+			open--
+			closed <- struct{}{}
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	ip, err := CheckHTTP(strings.TrimPrefix(srv.URL, "http://"), "http://judge.invalid/", time.Second, "", "")
+	if err != nil || ip != "203.0.113.7" {
+		t.Fatalf("got %q, %v", ip, err)
+	}
+
+	// The connection must not be kept alive once the check returns
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection still open after the check")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if open != 0 {
+		t.Errorf("%d connections left open", open)
+	}
+}
+
+func TestCheckHTTPDecodesGzip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte("203.0.113.7"))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte("203.0.113.7"))
+		gz.Close()
+	}))
+	defer srv.Close()
+
+	ip, err := CheckHTTP(strings.TrimPrefix(srv.URL, "http://"), "http://judge.invalid/", time.Second, "", "")
+	if err != nil || ip != "203.0.113.7" {
+		t.Fatalf("got %q, %v", ip, err)
+	}
+}
+
+func TestCheckHTTPBindsSourceAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("203.0.113.7"))
+	}))
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	ctx := withSourceAddr(context.Background(), "127.0.0.1")
+	if _, err := CheckHTTPContext(ctx, addr, "http://judge.invalid/", time.Second, "", ""); err != nil {
+		t.Fatalf("bound check failed: %v", err)
+	}
+
+	ctx = withSourceAddr(context.Background(), "no-such-interface0")
+	if _, err := CheckHTTPContext(ctx, addr, "http://judge.invalid/", time.Second, "", ""); !errors.Is(err, ErrSourceAddress) {
+		t.Errorf("expected ErrSourceAddress, got %v", err)
+	}
+}
+
+// serveSOCKS4 runs a SOCKS4a proxy granting requests sent with ident and answering them
+// with a judge response; other requests are rejected with code 93
+func serveSOCKS4(t *testing.T, ident string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				header := make([]byte, 8)
+				if _, err := io.ReadFull(r, header); err != nil {
+					return
+				}
+				userid, _ := r.ReadString(0)
+				r.ReadString(0) // SOCKS4a hostname
+				if strings.TrimSuffix(userid, "\x00") != ident {
+					conn.Write([]byte{0, SOCKS4IdentMismatch, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				conn.Write([]byte{0, SOCKS4Granted, 0, 0, 0, 0, 0, 0})
+				if _, err := http.ReadRequest(r); err != nil {
+					return
+				}
+				conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Length: 11\r\nConnection: close\r\n\r\n203.0.113.7"))
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestCheckSOCKS4Ident(t *testing.T) {
+	addr := serveSOCKS4(t, "alice")
+
+	ctx := WithSOCKS4Ident(context.Background(), "alice")
+	ip, err := CheckSOCKS4Context(ctx, addr, "http://judge.invalid/", time.Second, "", "")
+	if err != nil || ip != "203.0.113.7" {
+		t.Fatalf("got %q, %v", ip, err)
+	}
+
+	// The username of the proxy address overrides the ident of the context
+	if _, err := CheckSOCKS4Context(ctx, "bob:x@"+addr, "http://judge.invalid/", time.Second, "", ""); !errors.Is(err, ErrIdentRejected) {
+		t.Fatalf("expected ErrIdentRejected, got %v", err)
+	} else if kind := ErrorKind(err); kind != "ident rejected" {
+		t.Errorf("got kind %q", kind)
+	}
+}
+
+func TestCheckProxyRecordsResolution(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("203.0.113.7"))
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+
+	m := NewManager()
+	req := ProxyCheckRequest{ProxyType: HTTP, Endpoint: "http://judge.invalid/", CheckEachIP: true}
+	result := m.checkProxy(context.Background(), req, "localhost:"+port, func(string) {})
+	if result.Status != "LIVE" || result.DialedIP == "" || len(result.ResolvedIPs) == 0 {
+		t.Fatalf("got status %q dialed %q resolved %v", result.Status, result.DialedIP, result.ResolvedIPs)
+	}
+	if len(result.IPChecks) != len(result.ResolvedIPs) {
+		t.Errorf("got %d IP checks for %d addresses", len(result.IPChecks), len(result.ResolvedIPs))
+	}
+	if got := withIP("user:pass@gw.example.com:8080", "192.0.2.1"); got != "user:pass@192.0.2.1:8080" {
+		t.Errorf("withIP: got %q", got)
+	}
+}
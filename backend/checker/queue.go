@@ -0,0 +1,140 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// queueItem is a proxy waiting in the job queue
+type queueItem struct {
+	proxy    string
+	priority int
+	seq      int
+}
+
+// jobHeap orders items by priority (highest first), then by insertion order
+type jobHeap []queueItem
+
+func (h jobHeap) Len() int { return len(h) }
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+func (h jobHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *jobHeap) Push(x interface{}) { *h = append(*h, x.(queueItem)) }
+func (h *jobHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// JobQueue is a concurrency-safe priority queue of proxies to check
+// Proxies with equal priority are handed out in the order they were added.
+// A streaming queue is fed while the run is in progress: Pop waits for more
+// proxies until the queue is closed, and Push waits while the queue is full
+type JobQueue struct {
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	items     jobHeap
+	seq       int
+	streaming bool
+	limit     int
+	closed    bool
+}
+
+// NewJobQueue creates a queue of proxies with the given priorities (missing entries default to 0)
+func NewJobQueue(proxies []string, priorities map[string]int) *JobQueue {
+	q := &JobQueue{items: make(jobHeap, 0, len(proxies))}
+	q.cond = sync.NewCond(&q.mutex)
+	for _, proxy := range proxies {
+		q.items = append(q.items, queueItem{proxy: proxy, priority: priorities[proxy], seq: q.seq})
+		q.seq++
+	}
+	heap.Init(&q.items)
+	return q
+}
+
+// NewStreamingQueue creates an empty queue that is fed while proxies are checked
+// At most limit proxies are held at a time (0 for no limit)
+func NewStreamingQueue(limit int) *JobQueue {
+	q := &JobQueue{streaming: true, limit: limit}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+// Push adds a proxy to the queue
+// On a full streaming queue it waits for room; it returns false if the queue was closed
+func (q *JobQueue) Push(proxy string, priority int) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for q.limit > 0 && len(q.items) >= q.limit && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return false
+	}
+
+	heap.Push(&q.items, queueItem{proxy: proxy, priority: priority, seq: q.seq})
+	q.seq++
+	q.cond.Broadcast()
+	return true
+}
+
+// Requeue adds a proxy back to the queue for another check
+// Unlike Push it never waits and also works on a closed queue, so proxies taken from the
+// queue can be returned until the last worker has finished
+func (q *JobQueue) Requeue(proxy string, priority int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	heap.Push(&q.items, queueItem{proxy: proxy, priority: priority, seq: q.seq})
+	q.seq++
+	q.cond.Broadcast()
+}
+
+// Pop removes and returns the highest priority proxy; ok is false when the queue is empty
+// On a streaming queue it waits for more proxies until the queue is closed
+func (q *JobQueue) Pop() (proxy string, ok bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for len(q.items) == 0 && q.streaming && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.items) == 0 {
+		return "", false
+	}
+
+	item := heap.Pop(&q.items).(queueItem)
+	q.cond.Broadcast()
+	return item.proxy, true
+}
+
+// Close marks the end of the input: Pop drains the remaining proxies, then reports
+// the queue as empty, and Push refuses new proxies
+func (q *JobQueue) Close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// Len returns the number of queued proxies
+func (q *JobQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.items)
+}
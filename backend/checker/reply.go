@@ -0,0 +1,120 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ProtocolReply is the protocol-level answer of a proxy that refused a check, e.g. a SOCKS5
+// reply code, the CD byte of a SOCKS4 reply or the HTTP status of a proxy
+type ProtocolReply struct {
+	// Protocol is "socks4", "socks5" or "http"
+	Protocol string `json:"protocol"`
+
+	// Code is the reply code or HTTP status code
+	Code int `json:"code"`
+
+	// Message is the meaning of the code
+	Message string `json:"message"`
+}
+
+// HTTPStatusError is an HTTP status answered instead of the judge response, by the proxy or
+// by the judge through it
+type HTTPStatusError struct {
+	// Kind is the category sentinel, e.g. ErrAuthRequired
+	Kind error
+
+	// Code is the HTTP status code
+	Code int
+
+	// Status is the status line, e.g. "407 Proxy Authentication Required"
+	Status string
+}
+
+// Error returns the error message
+func (e *HTTPStatusError) Error() string {
+	return e.Kind.Error() + ": " + e.Status
+}
+
+// Unwrap returns the category
+func (e *HTTPStatusError) Unwrap() error {
+	return e.Kind
+}
+
+// socks5Replies maps the messages of the SOCKS5 client of golang.org/x/net back to the
+// reply codes it does not expose
+var socks5Replies = map[string]int{
+	"general SOCKS server failure":      0x01,
+	"connection not allowed by ruleset": 0x02,
+	"network unreachable":               0x03,
+	"host unreachable":                  0x04,
+	"connection refused":                0x05,
+	"TTL expired":                       0x06,
+	"command not supported":             0x07,
+	"address type not supported":        0x08,
+}
+
+// ReplyOf returns the protocol-level reply behind a check error, or nil if the proxy never
+// answered with one (timeouts, resets, ...)
+func ReplyOf(err error) *ProtocolReply {
+	if err == nil {
+		return nil
+	}
+
+	var socks4Err *SOCKS4ReplyError
+	if errors.As(err, &socks4Err) {
+		return &ProtocolReply{Protocol: "socks4", Code: int(socks4Err.Code), Message: socks4Err.Error()}
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return &ProtocolReply{Protocol: "http", Code: statusErr.Code, Message: statusErr.Status}
+	}
+
+	return socks5Reply(err.Error())
+}
+
+// socks5Reply parses the reply code out of an error message of the SOCKS5 client
+func socks5Reply(msg string) *ProtocolReply {
+	if strings.Contains(msg, "no acceptable authentication methods") {
+		return &ProtocolReply{Protocol: "socks5", Code: 0xff, Message: "no acceptable authentication methods"}
+	}
+
+	_, reply, ok := strings.Cut(msg, "unknown error ")
+	if !ok {
+		return nil
+	}
+	if code, ok := socks5Replies[reply]; ok {
+		return &ProtocolReply{Protocol: "socks5", Code: code, Message: reply}
+	}
+	if _, n, ok := strings.Cut(reply, "unknown code: "); ok {
+		if code, err := strconv.Atoi(n); err == nil {
+			return &ProtocolReply{Protocol: "socks5", Code: code, Message: reply}
+		}
+	}
+	return nil
+}
+
+// rejectConnect turns a refused CONNECT into a HTTPStatusError keeping its status code, which
+// the transport would otherwise reduce to the status text
+func rejectConnect(ctx context.Context, proxyURL *url.URL, req *http.Request, resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusProxyAuthRequired:
+		return &HTTPStatusError{Kind: ErrAuthRequired, Code: resp.StatusCode, Status: resp.Status}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return &HTTPStatusError{Kind: ErrProxyConnectionFailed, Code: resp.StatusCode, Status: resp.Status}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// MaxThreadsLimit is the highest thread count a request may ask for
+const MaxThreadsLimit = 5000
+
+var (
+	ErrInvalidRequest = errors.New("invalid check request")
+)
+
+// NewCheckRequest builds a request from options over the defaults and validates it
+func NewCheckRequest(proxies []string, opts ...Option) (ProxyCheckRequest, error) {
+	req := newCheckOptions(proxies, opts).req
+	if err := req.Validate(); err != nil {
+		return ProxyCheckRequest{}, err
+	}
+	return req, nil
+}
+
+// Validate checks a request before any work starts
+// All problems are reported together, each wrapping ErrInvalidRequest
+func (req ProxyCheckRequest) Validate() error {
+	return req.validate(false)
+}
+
+// ValidateStream checks a request for Manager.StartStream, whose proxies are not known up front
+func (req ProxyCheckRequest) ValidateStream() error {
+	return req.validate(true)
+}
+
+// validate checks a request; the proxy list may be empty for streamed runs
+func (req ProxyCheckRequest) validate(streamed bool) error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidRequest}, args...)...))
+	}
+
+	if len(req.ProxyList) == 0 && !streamed {
+		invalid("proxy list is empty")
+	}
+
+	if req.Threads < 1 || req.Threads > MaxThreadsLimit {
+		invalid("threads must be between 1 and %d, got %d", MaxThreadsLimit, req.Threads)
+	}
+
+	if !validCheckType(req.ProxyType, true) {
+		invalid("unsupported proxy type %q", req.ProxyType)
+	}
+
+	if err := validateEndpoint(req.Endpoint); err != nil {
+		invalid("endpoint %q: %v", req.Endpoint, err)
+	}
+
+	for _, endpoint := range req.FallbackEndpoints {
+		if err := validateEndpoint(endpoint); err != nil {
+			invalid("fallback endpoint %q: %v", endpoint, err)
+		}
+	}
+
+	if req.FailoverThreshold < 0 {
+		invalid("failover threshold cannot be negative")
+	}
+
+	if req.UpstreamProxy != "" {
+		if _, _, err := net.SplitHostPort(StripProxyAuth(req.UpstreamProxy)); err != nil {
+			invalid("upstream proxy %q must be host:port", req.UpstreamProxy)
+		}
+		if !validCheckType(req.UpstreamType, false) {
+			invalid("unsupported upstream proxy type %q", req.UpstreamType)
+		}
+	}
+
+	if req.MaxLatency < 0 {
+		invalid("max latency cannot be negative")
+	}
+
+	if req.WatchdogFactor != 0 && req.WatchdogFactor < 1 {
+		invalid("watchdog factor must be at least 1, got %g", req.WatchdogFactor)
+	}
+
+	if _, err := ResolveSourceAddr(req.SourceAddress); err != nil {
+		invalid("%v", err)
+	}
+
+	switch req.Order {
+	case "", OrderOriginal, OrderShuffled, OrderBySubnet, OrderInterleaveSource:
+	default:
+		invalid("unknown order %q", req.Order)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validCheckType reports whether t is a proxy type that can be checked
+func validCheckType(t ProxyType, allowAuto bool) bool {
+	switch t {
+	case HTTP, HTTPS, SOCKS4, SOCKS5:
+		return true
+	case Auto:
+		return allowAuto
+	default:
+		return false
+	}
+}
+
+// validateEndpoint checks that an endpoint is an absolute http(s) URL
+func validateEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return errors.New("endpoint is empty")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("scheme must be http or https")
+	}
+	if u.Host == "" {
+		return errors.New("host is missing")
+	}
+	return nil
+}
@@ -28,8 +28,17 @@ const (
 	// StatusDead indicates the proxy is not working
 	StatusDead ProxyStatus = "dead"
 
+	// StatusSlow indicates the proxy is working but slower than the acceptable latency
+	StatusSlow ProxyStatus = "slow"
+
+	// StatusBlocked indicates the proxy works but its ACL refuses the endpoint
+	StatusBlocked ProxyStatus = "blocked"
+
 	// StatusError indicates an error occurred during the proxy check
 	StatusError ProxyStatus = "error"
+
+	// StatusAborted indicates the check was cancelled by a forced stop before it finished
+	StatusAborted ProxyStatus = "aborted"
 )
 
 // ProxyResult represents the result of a proxy check
@@ -49,15 +58,69 @@ type ProxyResult struct {
 	// OutgoingIP is the IP address seen by the endpoint when using this proxy
 	OutgoingIP string `json:"outgoingIp"`
 
+	// IPVersion is 4 or 6, the IP version the exit uses (0 if unknown)
+	IPVersion int `json:"ipVersion,omitempty"`
+
+	// DialedIP is the address a proxy given as a hostname was reached at
+	DialedIP string `json:"dialedIp,omitempty"`
+
+	// ResolvedIPs are all the addresses a proxy given as a hostname resolves to
+	ResolvedIPs []string `json:"resolvedIps,omitempty"`
+
+	// IPChecks are the outcomes of checking each resolved address separately, if enabled
+	IPChecks []IPCheck `json:"ipChecks,omitempty"`
+
+	// Hostname is the reverse DNS name of the outgoing IP (if PTR lookups are enabled)
+	Hostname string `json:"hostname,omitempty"`
+
+	// Network is the RDAP registration of the outgoing IP (if RDAP lookups are enabled)
+	Network *NetworkInfo `json:"network,omitempty"`
+
 	// Country is the country of the proxy (if geolocation is enabled)
 	Country string `json:"country"`
 
 	// CountryCode is the ISO country code of the proxy (if geolocation is enabled)
 	CountryCode string `json:"countryCode"`
 
+	// City, Region, RegionCode, Timezone and the coordinates need a city-level GeoIP database
+	City       string  `json:"city,omitempty"`
+	Region     string  `json:"region,omitempty"`
+	RegionCode string  `json:"regionCode,omitempty"`
+	Timezone   string  `json:"timezone,omitempty"`
+	Latitude   float64 `json:"latitude,omitempty"`
+	Longitude  float64 `json:"longitude,omitempty"`
+
+	// JudgeDate is the Date header of the judge response, if it had one
+	JudgeDate *time.Time `json:"judgeDate,omitempty"`
+
+	// ClockSkew is how far (seconds) the judge response Date is ahead of the local clock
+	ClockSkew int64 `json:"clockSkew,omitempty"`
+
+	// StaleResponse flags a judge response dated well in the past, likely replayed by a cache
+	StaleResponse bool `json:"staleResponse,omitempty"`
+
+	// Cached flags a judge response served from a cache despite the unique token of the check
+	Cached bool `json:"cached,omitempty"`
+
+	// CacheEvidence is the response header that gave the cache away
+	CacheEvidence string `json:"cacheEvidence,omitempty"`
+
+	// Bytes is the traffic (sent and received) of the check and its probes
+	Bytes int64 `json:"bytes,omitempty"`
+
 	// Error is the error message if the proxy check failed
 	Error string `json:"error"`
 
+	// ErrorKind is the category of the failure (timeout, connection refused, ...), see ErrorKind
+	ErrorKind string `json:"errorKind,omitempty"`
+
+	// Suspect flags a failure checked around a system sleep, likely caused by the suspend
+	// rather than the proxy
+	Suspect bool `json:"suspect,omitempty"`
+
+	// Reply is the protocol-level answer of a proxy that refused the check, if any
+	Reply *ProtocolReply `json:"reply,omitempty"`
+
 	// Timestamp is when the check was completed
 	Timestamp time.Time `json:"timestamp"`
 
@@ -66,6 +129,18 @@ type ProxyResult struct {
 
 	// SupportsHTTPS indicates if the proxy supports HTTPS connections
 	SupportsHTTPS bool `json:"supportsHttps"`
+
+	// Source is where the proxy was imported from (file, URL, scraper)
+	Source string `json:"source,omitempty"`
+
+	// Vantage is the agent the proxy was checked from (empty for local checks)
+	Vantage string `json:"vantage,omitempty"`
+
+	// Metadata is the purchase information imported with the proxy, if any
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// Probes holds the outcome of the advanced diagnostics, if any ran
+	Probes *Probes `json:"probes,omitempty"`
 }
 
 // NewPendingResult creates a new ProxyResult with status pending
@@ -137,12 +212,37 @@ func (r *ProxyResult) Clone() *ProxyResult {
 		Status:        r.Status,
 		Latency:       r.Latency,
 		OutgoingIP:    r.OutgoingIP,
+		IPVersion:     r.IPVersion,
+		Hostname:      r.Hostname,
+		DialedIP:      r.DialedIP,
+		ResolvedIPs:   append([]string(nil), r.ResolvedIPs...),
+		IPChecks:      append([]IPCheck(nil), r.IPChecks...),
 		Country:       r.Country,
 		CountryCode:   r.CountryCode,
+		City:          r.City,
+		Region:        r.Region,
+		RegionCode:    r.RegionCode,
+		Timezone:      r.Timezone,
+		Latitude:      r.Latitude,
+		Longitude:     r.Longitude,
 		Error:         r.Error,
+		ErrorKind:     r.ErrorKind,
+		Reply:         r.Reply,
+		Suspect:       r.Suspect,
 		Timestamp:     r.Timestamp,
 		Anonymous:     r.Anonymous,
 		SupportsHTTPS: r.SupportsHTTPS,
+		Source:        r.Source,
+		Vantage:       r.Vantage,
+		Metadata:      r.Metadata.clone(),
+		Network:       r.Network.clone(),
+		Probes:        r.Probes.clone(),
+		JudgeDate:     r.JudgeDate,
+		ClockSkew:     r.ClockSkew,
+		StaleResponse: r.StaleResponse,
+		Cached:        r.Cached,
+		CacheEvidence: r.CacheEvidence,
+		Bytes:         r.Bytes,
 	}
 }
 
@@ -189,6 +289,19 @@ func (l ProxyResultList) FilterByType(proxyType ProxyType) ProxyResultList {
 	return result
 }
 
+// FilterBySource returns a new list containing only results imported from the specified source
+func (l ProxyResultList) FilterBySource(source string) ProxyResultList {
+	var result ProxyResultList
+
+	for _, r := range l {
+		if r.Source == source {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
 // GetLiveProxies returns a list of working proxy addresses (ip:port format)
 func (l ProxyResultList) GetLiveProxies() []string {
 	var result []string
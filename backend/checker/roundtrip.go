@@ -0,0 +1,205 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// callbackPath is the path prefix of the round-trip callbacks, followed by the token
+const callbackPath = "/rt/"
+
+var (
+	ErrCallbackURL     = errors.New("round-trip callback needs a public http URL")
+	ErrCallbackMissing = errors.New("the callback listener never saw the request")
+)
+
+// RoundTripOutcome is the outcome of the round-trip probe
+type RoundTripOutcome struct {
+	// Passed is true if the request through the proxy reached the callback listener
+	Passed  bool  `json:"passed"`
+	Latency int64 `json:"latency"`
+
+	// CallerIP is the proxy's egress address as the callback listener saw it. It is only
+	// set when the listener is reached directly; behind a loopback relay, the default,
+	// the listener only sees the relay and it is left empty
+	CallerIP string `json:"callerIp,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// CallbackListener is a temporary HTTP listener that proxies are asked to reach back to,
+// telling egress-only proxies, which reach public judges but not arbitrary hosts, from
+// proxies that can connect anywhere. It must be reachable at its public URL, directly
+// or through a relay forwarding to it
+type CallbackListener struct {
+	mutex     sync.Mutex
+	publicURL string
+	listener  net.Listener
+	srv       *http.Server
+	pending   map[string]callbackHit // token of a probe in flight -> its callback, once seen
+}
+
+// callbackHit records the callback of a probe
+type callbackHit struct {
+	seen   bool
+	caller string
+}
+
+// NewCallbackListener starts a callback listener on listenAddr, reachable from the
+// internet at publicURL (e.g. http://203.0.113.7:8089, or a relay URL forwarding to a
+// loopback listenAddr)
+func NewCallbackListener(listenAddr string, publicURL string) (*CallbackListener, error) {
+	publicURL = strings.TrimRight(publicURL, "/")
+	if !strings.HasPrefix(publicURL, "http://") && !strings.HasPrefix(publicURL, "https://") {
+		return nil, ErrCallbackURL
+	}
+
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+
+	l := &CallbackListener{
+		publicURL: publicURL,
+		listener:  listener,
+		pending:   make(map[string]callbackHit),
+	}
+	l.srv = &http.Server{Handler: l, ReadHeaderTimeout: 10 * time.Second}
+	go l.srv.Serve(listener)
+	return l, nil
+}
+
+// Addr returns the local address the listener is bound to
+func (l *CallbackListener) Addr() string {
+	return l.listener.Addr().String()
+}
+
+// PublicURL returns the URL proxies reach the listener at
+func (l *CallbackListener) PublicURL() string {
+	return l.publicURL
+}
+
+// Close stops the listener
+func (l *CallbackListener) Close() error {
+	return l.srv.Close()
+}
+
+// ServeHTTP records the callback of a pending token and echoes the token back; tokens no
+// probe is waiting for are refused. The caller is the peer address of the connection,
+// which is the relay if one forwards to the listener
+func (l *CallbackListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, _ := strings.CutPrefix(r.URL.Path, callbackPath)
+	// A loopback caller is the relay, not the proxy
+	caller, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		caller = r.RemoteAddr
+	}
+	if ip := net.ParseIP(caller); ip != nil && ip.IsLoopback() {
+		caller = ""
+	}
+
+	l.mutex.Lock()
+	_, ok := l.pending[token]
+	if ok {
+		l.pending[token] = callbackHit{seen: true, caller: caller}
+	}
+	l.mutex.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(token))
+}
+
+// expect registers the token of a probe about to run
+func (l *CallbackListener) expect(token string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.pending[token] = callbackHit{}
+}
+
+// take returns the caller of a token and whether its callback arrived, and forgets the
+// token so that late callbacks are refused
+func (l *CallbackListener) take(token string) (string, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	hit := l.pending[token]
+	delete(l.pending, token)
+	return hit.caller, hit.seen
+}
+
+// ProbeRoundTrip asks the proxy to fetch a unique URL of the callback listener; it passes
+// only if the listener saw the request and the proxy relayed its answer
+func ProbeRoundTrip(ctx context.Context, proxyAddr string, proxyType ProxyType, callback *CallbackListener, timeout time.Duration, upstreamProxy string, upstreamType ProxyType) RoundTripOutcome {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return RoundTripOutcome{Error: fmt.Sprintf("failed to generate token: %v", err)}
+	}
+	token := hex.EncodeToString(buf)
+	callback.expect(token)
+	defer callback.take(token)
+
+	client, closeIdle, err := newProxyClient(ctx, proxyAddr, proxyType, timeout, upstreamProxy, upstreamType)
+	if err != nil {
+		return RoundTripOutcome{Error: err.Error()}
+	}
+	defer closeIdle()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", callback.PublicURL()+callbackPath+token, nil)
+	if err != nil {
+		return RoundTripOutcome{Error: fmt.Sprintf("failed to create request: %v", err)}
+	}
+	req.Close = true
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	caller, seen := callback.take(token)
+	if err != nil {
+		outcome := RoundTripOutcome{CallerIP: caller, Error: classify("callback request failed", err).Error()}
+		if seen {
+			// The request got out but the answer never came back
+			outcome.Error = "the proxy reached the callback but did not relay the answer: " + outcome.Error
+		}
+		return outcome
+	}
+	defer resp.Body.Close()
+
+	outcome := RoundTripOutcome{Latency: time.Since(start).Milliseconds(), CallerIP: caller}
+	if err := checkStatus(resp); err != nil {
+		outcome.Error = err.Error()
+		return outcome
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		outcome.Error = err.Error()
+		return outcome
+	}
+	switch {
+	case !seen:
+		outcome.Error = ErrCallbackMissing.Error()
+	case strings.TrimSpace(string(body)) != token:
+		outcome.Error = "the callback answer was altered on the way back"
+	default:
+		outcome.Passed = true
+	}
+	return outcome
+}
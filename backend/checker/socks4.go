@@ -0,0 +1,185 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// SOCKS4 reply codes (the CD byte of a reply)
+const (
+	SOCKS4Granted       byte = 90
+	SOCKS4Rejected      byte = 91
+	SOCKS4IdentUnreach  byte = 92
+	SOCKS4IdentMismatch byte = 93
+)
+
+// SOCKS4ReplyError is a SOCKS4 request refused by the proxy
+// Codes 92 and 93 match ErrIdentRejected
+type SOCKS4ReplyError struct {
+	Code byte
+}
+
+// Error returns the error message
+func (e *SOCKS4ReplyError) Error() string {
+	switch e.Code {
+	case SOCKS4Rejected:
+		return "socks4 request rejected or failed (code 91)"
+	case SOCKS4IdentUnreach:
+		return "socks4 ident rejected: identd unreachable (code 92)"
+	case SOCKS4IdentMismatch:
+		return "socks4 ident rejected: userid mismatch (code 93)"
+	default:
+		return fmt.Sprintf("socks4 request failed (code %d)", e.Code)
+	}
+}
+
+// Is reports whether the reply is an ident rejection
+func (e *SOCKS4ReplyError) Is(target error) bool {
+	return target == ErrIdentRejected && (e.Code == SOCKS4IdentUnreach || e.Code == SOCKS4IdentMismatch)
+}
+
+// socks4IdentKey is the context key of the SOCKS4 userid
+type socks4IdentKey struct{}
+
+// WithSOCKS4Ident returns a context making the SOCKS4 connections dialed with it send ident
+// as their userid
+func WithSOCKS4Ident(ctx context.Context, ident string) context.Context {
+	return context.WithValue(ctx, socks4IdentKey{}, ident)
+}
+
+// socks4Ident returns the SOCKS4 userid of ctx, if any
+func socks4Ident(ctx context.Context) string {
+	ident, _ := ctx.Value(socks4IdentKey{}).(string)
+	return ident
+}
+
+// socks4Dialer connects through a SOCKS4 proxy, falling back to SOCKS4a for hostnames
+// that are not IPv4 addresses
+type socks4Dialer struct {
+	proxyAddr string
+	ident     string
+	forward   proxy.Dialer
+	timeout   time.Duration
+}
+
+// newSOCKS4Dialer returns a dialer connecting through the SOCKS4 proxy at proxyAddr
+func newSOCKS4Dialer(proxyAddr string, ident string, forward proxy.Dialer, timeout time.Duration) *socks4Dialer {
+	return &socks4Dialer{proxyAddr: proxyAddr, ident: ident, forward: forward, timeout: timeout}
+}
+
+// Dial connects to addr through the proxy
+func (d *socks4Dialer) Dial(network string, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+// DialContext connects to addr through the proxy, giving up as soon as ctx is cancelled
+func (d *socks4Dialer) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	if network != "tcp" && network != "tcp4" {
+		return nil, fmt.Errorf("%w: socks4 cannot dial %s", ErrUnsupportedProxyType, network)
+	}
+	request, err := socks4Request(addr, d.ident)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialContext(ctx, d.forward, "tcp", d.proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The handshake is bounded by the timeout and by ctx
+	deadline := time.Now().Add(d.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if err := socks4Handshake(conn, request); err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if !stop() {
+		conn.Close()
+		return nil, ctx.Err()
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// socks4Request builds the CONNECT request to addr
+// Hostnames are sent with the SOCKS4a extension, letting the proxy resolve them
+func socks4Request(addr string, ident string) ([]byte, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProxyFormat, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid port %s", ErrInvalidProxyFormat, portStr)
+	}
+
+	if strings.IndexByte(ident, 0) >= 0 {
+		return nil, fmt.Errorf("%w: socks4 userid contains a NUL byte", ErrInvalidProxyFormat)
+	}
+
+	request := []byte{4, 1, 0, 0}
+	binary.BigEndian.PutUint16(request[2:], uint16(port))
+
+	ip := net.ParseIP(host).To4()
+	if ip == nil && net.ParseIP(host) != nil {
+		return nil, fmt.Errorf("%w: socks4 cannot reach IPv6 address %s", ErrUnsupportedProxyType, host)
+	}
+	if ip != nil {
+		request = append(request, ip...)
+	} else {
+		request = append(request, 0, 0, 0, 1) // 0.0.0.x marks a SOCKS4a request
+	}
+	request = append(request, ident...)
+	request = append(request, 0)
+	if ip == nil {
+		request = append(request, host...)
+		request = append(request, 0)
+	}
+	return request, nil
+}
+
+// socks4Handshake sends request over conn and reads the reply
+func socks4Handshake(conn net.Conn, request []byte) error {
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	reply := make([]byte, 8)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != 0 {
+		return errors.New("socks4: malformed reply from proxy")
+	}
+	if reply[1] != SOCKS4Granted {
+		return &SOCKS4ReplyError{Code: reply[1]}
+	}
+	return nil
+}
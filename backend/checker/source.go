@@ -0,0 +1,93 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+var (
+	ErrSourceAddress = errors.New("source address unavailable")
+)
+
+// ResolveSourceAddr resolves the source of checks, an IP address or the name of a network
+// interface, to the local address connections are bound to; an empty source returns nil
+// An interface is resolved to its first IPv4 address, or its first address if it has none
+func ResolveSourceAddr(source string) (*net.TCPAddr, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	if ip := net.ParseIP(source); ip != nil {
+		return &net.TCPAddr{IP: ip}, nil
+	}
+
+	iface, err := net.InterfaceByName(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is neither an IP address nor an interface", ErrSourceAddress, source)
+	}
+	if iface.Flags&net.FlagUp == 0 {
+		return nil, fmt.Errorf("%w: interface %s is down", ErrSourceAddress, source)
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("%w: interface %s: %v", ErrSourceAddress, source, err)
+	}
+
+	var first net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return &net.TCPAddr{IP: ipNet.IP}, nil
+		}
+		if first == nil {
+			first = ipNet.IP
+		}
+	}
+	if first == nil {
+		return nil, fmt.Errorf("%w: interface %s has no usable address", ErrSourceAddress, source)
+	}
+	return &net.TCPAddr{IP: first}, nil
+}
+
+// sourceAddrKey is the context key of the source of checks
+type sourceAddrKey struct{}
+
+// withSourceAddr returns a context binding the connections dialed with it to source
+func withSourceAddr(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceAddrKey{}, source)
+}
+
+// newDialer returns a dialer bound to the source of ctx, if any
+// The source is resolved on every dial, so a check fails instead of silently leaving
+// through the default route once a bound interface goes away
+func newDialer(ctx context.Context, timeout time.Duration) (*net.Dialer, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+
+	source, _ := ctx.Value(sourceAddrKey{}).(string)
+	addr, err := ResolveSourceAddr(source)
+	if err != nil {
+		return nil, err
+	}
+	if addr != nil {
+		dialer.LocalAddr = addr
+	}
+	if rec, ok := ctx.Value(dialRecorderKey{}).(*dialRecorder); ok {
+		dialer.ControlContext = rec.control
+	}
+	return dialer, nil
+}
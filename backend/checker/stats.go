@@ -9,6 +9,7 @@
 package checker
 
 import (
+	"strings"
 	"sync"
 	"time"
 )
@@ -21,12 +22,21 @@ type Stats struct {
 	// Live is the number of working proxies
 	Live int `json:"live"`
 
+	// Slow is the number of working proxies above the acceptable latency
+	Slow int `json:"slow"`
+
 	// Dead is the number of non-working proxies
 	Dead int `json:"dead"`
 
+	// Blocked is the number of working proxies whose ACL refuses the endpoint
+	Blocked int `json:"blocked"`
+
 	// Errors is the number of proxies that resulted in errors
 	Errors int `json:"errors"`
 
+	// Aborted is the number of checks cancelled by a forced stop
+	Aborted int `json:"aborted"`
+
 	// Pending is the number of proxies waiting to be checked
 	Pending int `json:"pending"`
 
@@ -36,6 +46,16 @@ type Stats struct {
 	// TypeCounts is a map of proxy types to their counts
 	TypeCounts map[ProxyType]int `json:"typeCounts"`
 
+	// SourceStats is a map of import sources to their live-rate statistics
+	SourceStats map[string]SourceStats `json:"sourceStats"`
+
+	// ErrorKinds is a map of failure categories (see ErrorKind) to their counts
+	ErrorKinds map[string]int `json:"errorKinds"`
+
+	// BytesTransferred is the traffic of the completed checks, to gauge the cost of a run
+	// on a metered connection
+	BytesTransferred int64 `json:"bytesTransferred"`
+
 	// SuccessRate is the percentage of successful checks (live proxies)
 	SuccessRate float64 `json:"successRate"`
 
@@ -58,23 +78,74 @@ type Stats struct {
 	EstimatedTimeRemaining time.Duration `json:"estimatedTimeRemaining"`
 }
 
+// SourceStats represents the live-rate statistics of a single import source
+type SourceStats struct {
+	// Total is the number of proxies imported from the source
+	Total int `json:"total"`
+
+	// Checked is the number of proxies from the source that have been checked
+	Checked int `json:"checked"`
+
+	// Live is the number of working proxies from the source
+	Live int `json:"live"`
+
+	// LiveRate is the percentage of checked proxies from the source that are live
+	LiveRate float64 `json:"liveRate"`
+}
+
+// addSourceTotal counts a proxy towards the total of its source
+func (s *Stats) addSourceTotal(source string) {
+	if source == "" {
+		return
+	}
+
+	ss := s.SourceStats[source]
+	ss.Total++
+	s.SourceStats[source] = ss
+}
+
+// recordSourceResult updates the statistics of a source with a completed check
+func (s *Stats) recordSourceResult(source string, live bool) {
+	if source == "" {
+		return
+	}
+
+	ss := s.SourceStats[source]
+	ss.Checked++
+	if live {
+		ss.Live++
+	}
+	ss.LiveRate = float64(ss.Live) / float64(ss.Checked) * 100
+	s.SourceStats[source] = ss
+}
+
 // StatsTracker keeps track of proxy check statistics
+// All counters of a run are updated through it so Pending, Checking and the
+// completed counts always add up to Total
 type StatsTracker struct {
 	stats      Stats
 	mutex      sync.RWMutex
 	startTime  time.Time
+	endTime    time.Time
 	totalTime  int64
 	totalCount int
+	// inFlight counts the proxies marked as checking, by address
+	inFlight map[string]int
+	// expectedRate is the historical checks per second used while the run ramps up
+	expectedRate float64
 }
 
 // NewStatsTracker creates a new StatsTracker
 func NewStatsTracker() *StatsTracker {
 	return &StatsTracker{
 		stats: Stats{
-			TypeCounts: make(map[ProxyType]int),
-			StartTime:  time.Now(),
+			TypeCounts:  make(map[ProxyType]int),
+			SourceStats: make(map[string]SourceStats),
+			ErrorKinds:  make(map[string]int),
+			StartTime:   time.Now(),
 		},
 		startTime: time.Now(),
+		inFlight:  make(map[string]int),
 	}
 }
 
@@ -84,32 +155,105 @@ func (st *StatsTracker) Reset(totalProxies int) {
 	defer st.mutex.Unlock()
 
 	st.stats = Stats{
-		Total:      totalProxies,
-		Pending:    totalProxies,
-		TypeCounts: make(map[ProxyType]int),
-		StartTime:  time.Now(),
+		Total:       totalProxies,
+		Pending:     totalProxies,
+		TypeCounts:  make(map[ProxyType]int),
+		SourceStats: make(map[string]SourceStats),
+		ErrorKinds:  make(map[string]int),
+		StartTime:   time.Now(),
 	}
 
 	st.startTime = time.Now()
+	st.endTime = time.Time{}
 	st.totalTime = 0
 	st.totalCount = 0
+	st.inFlight = make(map[string]int)
+	st.expectedRate = 0
+}
+
+// AddPending counts a proxy added to a streamed run, whose total is not known up front
+func (st *StatsTracker) AddPending(source string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.stats.Total++
+	st.stats.Pending++
+	st.stats.addSourceTotal(source)
+}
+
+// SetThreadCount records the number of threads used by the run
+func (st *StatsTracker) SetThreadCount(threads int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.stats.ThreadCount = threads
+}
+
+// SetExpectedRate sets the checks per second of past runs at the thread count of the run
+func (st *StatsTracker) SetExpectedRate(rate float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.expectedRate = rate
+}
+
+// AddSourceTotal counts a queued proxy towards the total of its import source
+func (st *StatsTracker) AddSourceTotal(source string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.stats.addSourceTotal(source)
 }
 
 // UpdateWithResult updates statistics based on a proxy check result
+// A result with StatusChecking moves a proxy from pending to checking; a completed
+// result moves it from checking (or from pending, if it was never marked) to its final count
 func (st *StatsTracker) UpdateWithResult(result *ProxyResult) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
+	status := ProxyStatus(strings.ToLower(string(result.Status)))
+
+	switch status {
+	case StatusPending:
+		// No change needed for pending status
+		return
+
+	case StatusChecking:
+		st.stats.Checking++
+		st.stats.Pending--
+		st.inFlight[result.Proxy]++
+		return
+	}
+
+	// The check is complete
+	if st.inFlight[result.Proxy] > 0 {
+		st.inFlight[result.Proxy]--
+		if st.inFlight[result.Proxy] == 0 {
+			delete(st.inFlight, result.Proxy)
+		}
+		st.stats.Checking--
+	} else {
+		st.stats.Pending--
+	}
+
+	st.stats.BytesTransferred += result.Bytes
+
 	// Update type counts
 	if result.Type != "" {
-		st.stats.TypeCounts[result.Type] = st.stats.TypeCounts[result.Type] + 1
+		st.stats.TypeCounts[result.Type]++
+	}
+
+	// Update source statistics; an aborted check says nothing about its source
+	if status != StatusAborted {
+		st.stats.recordSourceResult(result.Source, status == StatusLive || status == StatusSlow)
 	}
 
 	// Update status counts
-	switch result.Status {
-	case StatusLive:
-		st.stats.Live++
-		st.stats.Pending--
+	switch status {
+	case StatusLive, StatusSlow:
+		if status == StatusLive {
+			st.stats.Live++
+		} else {
+			st.stats.Slow++
+		}
 
 		// Update speed statistics
 		if result.Latency > 0 {
@@ -120,39 +264,144 @@ func (st *StatsTracker) UpdateWithResult(result *ProxyResult) {
 
 	case StatusDead:
 		st.stats.Dead++
-		st.stats.Pending--
 
-	case StatusError:
+	case StatusBlocked:
+		st.stats.Blocked++
+
+	case StatusAborted:
+		st.stats.Aborted++
+
+	default:
 		st.stats.Errors++
-		st.stats.Pending--
+	}
 
-	case StatusChecking:
-		st.stats.Checking++
-		st.stats.Pending--
+	if result.ErrorKind != "" {
+		st.stats.ErrorKinds[result.ErrorKind]++
+	}
 
-	case StatusPending:
-		// No change needed for pending status
+	st.updateRatesLocked()
+}
+
+// Requeue turns a completed result back into a pending proxy, to be checked again
+func (st *StatsTracker) Requeue(result *ProxyResult) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	status := ProxyStatus(strings.ToLower(string(result.Status)))
+	if counter := st.counterLocked(status); counter != nil {
+		*counter--
+	}
+	st.stats.Pending++
+
+	if result.Type != "" {
+		st.stats.TypeCounts[result.Type]--
+	}
+	if result.ErrorKind != "" {
+		if st.stats.ErrorKinds[result.ErrorKind]--; st.stats.ErrorKinds[result.ErrorKind] <= 0 {
+			delete(st.stats.ErrorKinds, result.ErrorKind)
+		}
+	}
+	if ss, ok := st.stats.SourceStats[result.Source]; ok && ss.Checked > 0 {
+		ss.Checked--
+		if status == StatusLive || status == StatusSlow {
+			ss.Live--
+		}
+		ss.LiveRate = 0
+		if ss.Checked > 0 {
+			ss.LiveRate = float64(ss.Live) / float64(ss.Checked) * 100
+		}
+		st.stats.SourceStats[result.Source] = ss
+	}
+
+	st.updateRatesLocked()
+}
+
+// MoveStatus moves one completed proxy from one status count to another
+// Used when results are reclassified without being rechecked
+func (st *StatsTracker) MoveStatus(from ProxyStatus, to ProxyStatus) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if counter := st.counterLocked(from); counter != nil {
+		*counter--
+	}
+	if counter := st.counterLocked(to); counter != nil {
+		*counter++
+	}
+
+	st.updateRatesLocked()
+}
+
+// counterLocked returns the counter of a completed status (must be called with mutex locked)
+func (st *StatsTracker) counterLocked(status ProxyStatus) *int {
+	switch ProxyStatus(strings.ToLower(string(status))) {
+	case StatusLive:
+		return &st.stats.Live
+	case StatusSlow:
+		return &st.stats.Slow
+	case StatusDead:
+		return &st.stats.Dead
+	case StatusBlocked:
+		return &st.stats.Blocked
+	case StatusError:
+		return &st.stats.Errors
+	default:
+		return nil
 	}
+}
 
+// completedLocked returns the number of completed checks (must be called with mutex locked)
+func (st *StatsTracker) completedLocked() int {
+	return st.stats.Live + st.stats.Slow + st.stats.Dead + st.stats.Blocked + st.stats.Errors
+}
+
+// updateRatesLocked recalculates the success rate and time estimates (must be called with mutex locked)
+func (st *StatsTracker) updateRatesLocked() {
 	// Calculate success rate
-	completedChecks := st.stats.Live + st.stats.Dead + st.stats.Errors
+	completedChecks := st.completedLocked()
 	if completedChecks > 0 {
-		st.stats.SuccessRate = float64(st.stats.Live) / float64(completedChecks) * 100
+		st.stats.SuccessRate = float64(st.stats.Live+st.stats.Slow) / float64(completedChecks) * 100
 	}
 
 	// Calculate elapsed time and checks per second
-	st.stats.ElapsedTime = time.Since(st.startTime)
+	end := st.endTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+	st.stats.ElapsedTime = end.Sub(st.startTime)
 	if st.stats.ElapsedTime.Seconds() > 0 {
 		st.stats.ChecksPerSecond = float64(completedChecks) / st.stats.ElapsedTime.Seconds()
 	}
 
 	// Estimate time remaining
-	if st.stats.ChecksPerSecond > 0 && st.stats.Pending > 0 {
-		remainingSeconds := float64(st.stats.Pending) / st.stats.ChecksPerSecond
+	st.stats.EstimatedTimeRemaining = 0
+	if rate := st.etaRateLocked(completedChecks); rate > 0 && st.stats.Pending > 0 {
+		remainingSeconds := float64(st.stats.Pending) / rate
 		st.stats.EstimatedTimeRemaining = time.Duration(remainingSeconds * float64(time.Second))
 	}
 }
 
+// etaRateLocked returns the checks per second used to estimate the time remaining
+// The first checks to complete are mostly fast failures, so the rate of the run alone is
+// far too optimistic early on: it is blended with the historical rate, weighing the run
+// more as checks complete, until about two checks per thread are done
+// (must be called with mutex locked)
+func (st *StatsTracker) etaRateLocked(completedChecks int) float64 {
+	if st.expectedRate <= 0 {
+		return st.stats.ChecksPerSecond
+	}
+	if st.stats.ChecksPerSecond <= 0 {
+		return st.expectedRate
+	}
+
+	warmup := 2 * st.stats.ThreadCount
+	if warmup <= 0 {
+		warmup = 2 * DefaultThreads
+	}
+	weight := float64(completedChecks) / float64(completedChecks+warmup)
+	return weight*st.stats.ChecksPerSecond + (1-weight)*st.expectedRate
+}
+
 // MarkCheckingAsDead marks all checking proxies as dead
 // Used when force stopping a check
 func (st *StatsTracker) MarkCheckingAsDead() {
@@ -161,12 +410,18 @@ func (st *StatsTracker) MarkCheckingAsDead() {
 
 	st.stats.Dead += st.stats.Checking
 	st.stats.Checking = 0
+	st.inFlight = make(map[string]int)
 
-	// Recalculate success rate
-	completedChecks := st.stats.Live + st.stats.Dead + st.stats.Errors
-	if completedChecks > 0 {
-		st.stats.SuccessRate = float64(st.stats.Live) / float64(completedChecks) * 100
-	}
+	st.updateRatesLocked()
+}
+
+// Finish freezes the elapsed time at the end of a run
+func (st *StatsTracker) Finish() {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.endTime = time.Now()
+	st.updateRatesLocked()
 }
 
 // GetStats returns a copy of the current statistics
@@ -175,27 +430,26 @@ func (st *StatsTracker) GetStats() Stats {
 	defer st.mutex.RUnlock()
 
 	// Create a copy of the stats to avoid race conditions
-	statsCopy := Stats{
-		Total:                  st.stats.Total,
-		Live:                   st.stats.Live,
-		Dead:                   st.stats.Dead,
-		Errors:                 st.stats.Errors,
-		Pending:                st.stats.Pending,
-		Checking:               st.stats.Checking,
-		SuccessRate:            st.stats.SuccessRate,
-		AverageSpeed:           st.stats.AverageSpeed,
-		ChecksPerSecond:        st.stats.ChecksPerSecond,
-		StartTime:              st.stats.StartTime,
-		ElapsedTime:            st.stats.ElapsedTime,
-		EstimatedTimeRemaining: st.stats.EstimatedTimeRemaining,
-		TypeCounts:             make(map[ProxyType]int),
-	}
+	statsCopy := st.stats
+	statsCopy.TypeCounts = make(map[ProxyType]int, len(st.stats.TypeCounts))
+	statsCopy.SourceStats = make(map[string]SourceStats, len(st.stats.SourceStats))
+	statsCopy.ErrorKinds = make(map[string]int, len(st.stats.ErrorKinds))
 
 	// Copy the type counts map
 	for k, v := range st.stats.TypeCounts {
 		statsCopy.TypeCounts[k] = v
 	}
 
+	// Copy the source statistics map
+	for k, v := range st.stats.SourceStats {
+		statsCopy.SourceStats[k] = v
+	}
+
+	// Copy the error categories map
+	for k, v := range st.stats.ErrorKinds {
+		statsCopy.ErrorKinds[k] = v
+	}
+
 	return statsCopy
 }
 
@@ -205,17 +459,7 @@ func (st *StatsTracker) UpdateElapsedTime() {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
-	st.stats.ElapsedTime = time.Since(st.startTime)
-
-	completedChecks := st.stats.Live + st.stats.Dead + st.stats.Errors
-	if st.stats.ElapsedTime.Seconds() > 0 {
-		st.stats.ChecksPerSecond = float64(completedChecks) / st.stats.ElapsedTime.Seconds()
-	}
-
-	if st.stats.ChecksPerSecond > 0 && st.stats.Pending > 0 {
-		remainingSeconds := float64(st.stats.Pending) / st.stats.ChecksPerSecond
-		st.stats.EstimatedTimeRemaining = time.Duration(remainingSeconds * float64(time.Second))
-	}
+	st.updateRatesLocked()
 }
 
 // FormatDuration formats a duration in a human-readable format
@@ -0,0 +1,127 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"testing"
+)
+
+func TestStatsTrackerCountsAddUp(t *testing.T) {
+	st := NewStatsTracker()
+	st.Reset(5)
+
+	results := []ProxyResult{
+		{Proxy: "a:1", Type: HTTP, Status: "LIVE", Latency: 100},
+		{Proxy: "b:1", Type: HTTP, Status: "live", Latency: 300},
+		{Proxy: "c:1", Type: SOCKS5, Status: "DEAD"},
+		{Proxy: "d:1", Type: "bogus", Status: StatusError},
+	}
+
+	for i := range results {
+		st.UpdateWithResult(&ProxyResult{Proxy: results[i].Proxy, Status: StatusChecking})
+		st.UpdateWithResult(&results[i])
+	}
+
+	stats := st.GetStats()
+	if stats.Live != 2 || stats.Dead != 1 || stats.Errors != 1 {
+		t.Fatalf("got live=%d dead=%d errors=%d, want 2/1/1", stats.Live, stats.Dead, stats.Errors)
+	}
+	if stats.Pending != 1 || stats.Checking != 0 {
+		t.Fatalf("got pending=%d checking=%d, want 1/0", stats.Pending, stats.Checking)
+	}
+	if stats.AverageSpeed != 200 {
+		t.Errorf("got average speed %d, want 200", stats.AverageSpeed)
+	}
+	if stats.SuccessRate != 50 {
+		t.Errorf("got success rate %.1f, want 50", stats.SuccessRate)
+	}
+	if stats.TypeCounts[HTTP] != 2 || stats.TypeCounts[SOCKS5] != 1 {
+		t.Errorf("unexpected type counts %v", stats.TypeCounts)
+	}
+}
+
+func TestStatsTrackerCheckingState(t *testing.T) {
+	st := NewStatsTracker()
+	st.Reset(2)
+
+	st.UpdateWithResult(&ProxyResult{Proxy: "a:1", Status: StatusChecking})
+	if stats := st.GetStats(); stats.Pending != 1 || stats.Checking != 1 {
+		t.Fatalf("got pending=%d checking=%d, want 1/1", stats.Pending, stats.Checking)
+	}
+
+	// A result that was never marked as checking comes straight out of pending
+	st.UpdateWithResult(&ProxyResult{Proxy: "b:1", Status: StatusDead})
+	if stats := st.GetStats(); stats.Pending != 0 || stats.Checking != 1 || stats.Dead != 1 {
+		t.Fatalf("got pending=%d checking=%d dead=%d, want 0/1/1", stats.Pending, stats.Checking, stats.Dead)
+	}
+
+	st.MarkCheckingAsDead()
+	if stats := st.GetStats(); stats.Checking != 0 || stats.Dead != 2 {
+		t.Fatalf("got checking=%d dead=%d, want 0/2", stats.Checking, stats.Dead)
+	}
+}
+
+func TestStatsTrackerMoveStatus(t *testing.T) {
+	st := NewStatsTracker()
+	st.Reset(1)
+	st.UpdateWithResult(&ProxyResult{Proxy: "a:1", Status: "LIVE", Latency: 900})
+
+	st.MoveStatus("LIVE", StatusSlow)
+
+	stats := st.GetStats()
+	if stats.Live != 0 || stats.Slow != 1 {
+		t.Fatalf("got live=%d slow=%d, want 0/1", stats.Live, stats.Slow)
+	}
+	if stats.SuccessRate != 100 {
+		t.Errorf("slow proxies are working, got success rate %.1f", stats.SuccessRate)
+	}
+}
+
+func TestStatsTrackerSourceStats(t *testing.T) {
+	st := NewStatsTracker()
+	st.Reset(3)
+	st.AddSourceTotal("file:a.txt")
+	st.AddSourceTotal("file:a.txt")
+	st.AddSourceTotal("")
+
+	st.UpdateWithResult(&ProxyResult{Proxy: "a:1", Status: "LIVE", Source: "file:a.txt"})
+	st.UpdateWithResult(&ProxyResult{Proxy: "b:1", Status: "DEAD", Source: "file:a.txt"})
+
+	ss := st.GetStats().SourceStats["file:a.txt"]
+	if ss.Total != 2 || ss.Checked != 2 || ss.Live != 1 || ss.LiveRate != 50 {
+		t.Fatalf("unexpected source stats %+v", ss)
+	}
+}
+
+func TestStatsTrackerBlendsExpectedRate(t *testing.T) {
+	st := NewStatsTracker()
+	st.Reset(100)
+	st.SetThreadCount(10)
+
+	// Before any check completes, the estimate comes from the historical rate alone
+	st.SetExpectedRate(2)
+	st.mutex.Lock()
+	st.updateRatesLocked()
+	eta := st.stats.EstimatedTimeRemaining
+	st.mutex.Unlock()
+	if eta.Seconds() != 50 {
+		t.Fatalf("got ETA %v with no completed checks, want 50s", eta)
+	}
+
+	// Early on, the run weighs less than the history
+	st.mutex.Lock()
+	st.stats.ChecksPerSecond = 10
+	if rate := st.etaRateLocked(20); rate != 6 {
+		t.Errorf("got rate %.2f after 20 checks, want 6 (even blend)", rate)
+	}
+	if rate := st.etaRateLocked(1980); rate <= 9.8 {
+		t.Errorf("got rate %.2f after 1980 checks, want close to the run rate", rate)
+	}
+	st.mutex.Unlock()
+}
@@ -0,0 +1,140 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	ErrUnsupportedEncoding = fmt.Errorf("%w: unsupported content encoding", ErrBadJudgeResponse)
+)
+
+// maxJudgeBody is the largest decoded judge response read by a check
+const maxJudgeBody = 1 << 20
+
+// byteCounter counts the bytes sent and received over the connections of a check
+type byteCounter struct {
+	read    atomic.Int64
+	written atomic.Int64
+}
+
+// total returns the number of bytes transferred in both directions
+func (c *byteCounter) total() int64 {
+	return c.read.Load() + c.written.Load()
+}
+
+// byteCounterKey is the context key of the byte counter of a check
+type byteCounterKey struct{}
+
+// withByteCounter returns a context counting the traffic of the connections dialed with it
+func withByteCounter(ctx context.Context, counter *byteCounter) context.Context {
+	return context.WithValue(ctx, byteCounterKey{}, counter)
+}
+
+// meteredConn is a connection adding its traffic to a byte counter and throttling it
+// with a bandwidth limiter; either may be nil
+type meteredConn struct {
+	net.Conn
+	counter *byteCounter
+	limiter *BandwidthLimiter
+}
+
+func (c *meteredConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if c.counter != nil {
+		c.counter.read.Add(int64(n))
+	}
+	if c.limiter != nil {
+		// The bytes are already in; throttling here delays the next read
+		c.limiter.Wait(n)
+	}
+	return n, err
+}
+
+func (c *meteredConn) Write(b []byte) (int, error) {
+	if c.limiter != nil {
+		c.limiter.Wait(len(b))
+	}
+	n, err := c.Conn.Write(b)
+	if c.counter != nil {
+		c.counter.written.Add(int64(n))
+	}
+	return n, err
+}
+
+// countTraffic wraps the dialer of a transport so connections dialed with a context
+// carrying a byte counter add their traffic to it, and those dialed with a context
+// carrying a bandwidth limiter are throttled by it
+func countTraffic(transport *http.Transport) {
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return meter(ctx, conn), nil
+	}
+}
+
+// meter wraps conn to count and throttle its traffic with the byte counter and
+// bandwidth limiter of ctx, if any
+func meter(ctx context.Context, conn net.Conn) net.Conn {
+	counter, _ := ctx.Value(byteCounterKey{}).(*byteCounter)
+	limiter, _ := ctx.Value(bandwidthLimiterKey{}).(*BandwidthLimiter)
+	if counter == nil && limiter == nil {
+		return conn
+	}
+	return &meteredConn{Conn: conn, counter: counter, limiter: limiter}
+}
+
+// acceptEncoding is the Accept-Encoding header of checks
+// Setting it explicitly turns off the transparent decompression of the transport, so
+// readBody decodes responses itself; brotli is not offered as the standard library cannot decode it
+const acceptEncoding = "gzip"
+
+// readBody reads a judge response, decoding a compressed body
+// Network errors are classified like those of the request
+func readBody(resp *http.Response) ([]byte, error) {
+	var reader io.Reader = resp.Body
+
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("%w: invalid gzip body: %v", ErrBadJudgeResponse, err)
+		}
+		defer gz.Close()
+		reader = gz
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(reader, maxJudgeBody))
+	if err != nil {
+		return nil, classify("failed to read response", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// Tunnel opens a raw TCP connection to target (host:port) through a proxy
+// HTTP and HTTPS proxies are asked to CONNECT to the target, SOCKS proxies to connect to it;
+// the connection is ready for the protocol of the target once Tunnel returns
+func Tunnel(ctx context.Context, proxyAddr string, proxyType ProxyType, target string, timeout time.Duration) (net.Conn, error) {
+	if !strings.Contains(proxyAddr, ":") {
+		return nil, ErrInvalidProxyFormat
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	switch proxyType {
+	case HTTP, HTTPS:
+		var dialer *net.Dialer
+		dialer, err = newDialer(ctx, timeout)
+		if err != nil {
+			return nil, err
+		}
+		conn, err = connectTunnel(ctx, dialer, proxyAddr, proxyType == HTTPS, target)
+
+	case SOCKS4, SOCKS5:
+		var socksDialer proxy.Dialer
+		socksDialer, err = newSOCKSDialer(ctx, proxyAddr, proxyType, timeout)
+		if err != nil {
+			return nil, err
+		}
+		conn, err = dialContext(ctx, socksDialer, "tcp", target)
+		if err != nil {
+			err = classify(string(proxyType)+" connection failed", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProxyType, proxyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return meter(ctx, conn), nil
+}
+
+// connectTunnel opens a tunnel to target with an HTTP CONNECT request
+func connectTunnel(ctx context.Context, dialer *net.Dialer, proxyAddr string, useTLS bool, target string) (net.Conn, error) {
+	proxyAddr, auth := splitProxyAuth(proxyAddr)
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, classify("proxy connection failed", err)
+	}
+
+	if useTLS {
+		host, _, _ := net.SplitHostPort(proxyAddr)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, classify("proxy TLS handshake failed", err)
+		}
+		conn = tlsConn
+	}
+
+	// The dial deadline does not cover the CONNECT exchange
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	header := ""
+	if auth != nil {
+		login := base64.StdEncoding.EncodeToString([]byte(auth.User + ":" + auth.Password))
+		header = "Proxy-Authorization: Basic " + login + "\r\n"
+	}
+	fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n%s\r\n", target, target, header)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		conn.Close()
+		return nil, classify("CONNECT failed", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		kind := ErrProxyConnectionFailed
+		if resp.StatusCode == http.StatusProxyAuthRequired {
+			kind = ErrAuthRequired
+		}
+		return nil, fmt.Errorf("CONNECT to %s refused: %w", target, &HTTPStatusError{Kind: kind, Code: resp.StatusCode, Status: resp.Status})
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	// The target may have spoken already, e.g. the greeting of an FTP server
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes were read into a buffer
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
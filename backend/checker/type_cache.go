@@ -0,0 +1,125 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// typeEntry is a detected proxy type and when it was detected
+type typeEntry struct {
+	Type     ProxyType `json:"type"`
+	Detected time.Time `json:"detected"`
+}
+
+// TypeCache remembers the types detected in Auto mode (host:port -> type) for a while,
+// so rechecking the same list skips probing every protocol
+type TypeCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]typeEntry
+}
+
+// NewTypeCache creates a cache whose entries expire after ttl
+func NewTypeCache(ttl time.Duration) *TypeCache {
+	return &TypeCache{ttl: ttl, entries: make(map[string]typeEntry)}
+}
+
+// Get returns the cached type of a proxy, if detected less than the TTL ago
+func (c *TypeCache) Get(proxy string) (ProxyType, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[StripProxyAuth(proxy)]
+	if !ok || time.Since(entry.Detected) > c.ttl {
+		return "", false
+	}
+	return entry.Type, true
+}
+
+// Put records the detected type of a proxy
+func (c *TypeCache) Put(proxy string, proxyType ProxyType) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[StripProxyAuth(proxy)] = typeEntry{Type: proxyType, Detected: time.Now()}
+}
+
+// Forget drops the cached type of a proxy, e.g. when it no longer answers as that type
+func (c *TypeCache) Forget(proxy string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, StripProxyAuth(proxy))
+}
+
+// Clear drops all cached types
+func (c *TypeCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries = make(map[string]typeEntry)
+}
+
+// Len returns the number of cached types, expired ones included
+func (c *TypeCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
+
+// Save writes the unexpired entries to path atomically
+func (c *TypeCache) Save(path string) error {
+	c.mutex.Lock()
+	entries := make(map[string]typeEntry, len(c.entries))
+	for proxy, entry := range c.entries {
+		if time.Since(entry.Detected) <= c.ttl {
+			entries[proxy] = entry
+		}
+	}
+	c.mutex.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode type cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write type cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write type cache: %w", err)
+	}
+	return nil
+}
+
+// Load reads a cache written by Save; a missing file leaves the cache empty
+func (c *TypeCache) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read type cache: %w", err)
+	}
+
+	entries := make(map[string]typeEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("invalid type cache: %w", err)
+	}
+
+	c.mutex.Lock()
+	c.entries = entries
+	c.mutex.Unlock()
+	return nil
+}
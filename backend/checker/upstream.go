@@ -53,7 +53,7 @@ func (up *UpstreamProxy) CreateDialer() (proxy.Dialer, error) {
 		return &net.Dialer{Timeout: up.Timeout}, nil
 	}
 
-	return createUpstreamDialer(up.Address, up.Type, up.Timeout)
+	return createUpstreamDialer(context.Background(), up.Address, up.Type, up.Timeout)
 }
 
 // CreateHTTPTransport creates an HTTP transport that routes connections through the upstream proxy
@@ -133,14 +133,14 @@ func (up *UpstreamProxy) TestUpstreamConnection(endpoint string) (string, error)
 	// Send the request
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("upstream proxy connection failed: %w", err)
+		return "", classify("upstream proxy connection failed", err)
 	}
 	defer resp.Body.Close()
 
 	// Read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", classify("failed to read response", err)
 	}
 
 	// The response should contain the outgoing IP
@@ -0,0 +1,73 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// DefaultWatchdogFactor is the multiple of the check timeout after which a hanging check is abandoned
+const DefaultWatchdogFactor = 3.0
+
+// checkPanic carries a panic out of a watched check together with the stack it happened on
+type checkPanic struct {
+	value interface{}
+	stack []byte
+}
+
+// watchdogLimit returns how long a single check may run before the watchdog abandons it
+func watchdogLimit(timeout time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		factor = DefaultWatchdogFactor
+	}
+	return time.Duration(float64(timeout) * factor)
+}
+
+// runWatched runs check with a hard deadline of limit. A check still running at the
+// deadline is abandoned and reported as a timeout, so a pathological half-open
+// connection cannot hold its worker until TCP gives up. fired reports whether the
+// watchdog had to step in
+func runWatched(ctx context.Context, limit time.Duration, check func(context.Context) (string, error)) (ip string, fired bool, err error) {
+	watchCtx, cancel := context.WithTimeout(ctx, limit)
+	defer cancel()
+
+	type outcome struct {
+		ip    string
+		err   error
+		panic *checkPanic
+	}
+	done := make(chan outcome, 1)
+
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				done <- outcome{panic: &checkPanic{value: value, stack: debug.Stack()}}
+			}
+		}()
+		ip, err := check(watchCtx)
+		done <- outcome{ip: ip, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		if o.panic != nil {
+			// Re-raised on the worker so it is recovered like any other check panic
+			panic(o.panic)
+		}
+		return o.ip, false, o.err
+	case <-watchCtx.Done():
+		if ctx.Err() != nil {
+			return "", false, fmt.Errorf("%w: %v", ErrAborted, ctx.Err())
+		}
+		return "", true, fmt.Errorf("%w: check exceeded the watchdog limit of %s", ErrTimeout, limit)
+	}
+}
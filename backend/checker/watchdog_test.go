@@ -0,0 +1,52 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunWatchedAbandonsHungCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	// The check ignores its context, like a read stuck on a half-open connection
+	hung := func(context.Context) (string, error) {
+		<-release
+		return "203.0.113.7", nil
+	}
+
+	start := time.Now()
+	_, fired, err := runWatched(context.Background(), 50*time.Millisecond, hung)
+	if !fired || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("got fired=%v err=%v, want a watchdog timeout", fired, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("watchdog took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, fired, err = runWatched(ctx, time.Minute, hung)
+	if fired || !errors.Is(err, ErrAborted) {
+		t.Fatalf("got fired=%v err=%v, want an aborted check", fired, err)
+	}
+}
+
+func TestRunWatchedPassesResult(t *testing.T) {
+	ip, fired, err := runWatched(context.Background(), time.Second, func(context.Context) (string, error) {
+		return "203.0.113.7", nil
+	})
+	if ip != "203.0.113.7" || fired || err != nil {
+		t.Fatalf("got %q fired=%v err=%v", ip, fired, err)
+	}
+}
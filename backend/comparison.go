@@ -0,0 +1,164 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Local vantage point names
+const (
+	vantageDirect   = "direct"
+	vantageUpstream = "upstream"
+)
+
+// vantageComparison collects the results of one comparison run
+type vantageComparison struct {
+	mutex    sync.Mutex
+	id       string
+	vantages []string
+	results  map[string][]checker.ProxyResult
+	pending  map[string]bool
+}
+
+// StartVantageComparison checks the same proxies directly, through the upstream proxy
+// (if set) and from every online agent, to find proxies that are only reachable from some paths
+func (a *App) StartVantageComparison(params CheckParams) string {
+	if a.closing.Load() {
+		return "Check refused: application is shutting down"
+	}
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	if err := a.filterInput(&params); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	a.clampThreads(&params)
+
+	if len(params.ProxyList) == 0 {
+		return "No proxies to compare"
+	}
+
+	// The local vantages use the same request as a normal check, credentials and
+	// source address included
+	req := a.buildCheckRequest(params)
+	if err := req.Validate(); err != nil {
+		a.emit("log", err.Error())
+		return "Check refused: " + err.Error()
+	}
+
+	cmp := &vantageComparison{
+		id:       fmt.Sprintf("cmp-%d", time.Now().UnixNano()),
+		vantages: []string{vantageDirect},
+		results:  make(map[string][]checker.ProxyResult),
+		pending:  map[string]bool{vantageDirect: true},
+	}
+	if params.UpstreamProxy != "" {
+		cmp.vantages = append(cmp.vantages, vantageUpstream)
+		cmp.pending[vantageUpstream] = true
+	}
+
+	agentIDs := a.coordinator.OnlineAgents()
+	names := make(map[string]string)
+	for _, info := range a.coordinator.Agents() {
+		names[info.ID] = info.Name
+	}
+	for _, id := range agentIDs {
+		cmp.vantages = append(cmp.vantages, names[id])
+		cmp.pending[names[id]] = true
+	}
+
+	if len(cmp.vantages) < 2 {
+		return "Vantage comparison needs an upstream proxy or at least one online agent"
+	}
+
+	a.comparisonMux.Lock()
+	a.comparison = cmp
+	a.comparisonMux.Unlock()
+
+	direct := req
+	direct.UpstreamProxy = ""
+	direct.UpstreamType = ""
+	a.runLocalVantage(cmp.id, vantageDirect, direct)
+
+	if params.UpstreamProxy != "" {
+		a.runLocalVantage(cmp.id, vantageUpstream, req)
+	}
+
+	job := toJobSpec(params)
+	job.Comparison = cmp.id
+	for _, id := range agentIDs {
+		if err := a.coordinator.SubmitTo(id, job, params.ProxyList); err != nil {
+			a.recordComparison(cmp.id, names[id], nil, true)
+		}
+	}
+
+	a.emit("log", fmt.Sprintf("Comparing %d proxies from %s", len(params.ProxyList), strings.Join(cmp.vantages, ", ")))
+	return "Vantage comparison started"
+}
+
+// GetVantageComparison returns the current comparison, or nil if none was started
+func (a *App) GetVantageComparison() *checker.Comparison {
+	a.comparisonMux.Lock()
+	cmp := a.comparison
+	a.comparisonMux.Unlock()
+
+	if cmp == nil {
+		return nil
+	}
+
+	cmp.mutex.Lock()
+	defer cmp.mutex.Unlock()
+	return checker.CompareVantages(cmp.vantages, cmp.results)
+}
+
+// runLocalVantage checks proxies with a separate manager and records them under vantage
+func (a *App) runLocalVantage(id string, vantage string, req checker.ProxyCheckRequest) {
+	manager := checker.NewManager()
+	manager.SetPanicHandler(a.panicHandler(id))
+	manager.SetCompletionHandler(func() {
+		a.recordComparison(id, vantage, manager.GetResults(), true)
+	})
+	manager.Start(req, func(string) {}, func() {})
+}
+
+// recordComparison stores results of a vantage point for comparison id
+func (a *App) recordComparison(id string, vantage string, results []checker.ProxyResult, done bool) {
+	a.comparisonMux.Lock()
+	cmp := a.comparison
+	a.comparisonMux.Unlock()
+
+	if cmp == nil || cmp.id != id {
+		return
+	}
+
+	cmp.mutex.Lock()
+	cmp.results[vantage] = append(cmp.results[vantage], results...)
+	if done {
+		delete(cmp.pending, vantage)
+	}
+	finished := len(cmp.pending) == 0
+	cmp.mutex.Unlock()
+
+	if !done {
+		return
+	}
+
+	result := a.GetVantageComparison()
+	a.emit("comparison-updated", result)
+	if finished {
+		a.emit("log", fmt.Sprintf("Vantage comparison complete: %d divergent, %d live everywhere, %d dead everywhere",
+			result.Divergent, result.LiveEverywhere, result.DeadEverywhere))
+	}
+}
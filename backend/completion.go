@@ -0,0 +1,54 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// CheckSummary is the payload of the "check-complete" event
+type CheckSummary struct {
+	RunID     string    `json:"runId"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Duration is the run duration in milliseconds
+	Duration int64 `json:"duration"`
+	Total    int   `json:"total"`
+	Checked  int   `json:"checked"`
+	Live     int   `json:"live"`
+	Slow     int   `json:"slow"`
+	Dead     int   `json:"dead"`
+	Blocked  int   `json:"blocked"`
+	Errors   int   `json:"errors"`
+	// Stopped is set when the run ended before every proxy was checked
+	Stopped bool  `json:"stopped"`
+	Stats   Stats `json:"stats"`
+}
+
+// checkSummary builds the completion summary of a run from its final statistics
+func checkSummary(runID string, stats checker.Stats) CheckSummary {
+	checked := stats.Live + stats.Slow + stats.Dead + stats.Blocked + stats.Errors
+	return CheckSummary{
+		RunID:     runID,
+		StartTime: stats.StartTime,
+		EndTime:   stats.StartTime.Add(stats.ElapsedTime),
+		Duration:  stats.ElapsedTime.Milliseconds(),
+		Total:     stats.Total,
+		Checked:   checked,
+		Live:      stats.Live,
+		Slow:      stats.Slow,
+		Dead:      stats.Dead,
+		Blocked:   stats.Blocked,
+		Errors:    stats.Errors,
+		Stopped:   checked < stats.Total,
+		Stats:     convertStats(stats),
+	}
+}
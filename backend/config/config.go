@@ -14,9 +14,12 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/sysinfo"
 )
 
 // Config represents the application configuration
@@ -48,7 +51,7 @@ type Config struct {
 	// EnableGeolocation enables geolocation for proxies
 	EnableGeolocation bool `json:"enableGeolocation"`
 
-	// ExportFormat is the default format for exporting proxies
+	// ExportFormat is the default format for exporting proxies: plain, with-type, json or a template name
 	ExportFormat string `json:"exportFormat"`
 
 	// AutoSaveResults enables automatic saving of results
@@ -56,6 +59,256 @@ type Config struct {
 
 	// AutoSavePath is the path for automatically saved results
 	AutoSavePath string `json:"autoSavePath"`
+
+	// Blocklist is a list of ip:port, host and CIDR patterns that are never checked
+	Blocklist []string `json:"blocklist"`
+
+	// AllowlistEnabled restricts checking to proxies matching the allowlist
+	AllowlistEnabled bool `json:"allowlistEnabled"`
+
+	// AllowlistRanges is a list of ip:port, host and CIDR patterns that may be checked
+	AllowlistRanges []string `json:"allowlistRanges"`
+
+	// AllowlistCountries is a list of ISO country codes whose proxies may be checked
+	AllowlistCountries []string `json:"allowlistCountries"`
+
+	// GenerateReports enables writing a summary report when a run completes
+	GenerateReports bool `json:"generateReports"`
+
+	// ReportFormat is the format of run reports (markdown or html)
+	ReportFormat string `json:"reportFormat"`
+
+	// ScheduledExportEnabled enables periodic export of live proxies while monitoring
+	ScheduledExportEnabled bool `json:"scheduledExportEnabled"`
+
+	// ScheduledExportInterval is the scheduled export interval in minutes
+	ScheduledExportInterval int `json:"scheduledExportInterval"`
+
+	// ScheduledExportPath is the file the live list is written to
+	ScheduledExportPath string `json:"scheduledExportPath"`
+
+	// ScheduledExportURL is a URL the live list is POSTed to
+	ScheduledExportURL string `json:"scheduledExportUrl"`
+
+	// ScheduledExportFormat is the format of scheduled exports (plain, with-type, json)
+	ScheduledExportFormat string `json:"scheduledExportFormat"`
+
+	// LiveServerEnabled starts the local live list HTTP server on startup
+	LiveServerEnabled bool `json:"liveServerEnabled"`
+
+	// LiveServerAddress is the listen address of the live list server
+	LiveServerAddress string `json:"liveServerAddress"`
+
+	// GeoIPDatabasePath is the path of the local MaxMind database (defaults to the config directory)
+	GeoIPDatabasePath string `json:"geoIpDatabasePath"`
+
+	// ControlAPIEnabled starts the automation API on startup
+	ControlAPIEnabled bool `json:"controlApiEnabled"`
+
+	// ControlAPIAddress is the listen address of the automation API
+	ControlAPIAddress string `json:"controlApiAddress"`
+
+	// ControlAPIToken is the bearer token required by the automation API
+	ControlAPIToken string `json:"controlApiToken"`
+
+	// AgentShardSize is the number of proxies per shard handed to remote agents
+	AgentShardSize int `json:"agentShardSize"`
+
+	// QueueOrder is the default check order: original, shuffled, subnet or interleave-source
+	QueueOrder string `json:"queueOrder"`
+
+	// CollapseDuplicateExits keeps only the fastest proxy per outgoing IP in exports
+	CollapseDuplicateExits bool `json:"collapseDuplicateExits"`
+
+	// Quality score weights
+	ScoreWeightLatency    float64 `json:"scoreWeightLatency"`
+	ScoreWeightAnonymity  float64 `json:"scoreWeightAnonymity"`
+	ScoreWeightStability  float64 `json:"scoreWeightStability"`
+	ScoreWeightReputation float64 `json:"scoreWeightReputation"`
+	ScoreWeightHTTPS      float64 `json:"scoreWeightHttps"`
+
+	// MaxAcceptableLatency (ms) classifies slower live proxies as slow; 0 disables
+	MaxAcceptableLatency int `json:"maxAcceptableLatency"`
+
+	// ExcludeSlow leaves slow proxies out of the working list and exports
+	ExcludeSlow bool `json:"excludeSlow"`
+
+	// QueueChecks queues checks started while another one is running instead of refusing them
+	QueueChecks bool `json:"queueChecks"`
+
+	// WatchdogFactor abandons a single check running longer than the check timeout times this factor
+	WatchdogFactor float64 `json:"watchdogFactor"`
+
+	// ExportGzip compresses exported files with gzip
+	ExportGzip bool `json:"exportGzip"`
+
+	// ExportChunkLines splits exports into files of at most this many proxies, 0 for one file
+	ExportChunkLines int `json:"exportChunkLines"`
+
+	// ExportTemplates maps names to export line templates with {ip}, {port}, {type}, {user},
+	// {pass}, {country}, {latency} and {score} placeholders; a name can be used as an export format
+	ExportTemplates map[string]string `json:"exportTemplates"`
+
+	// MergeRuns keeps the results of previous runs, updating the rows of rechecked proxies
+	// instead of clearing the result set when a check starts
+	MergeRuns bool `json:"mergeRuns"`
+
+	// ExpiryWarningDays warns after a run about purchased proxies expiring within this
+	// many days; 0 disables the warning
+	ExpiryWarningDays int `json:"expiryWarningDays"`
+
+	// Favorites is the curated pool of proxies kept independently of any run
+	Favorites []string `json:"favorites"`
+
+	// VerifiedPoolThreshold is the number of consecutive live runs after which a proxy
+	// joins the verified pool; 0 disables the pool
+	VerifiedPoolThreshold int `json:"verifiedPoolThreshold"`
+
+	// FlapThreshold quarantines a monitored proxy after this many live/dead transitions
+	// within FlapWindow cycles; 0 disables quarantine
+	FlapThreshold int `json:"flapThreshold"`
+
+	// FlapWindow is the number of recent monitoring cycles in which flaps are counted
+	FlapWindow int `json:"flapWindow"`
+
+	// QuarantineReleaseCycles releases a quarantined proxy after this many cycles with the same outcome
+	QuarantineReleaseCycles int `json:"quarantineReleaseCycles"`
+
+	// GeoIPLicenseKey is the MaxMind license key used to download GeoLite2 databases
+	GeoIPLicenseKey string `json:"geoIpLicenseKey"`
+
+	// GeoIPEdition is the GeoLite2 edition to download (GeoLite2-Country or GeoLite2-City)
+	GeoIPEdition string `json:"geoIpEdition"`
+
+	// GeoIPUpdateDays refreshes the downloaded database once it is older than this many days; 0 disables updates
+	GeoIPUpdateDays int `json:"geoIpUpdateDays"`
+
+	// ResolvePTR looks up the reverse DNS name of the outgoing IP of live proxies
+	ResolvePTR bool `json:"resolvePtr"`
+
+	// RDAPLookup attaches the network name, organisation and abuse contact of the outgoing
+	// IP of live proxies, queried over RDAP and cached in the data directory
+	RDAPLookup bool `json:"rdapLookup"`
+
+	// MTUProbeBytes sends a request padded with this many bytes through every live proxy to
+	// detect broken path MTU discovery; 0 disables the probe
+	MTUProbeBytes int `json:"mtuProbeBytes"`
+
+	// SoakSeconds keeps a connection through every live proxy open this long, pinging the
+	// judge every SoakIntervalSeconds, to find proxies that die under sustained use; 0 disables
+	SoakSeconds         int `json:"soakSeconds"`
+	SoakIntervalSeconds int `json:"soakIntervalSeconds"`
+
+	// CapacityProbeConnections opens this many parallel connections through every live proxy
+	// and records how many succeed, estimating its concurrency limit; 0 disables the probe
+	CapacityProbeConnections int `json:"capacityProbeConnections"`
+
+	// CacheBust appends a unique token to the judge URL of every check and flags proxies
+	// whose responses come from a caching middlebox anyway
+	CacheBust bool `json:"cacheBust"`
+
+	// BandwidthLimitMbps caps the combined traffic of all checks in Mbit/s, so checking does
+	// not saturate the uplink and distort latency measurements; 0 is unlimited
+	BandwidthLimitMbps float64 `json:"bandwidthLimitMbps"`
+
+	// SourceAddress binds the connections of checks to an IP address or network interface
+	// (multi-homed machines, VPN split tunnels); empty uses the default route
+	SourceAddress string `json:"sourceAddress"`
+
+	// FTPProbeServer is an FTP server (host:port, e.g. ftp.gnu.org:21) every live proxy must
+	// reach on the control channel to be marked FTP capable; empty disables the probe
+	FTPProbeServer string `json:"ftpProbeServer"`
+
+	// PortMatrix lists the ports (e.g. 993, 995, 22, 443, 25) tried connect-only through every
+	// live proxy on PortMatrixHost, a host answering on all ports; empty disables the probe
+	PortMatrix     []int  `json:"portMatrix"`
+	PortMatrixHost string `json:"portMatrixHost"`
+
+	// RedactionRules are extra regular expressions whose matches are hidden from logs, on top of
+	// proxy credentials, the upstream proxy and the configured API keys
+	RedactionRules []string `json:"redactionRules"`
+	// FullVerbosityLogs disables log redaction, for debugging
+	FullVerbosityLogs bool `json:"fullVerbosityLogs"`
+
+	// SchemaVersion is the layout version of the configuration, used to migrate older files
+	SchemaVersion int `json:"schemaVersion"`
+
+	// DebugServerEnabled starts the net/http/pprof endpoints on DebugServerAddress at startup
+	// It is a hidden setting, edited in the config file to diagnose performance issues
+	DebugServerEnabled bool   `json:"debugServerEnabled"`
+	DebugServerAddress string `json:"debugServerAddress"`
+
+	// TypeCacheEnabled remembers the types detected in Auto mode for a week, so rechecking
+	// the same list skips probing every protocol
+	TypeCacheEnabled bool `json:"typeCacheEnabled"`
+
+	// EndpointFailover switches a run to the next of DefaultEndpoints when the endpoint fails
+	// for FailoverThreshold proxies in a row, and checks those proxies again
+	EndpointFailover  bool `json:"endpointFailover"`
+	FailoverThreshold int  `json:"failoverThreshold"`
+
+	// HealthCheckInterval is how often (seconds) the endpoint is requested directly during a
+	// run; when it or the local network is down the run is paused. 0 disables the monitor
+	HealthCheckInterval int `json:"healthCheckInterval"`
+	// HealthAutoResume resumes a run paused by the monitor once the endpoint answers again
+	HealthAutoResume bool `json:"healthAutoResume"`
+
+	// ProviderKeys holds the API keys of the proxy providers, by provider name
+	ProviderKeys map[string]string `json:"providerKeys"`
+
+	// DuplicateRunWindow is how long (minutes) a finished run is offered for reload instead
+	// of rechecking an identical list with the same parameters (0 disables the check)
+	DuplicateRunWindow int `json:"duplicateRunWindow"`
+
+	// ExportRunLog writes the log of the run next to exported results
+	ExportRunLog bool `json:"exportRunLog"`
+
+	// SOCKS4Ident is the userid sent to SOCKS4 proxies; SOCKS4Idents overrides it per proxy
+	// (ip:port -> userid). A username in the proxy address takes precedence over both
+	SOCKS4Ident  string            `json:"socks4Ident"`
+	SOCKS4Idents map[string]string `json:"socks4Idents"`
+
+	// ACLReferenceJudge is the judge a proxy refusing the endpoint is also checked against;
+	// one reaching it is classified as blocked by its ACL instead of dead. Empty disables it
+	ACLReferenceJudge string `json:"aclReferenceJudge"`
+
+	// DualStackProbe asks an IPv4-only and an IPv6-only echo endpoint through every live proxy
+	// for its exit addresses, telling whether it is dual-stack
+	DualStackProbe   bool   `json:"dualStackProbe"`
+	IPv4EchoEndpoint string `json:"ipv4EchoEndpoint"`
+	IPv6EchoEndpoint string `json:"ipv6EchoEndpoint"`
+
+	// CheckEachResolvedIP checks every address a proxy given as a hostname resolves to
+	// separately, e.g. the exits of a backconnect gateway
+	CheckEachResolvedIP bool `json:"checkEachResolvedIp"`
+
+	// SleepDetection pauses a run when the system wakes up from sleep, checks the network
+	// and endpoint again and flags the failures recorded around the sleep as suspect
+	SleepDetection bool `json:"sleepDetection"`
+
+	// CPUBackoffThreshold is the process CPU usage, in percent of all cores, above which
+	// a run sheds workers until the usage drops again; 0 disables the backoff
+	CPUBackoffThreshold float64 `json:"cpuBackoffThreshold"`
+
+	// LogEventLevel is the lowest level of the log lines sent to the frontend as events
+	// ("debug", "info", "warn" or "error"); every line is stored for QueryLogs regardless
+	LogEventLevel string `json:"logEventLevel"`
+
+	// Pools are the named groups of proxies (name -> proxies), summarised by GetPoolHealth
+	Pools map[string][]string `json:"pools"`
+
+	// RoundTripProbe asks every live proxy to reach back to a temporary callback listener,
+	// detecting egress-only proxies. The listener binds CallbackListenAddress and must be
+	// reachable from the internet at CallbackPublicURL. It binds the loopback interface by
+	// default, for a relay or tunnel forwarding to it; binding a public interface exposes it
+	RoundTripProbe        bool   `json:"roundTripProbe"`
+	CallbackListenAddress string `json:"callbackListenAddress"`
+	CallbackPublicURL     string `json:"callbackPublicUrl"`
+
+	// ConnectPolicyProbe asks every live HTTP proxy to CONNECT to ConnectPolicyPorts on the
+	// port matrix host, recording which ports its policy allows; empty uses the defaults
+	ConnectPolicyProbe bool  `json:"connectPolicyProbe"`
+	ConnectPolicyPorts []int `json:"connectPolicyPorts"`
 }
 
 // DefaultConfig returns the default configuration
@@ -73,12 +326,89 @@ func DefaultConfig() *Config {
 			"https://ipinfo.io/ip",
 			"https://checkip.amazonaws.com",
 		},
-		MaxThreads:        100,
-		Theme:             "system",
-		EnableGeolocation: true,
-		ExportFormat:      "plain", // plain, with-type, json
-		AutoSaveResults:   false,
-		AutoSavePath:      "",
+		MaxThreads:              sysinfo.RecommendedThreads(),
+		Theme:                   "system",
+		EnableGeolocation:       true,
+		ExportFormat:            "plain", // plain, with-type, json
+		AutoSaveResults:         false,
+		AutoSavePath:            "",
+		Blocklist:               []string{},
+		AllowlistEnabled:        false,
+		AllowlistRanges:         []string{},
+		AllowlistCountries:      []string{},
+		GenerateReports:         true,
+		ReportFormat:            "markdown",
+		ScheduledExportEnabled:  false,
+		ScheduledExportInterval: 5,
+		ScheduledExportPath:     "",
+		ScheduledExportURL:      "",
+		ScheduledExportFormat:   "plain",
+		LiveServerEnabled:       false,
+		LiveServerAddress:       "127.0.0.1:8765",
+		GeoIPDatabasePath:       "",
+		ControlAPIEnabled:       false,
+		ControlAPIAddress:       "127.0.0.1:8766",
+		ControlAPIToken:         "",
+		AgentShardSize:          500,
+		QueueOrder:              "original",
+		CollapseDuplicateExits:  false,
+		ScoreWeightLatency:      0.4,
+		ScoreWeightAnonymity:    0.2,
+		ScoreWeightStability:    0.2,
+		ScoreWeightReputation:   0.1,
+		ScoreWeightHTTPS:        0.1,
+		MaxAcceptableLatency:    0,
+		ExcludeSlow:             true,
+		QueueChecks:             true,
+		WatchdogFactor:          checker.DefaultWatchdogFactor,
+		ExportGzip:              false,
+		ExportChunkLines:        0,
+		ExportTemplates: map[string]string{
+			"csv": "{ip},{port},{type},{country},{latency}",
+		},
+		MergeRuns:                false,
+		ExpiryWarningDays:        7,
+		Favorites:                []string{},
+		VerifiedPoolThreshold:    3,
+		FlapThreshold:            3,
+		FlapWindow:               10,
+		QuarantineReleaseCycles:  5,
+		GeoIPLicenseKey:          "",
+		GeoIPEdition:             "GeoLite2-Country",
+		GeoIPUpdateDays:          7,
+		ResolvePTR:               false,
+		RDAPLookup:               false,
+		MTUProbeBytes:            0,
+		SoakSeconds:              0,
+		SoakIntervalSeconds:      5,
+		CapacityProbeConnections: 0,
+		CacheBust:                true,
+		BandwidthLimitMbps:       0,
+		SourceAddress:            "",
+		FTPProbeServer:           "",
+		PortMatrix:               []int{},
+		PortMatrixHost:           "portquiz.net",
+		RedactionRules:           []string{},
+		FullVerbosityLogs:        false,
+		SchemaVersion:            SchemaVersion,
+		DebugServerEnabled:       false,
+		DebugServerAddress:       "127.0.0.1:6060",
+		TypeCacheEnabled:         true,
+		EndpointFailover:         true,
+		FailoverThreshold:        checker.DefaultFailoverThreshold,
+		HealthCheckInterval:      30,
+		HealthAutoResume:         true,
+		ProviderKeys:             map[string]string{},
+		DuplicateRunWindow:       10,
+		ExportRunLog:             false,
+		SOCKS4Idents:             map[string]string{},
+		ACLReferenceJudge:        "https://api.ipify.org",
+		IPv4EchoEndpoint:         checker.DefaultIPv4Echo,
+		IPv6EchoEndpoint:         checker.DefaultIPv6Echo,
+		SleepDetection:           true,
+		LogEventLevel:            "debug",
+		Pools:                    map[string][]string{},
+		CallbackListenAddress:    "127.0.0.1:8089",
 	}
 }
 
@@ -91,7 +421,9 @@ var (
 type ConfigManager struct {
 	config     *Config
 	configPath string
-	mutex      sync.RWMutex
+	// profile is the name of the active profile, whose directory holds configPath
+	profile string
+	mutex   sync.RWMutex
 }
 
 // GetInstance returns the singleton instance of ConfigManager
@@ -100,7 +432,8 @@ func GetInstance() *ConfigManager {
 		instance = &ConfigManager{
 			config: DefaultConfig(),
 		}
-		instance.configPath = getConfigPath()
+		instance.profile = activeProfile()
+		instance.configPath = filepath.Join(profileDir(instance.profile), "config.json")
 
 		// Update the Load call to handle the error (around line 104)
 		if err := instance.Load(); err != nil {
@@ -135,11 +468,19 @@ func (cm *ConfigManager) Load() error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse config
-	if err := json.Unmarshal(data, &cm.config); err != nil {
+	// Parse config, migrating older layouts and keeping a backup of the original file
+	config, migrated, err := Parse(data)
+	if err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
+	cm.config = config
 
+	if migrated {
+		if err := os.WriteFile(cm.configPath+".bak", data, 0644); err != nil {
+			return fmt.Errorf("failed to back up config file: %w", err)
+		}
+		return cm.save()
+	}
 	return nil
 }
 
@@ -194,6 +535,52 @@ func (cm *ConfigManager) UpdateConfig(updater func(*Config)) error {
 	return cm.save()
 }
 
+// DataDir returns the directory holding the config file and other data of the active profile
+func (cm *ConfigManager) DataDir() string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return filepath.Dir(cm.configPath)
+}
+
+// ExportDir returns the directory exports and reports are written to
+// This is the auto-save path if configured, otherwise an exports folder in the data directory
+func (cm *ConfigManager) ExportDir() string {
+	cm.mutex.RLock()
+	path := cm.config.AutoSavePath
+	cm.mutex.RUnlock()
+
+	if path == "" {
+		path = filepath.Join(cm.DataDir(), "exports")
+	}
+	return path
+}
+
+// GeoIPDatabasePath returns the configured GeoIP database path or the default location,
+// the file named after the GeoIP edition in the data directory
+func (cm *ConfigManager) GeoIPDatabasePath() string {
+	cm.mutex.RLock()
+	path := cm.config.GeoIPDatabasePath
+	edition := cm.config.GeoIPEdition
+	cm.mutex.RUnlock()
+
+	if edition == "" {
+		edition = "GeoLite2-Country"
+	}
+	if path == "" {
+		path = filepath.Join(cm.DataDir(), edition+".mmdb")
+	}
+	return path
+}
+
+// UpdateAllowlist updates the allowlist settings
+func (cm *ConfigManager) UpdateAllowlist(enabled bool, ranges []string, countries []string) error {
+	return cm.UpdateConfig(func(c *Config) {
+		c.AllowlistEnabled = enabled
+		c.AllowlistRanges = appendUnique(nil, ranges)
+		c.AllowlistCountries = appendUnique(nil, countries)
+	})
+}
+
 // UpdateLastProxyType updates the last used proxy type
 func (cm *ConfigManager) UpdateLastProxyType(proxyType checker.ProxyType) error {
 	return cm.UpdateConfig(func(c *Config) {
@@ -252,6 +639,105 @@ func (cm *ConfigManager) UpdateAutoSave(enable bool, path string) error {
 	})
 }
 
+// AddBlocklistEntries adds patterns to the blocklist, ignoring ones already present
+func (cm *ConfigManager) AddBlocklistEntries(entries []string) error {
+	return cm.UpdateConfig(func(c *Config) {
+		c.Blocklist = appendUnique(c.Blocklist, entries)
+	})
+}
+
+// RemoveBlocklistEntries removes patterns from the blocklist
+func (cm *ConfigManager) RemoveBlocklistEntries(entries []string) error {
+	return cm.UpdateConfig(func(c *Config) {
+		c.Blocklist = removeAll(c.Blocklist, entries)
+	})
+}
+
+// AddFavorites adds proxies to the favorites pool, ignoring ones already present
+func (cm *ConfigManager) AddFavorites(proxies []string) error {
+	return cm.UpdateConfig(func(c *Config) {
+		c.Favorites = appendUnique(c.Favorites, proxies)
+	})
+}
+
+// RemoveFavorites removes proxies from the favorites pool
+func (cm *ConfigManager) RemoveFavorites(proxies []string) error {
+	return cm.UpdateConfig(func(c *Config) {
+		c.Favorites = removeAll(c.Favorites, proxies)
+	})
+}
+
+// AddToPool adds proxies to a named pool, creating it if needed
+func (cm *ConfigManager) AddToPool(name string, proxies []string) error {
+	return cm.updatePools(func(pools map[string][]string) {
+		pools[name] = appendUnique(slices.Clone(pools[name]), proxies)
+	})
+}
+
+// RemoveFromPool removes proxies from a named pool
+func (cm *ConfigManager) RemoveFromPool(name string, proxies []string) error {
+	return cm.updatePools(func(pools map[string][]string) {
+		if _, ok := pools[name]; ok {
+			pools[name] = removeAll(pools[name], proxies)
+		}
+	})
+}
+
+// DeletePool removes a named pool
+func (cm *ConfigManager) DeletePool(name string) error {
+	return cm.updatePools(func(pools map[string][]string) {
+		delete(pools, name)
+	})
+}
+
+// updatePools applies update to a copy of the pools, so configs returned earlier are not modified
+func (cm *ConfigManager) updatePools(update func(pools map[string][]string)) error {
+	return cm.UpdateConfig(func(c *Config) {
+		pools := make(map[string][]string, len(c.Pools)+1)
+		for name, proxies := range c.Pools {
+			pools[name] = proxies
+		}
+		update(pools)
+		c.Pools = pools
+	})
+}
+
+// appendUnique appends the trimmed, non-empty items not already in list
+func appendUnique(list []string, items []string) []string {
+	seen := make(map[string]bool, len(list))
+	for _, item := range list {
+		seen[item] = true
+	}
+
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		list = append(list, item)
+	}
+
+	return list
+}
+
+// removeAll returns list without any of the given items
+func removeAll(list []string, items []string) []string {
+	remove := make(map[string]bool, len(items))
+	for _, item := range items {
+		remove[strings.TrimSpace(item)] = true
+	}
+
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if !remove[item] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
 // getConfigPath returns the path to the config file based on the OS
 func getConfigPath() string {
 	var configDir string
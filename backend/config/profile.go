@@ -0,0 +1,200 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is the profile whose data lives directly in the application directory
+const DefaultProfile = "default"
+
+const (
+	// profilesDir is the directory of the application directory holding the named profiles
+	profilesDir = "profiles"
+
+	// activeProfileFile is the file of the application directory naming the active profile
+	activeProfileFile = "active_profile"
+)
+
+var (
+	ErrInvalidProfile  = errors.New("invalid profile name")
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrProfileExists   = errors.New("profile already exists")
+	ErrProfileActive   = errors.New("profile is active")
+)
+
+// profileName is the pattern of valid profile names
+var profileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _.-]{0,63}$`)
+
+// rootDir returns the application directory, holding the default profile and the others
+func rootDir() string {
+	return filepath.Dir(getConfigPath())
+}
+
+// profileDir returns the data directory of a profile
+func profileDir(name string) string {
+	if name == DefaultProfile {
+		return rootDir()
+	}
+	return filepath.Join(rootDir(), profilesDir, name)
+}
+
+// validateProfile checks a profile name
+func validateProfile(name string) error {
+	if !profileName.MatchString(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("%w: %q", ErrInvalidProfile, name)
+	}
+	return nil
+}
+
+// activeProfile returns the profile selected last, or the default profile
+func activeProfile() string {
+	data, err := os.ReadFile(filepath.Join(rootDir(), activeProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+
+	name := strings.TrimSpace(string(data))
+	if validateProfile(name) != nil {
+		return DefaultProfile
+	}
+	if _, err := os.Stat(profileDir(name)); err != nil {
+		return DefaultProfile
+	}
+	return name
+}
+
+// Profiles returns the names of the profiles, the default one first
+func Profiles() ([]string, error) {
+	profiles := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(filepath.Join(rootDir(), profilesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	var named []string
+	for _, entry := range entries {
+		if entry.IsDir() && validateProfile(entry.Name()) == nil && entry.Name() != DefaultProfile {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(profiles, named...), nil
+}
+
+// CreateProfile creates an empty profile, which starts with the default configuration
+func CreateProfile(name string) error {
+	if err := validateProfile(name); err != nil {
+		return err
+	}
+	dir := profileDir(name)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	return nil
+}
+
+// Profile returns the name of the active profile
+func (cm *ConfigManager) Profile() string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return cm.profile
+}
+
+// SwitchProfile makes another profile active, loading its configuration. The choice is
+// remembered for the next start
+func (cm *ConfigManager) SwitchProfile(name string) error {
+	if err := validateProfile(name); err != nil {
+		return err
+	}
+	dir := profileDir(name)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	if err := os.WriteFile(filepath.Join(rootDir(), activeProfileFile), []byte(name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save active profile: %w", err)
+	}
+
+	cm.mutex.Lock()
+	cm.profile = name
+	cm.configPath = filepath.Join(dir, "config.json")
+	cm.config = DefaultConfig()
+	cm.mutex.Unlock()
+
+	return cm.Load()
+}
+
+// DeleteProfile removes a profile and all its data; the default and the active profile
+// cannot be deleted
+func (cm *ConfigManager) DeleteProfile(name string) error {
+	if err := validateProfile(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile || name == cm.Profile() {
+		return fmt.Errorf("%w: %s", ErrProfileActive, name)
+	}
+
+	dir := profileDir(name)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	return os.RemoveAll(dir)
+}
+
+// RootDir returns the application directory, holding the data of every profile
+func RootDir() string {
+	return rootDir()
+}
+
+// AutoSavePaths returns the custom export directories configured by the profiles
+func AutoSavePaths() []string {
+	profiles, err := Profiles()
+	if err != nil {
+		profiles = []string{DefaultProfile}
+	}
+
+	var paths []string
+	for _, name := range profiles {
+		data, err := os.ReadFile(filepath.Join(profileDir(name), "config.json"))
+		if err != nil {
+			continue
+		}
+		if cfg, _, err := Parse(data); err == nil && cfg.AutoSavePath != "" {
+			paths = append(paths, cfg.AutoSavePath)
+		}
+	}
+	return paths
+}
+
+// Reset switches to the default profile with the default configuration, without saving
+// it. Used once the data directory has been wiped
+func (cm *ConfigManager) Reset() {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	cm.profile = DefaultProfile
+	cm.configPath = filepath.Join(rootDir(), "config.json")
+	cm.config = DefaultConfig()
+}
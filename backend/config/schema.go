@@ -0,0 +1,92 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// SchemaVersion is the version of the configuration layout written by this build
+const SchemaVersion = 1
+
+// ErrNewerSchema is returned for configurations written by a newer version of the app
+var ErrNewerSchema = errors.New("configuration was written by a newer version")
+
+// migration upgrades a raw configuration by one schema version
+type migration func(raw map[string]json.RawMessage) error
+
+// migrations holds the upgrade from each schema version to the next, indexed by version
+var migrations = []migration{
+	// Version 0 configurations predate versioning; their layout is unchanged in version 1
+	0: func(raw map[string]json.RawMessage) error { return nil },
+}
+
+// Parse decodes a configuration of any known schema version, migrating it to the current
+// one. Fields missing from data keep their defaults
+func Parse(data []byte) (*Config, bool, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, false, err
+	}
+
+	version := 0
+	if v, ok := raw["schemaVersion"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return nil, false, fmt.Errorf("invalid schema version: %w", err)
+		}
+	}
+	if version > SchemaVersion {
+		return nil, false, fmt.Errorf("%w: schema %d, supported up to %d", ErrNewerSchema, version, SchemaVersion)
+	}
+
+	migrated := version < SchemaVersion
+	for ; version < SchemaVersion; version++ {
+		if err := migrations[version](raw); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate from schema %d: %w", version, err)
+		}
+	}
+	raw["schemaVersion"], _ = json.Marshal(SchemaVersion)
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	config := DefaultConfig()
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, false, err
+	}
+	return config, migrated, nil
+}
+
+// WithoutSecrets returns a copy of the configuration without API keys, tokens and proxy
+// credentials, safe to share or move to another machine
+func (c Config) WithoutSecrets() Config {
+	c.ControlAPIToken = ""
+	c.GeoIPLicenseKey = ""
+	c.ScheduledExportURL = ""
+	c.ProviderKeys = map[string]string{}
+	c.LastUpstreamProxy = checker.StripProxyAuth(c.LastUpstreamProxy)
+	return c
+}
+
+// KeepSecrets copies the secrets of from into a configuration imported without them
+func (c *Config) KeepSecrets(from Config) {
+	c.ControlAPIToken = from.ControlAPIToken
+	c.GeoIPLicenseKey = from.GeoIPLicenseKey
+	c.ScheduledExportURL = from.ScheduledExportURL
+	c.ProviderKeys = from.ProviderKeys
+	if checker.StripProxyAuth(from.LastUpstreamProxy) == c.LastUpstreamProxy {
+		c.LastUpstreamProxy = from.LastUpstreamProxy
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package control
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Client is a Go client for the control API
+type Client struct {
+	// BaseURL is the API root, e.g. http://127.0.0.1:8766
+	BaseURL string
+
+	// Token is the API token
+	Token string
+
+	// HTTPClient is the client used for requests; http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client for the API at baseURL
+func NewClient(baseURL string, token string) *Client {
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+	}
+}
+
+// StartCheck starts a check
+func (c *Client) StartCheck(ctx context.Context, req CheckRequest) (string, error) {
+	var reply Reply
+	err := c.do(ctx, http.MethodPost, "/v1/check/start", req, &reply)
+	return reply.Message, err
+}
+
+// StopCheck stops the running check gracefully, letting checks in flight finish
+func (c *Client) StopCheck(ctx context.Context) (string, error) {
+	return c.action(ctx, "/v1/check/stop")
+}
+
+// ForceStopCheck stops the running check immediately, aborting checks in flight
+func (c *Client) ForceStopCheck(ctx context.Context) (string, error) {
+	return c.action(ctx, "/v1/check/force-stop")
+}
+
+// PauseCheck pauses the running check
+func (c *Client) PauseCheck(ctx context.Context) (string, error) {
+	return c.action(ctx, "/v1/check/pause")
+}
+
+// ResumeCheck resumes the paused check
+func (c *Client) ResumeCheck(ctx context.Context) (string, error) {
+	return c.action(ctx, "/v1/check/resume")
+}
+
+// Results returns the current results
+func (c *Client) Results(ctx context.Context) ([]checker.ProxyResult, error) {
+	var results []checker.ProxyResult
+	err := c.do(ctx, http.MethodGet, "/v1/results", nil, &results)
+	return results, err
+}
+
+// Stats returns the current statistics
+func (c *Client) Stats(ctx context.Context) (checker.Stats, error) {
+	var stats checker.Stats
+	err := c.do(ctx, http.MethodGet, "/v1/stats", nil, &stats)
+	return stats, err
+}
+
+// Stream calls fn for every event until ctx is cancelled or the server closes the stream
+func (c *Client) Stream(ctx context.Context, fn func(Event)) error {
+	resp, err := c.request(ctx, http.MethodGet, "/v1/stream", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("invalid event: %w", err)
+		}
+		fn(event)
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// action posts a parameterless control action
+func (c *Client) action(ctx context.Context, path string) (string, error) {
+	var reply Reply
+	err := c.do(ctx, http.MethodPost, path, nil, &reply)
+	return reply.Message, err
+}
+
+// do performs a request and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	resp, err := c.request(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+// request sends an authenticated request and checks the response status
+func (c *Client) request(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("control API request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("control API returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,303 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package control implements the automation API of the checker.
+//
+// The API mirrors the App bindings (start, stop, pause, resume, results, stats)
+// as the gRPC service defined in control.proto, served over cleartext HTTP/2,
+// and as JSON over HTTP. Every request must carry the configured token in an
+// "Authorization: Bearer <token>" header (gRPC metadata). Live results and stats
+// are streamed by the StreamEvents call, and from /v1/stream as
+// newline-delimited JSON events.
+//
+// GRPCClient calls the gRPC service and Client the JSON endpoints. Other
+// languages can generate a client from control.proto with protoc.
+package control
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+var (
+	ErrAlreadyRunning = errors.New("control API already running")
+	ErrNotRunning     = errors.New("control API not running")
+	ErrMissingToken   = errors.New("control API token is not configured")
+)
+
+// CheckRequest represents the parameters of a check started through the API
+// Field names match the CheckParams binding
+type CheckRequest struct {
+	ProxyList        []string          `json:"ProxyList"`
+	ProxyType        string            `json:"ProxyType"`
+	Endpoint         string            `json:"Endpoint"`
+	Threads          int               `json:"Threads"`
+	UpstreamProxy    string            `json:"UpstreamProxy,omitempty"`
+	UpstreamType     string            `json:"UpstreamType,omitempty"`
+	Sources          map[string]string `json:"Sources,omitempty"`
+	Order            string            `json:"Order,omitempty"`
+	Countries        []string          `json:"Countries,omitempty"`
+	ExcludeCountries bool              `json:"ExcludeCountries,omitempty"`
+	SourceAddress    string            `json:"SourceAddress,omitempty"`
+}
+
+// Reply is the response of a control action
+type Reply struct {
+	Message string `json:"message"`
+}
+
+// Event is a streamed event
+type Event struct {
+	Name string          `json:"name"`
+	Data json.RawMessage `json:"data"`
+	Time time.Time       `json:"time"`
+}
+
+// Service is implemented by the application to serve the API
+type Service interface {
+	StartCheck(req CheckRequest) string
+	StopCheck() string
+	ForceStopCheck() string
+	PauseCheck() string
+	ResumeCheck() string
+	Results() []checker.ProxyResult
+	Stats() checker.Stats
+	// Subscribe returns a channel of JSON encoded events and a function to cancel the subscription
+	Subscribe() (<-chan []byte, func())
+}
+
+// Server serves the control API
+type Server struct {
+	mutex    sync.Mutex
+	service  Service
+	token    string
+	srv      *http.Server
+	listener net.Listener
+	done     chan struct{}
+	extra    map[string]http.Handler
+}
+
+// NewServer creates a control API server for service
+func NewServer(service Service) *Server {
+	return &Server{service: service, extra: make(map[string]http.Handler)}
+}
+
+// Handle mounts an additional authenticated handler; it takes effect on the next Start
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.extra[pattern] = handler
+}
+
+// GenerateToken returns a new random API token
+func GenerateToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Start starts the API on addr, accepting only requests carrying token
+func (s *Server) Start(addr string, token string) error {
+	if token == "" {
+		return ErrMissingToken
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.srv != nil {
+		return ErrAlreadyRunning
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/check/start", s.handleStart)
+	mux.HandleFunc("/v1/check/stop", s.handleAction(s.service.StopCheck))
+	mux.HandleFunc("/v1/check/force-stop", s.handleAction(s.service.ForceStopCheck))
+	mux.HandleFunc("/v1/check/pause", s.handleAction(s.service.PauseCheck))
+	mux.HandleFunc("/v1/check/resume", s.handleAction(s.service.ResumeCheck))
+	mux.HandleFunc("/v1/results", s.handleResults)
+	mux.HandleFunc("/v1/stats", s.handleStats)
+	mux.HandleFunc("/v1/stream", s.handleStream)
+	for pattern, handler := range s.extra {
+		mux.Handle(pattern, handler)
+	}
+
+	s.token = token
+	s.done = make(chan struct{})
+	s.listener = listener
+	// gRPC calls arrive over cleartext HTTP/2 on the same listener
+	routes := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGRPC(r) {
+			s.handleGRPC(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+	s.srv = &http.Server{
+		Handler:           h2c.NewHandler(s.authenticate(routes), &http2.Server{}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go s.srv.Serve(listener)
+	return nil
+}
+
+// Stop shuts the API down, closing open streams
+func (s *Server) Stop() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.srv == nil {
+		return ErrNotRunning
+	}
+
+	// End open streams so shutdown does not wait for them
+	close(s.done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := s.srv.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = s.srv.Close()
+	}
+	s.srv = nil
+	s.listener = nil
+	return err
+}
+
+// Addr returns the address the API is listening on, or an empty string if stopped
+func (s *Server) Addr() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// authenticate rejects requests without a valid bearer token
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			if isGRPC(r) {
+				writeGRPCStatus(w, codeUnauthenticated, "unauthorized")
+				return
+			}
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleStart starts a check from a JSON CheckRequest body
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req CheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, Reply{Message: s.service.StartCheck(req)})
+}
+
+// handleAction returns a handler running a parameterless control action
+func (s *Server) handleAction(action func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, Reply{Message: action()})
+	}
+}
+
+// handleResults returns the current results
+func (s *Server) handleResults(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.service.Results())
+}
+
+// handleStats returns the current statistics
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.service.Stats())
+}
+
+// handleStream streams events as newline-delimited JSON until the client disconnects
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+
+	events, cancel := s.service.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(append(event, '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
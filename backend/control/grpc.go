@@ -0,0 +1,226 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package control
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// grpcService is the full name of the service in control.proto
+const grpcService = "soxychecker.control.v1.Control"
+
+// maxGRPCMessage caps the size of a request message
+const maxGRPCMessage = 64 << 20
+
+// gRPC status codes used by the API
+const (
+	codeOK                = 0
+	codeInvalidArgument   = 3
+	codeResourceExhausted = 8
+	codeUnimplemented     = 12
+	codeInternal          = 13
+	codeUnauthenticated   = 16
+)
+
+// StatusError is a gRPC call that ended with a status other than OK
+type StatusError struct {
+	Code    int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("control API returned gRPC status %d: %s", e.Code, e.Message)
+}
+
+// isGRPC returns true for gRPC requests, which are served by handleGRPC
+func isGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+// unaryMethods are the calls of the service taking one message and returning one
+func (s *Server) unaryMethods() map[string]func(req []byte) ([]byte, error) {
+	action := func(fn func() string) func([]byte) ([]byte, error) {
+		return func([]byte) ([]byte, error) {
+			return marshalReply(Reply{Message: fn()}), nil
+		}
+	}
+
+	return map[string]func([]byte) ([]byte, error){
+		"StartCheck": func(data []byte) ([]byte, error) {
+			req, err := unmarshalCheckRequest(data)
+			if err != nil {
+				return nil, &StatusError{Code: codeInvalidArgument, Message: err.Error()}
+			}
+			return marshalReply(Reply{Message: s.service.StartCheck(req)}), nil
+		},
+		"StopCheck":      action(s.service.StopCheck),
+		"ForceStopCheck": action(s.service.ForceStopCheck),
+		"PauseCheck":     action(s.service.PauseCheck),
+		"ResumeCheck":    action(s.service.ResumeCheck),
+		"GetResults": func([]byte) ([]byte, error) {
+			return marshalResults(s.service.Results()), nil
+		},
+		"GetStats": func([]byte) ([]byte, error) {
+			return marshalStats(s.service.Stats()), nil
+		},
+	}
+}
+
+// handleGRPC serves a call of the Control service
+func (s *Server) handleGRPC(w http.ResponseWriter, r *http.Request) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if service != grpcService {
+		writeGRPCStatus(w, codeUnimplemented, "unknown service "+service)
+		return
+	}
+
+	req, err := readGRPCMessage(r.Body)
+	if err != nil {
+		var status *StatusError
+		if !errors.As(err, &status) {
+			status = &StatusError{Code: codeInvalidArgument, Message: err.Error()}
+		}
+		writeGRPCStatus(w, status.Code, status.Message)
+		return
+	}
+
+	if method == "StreamEvents" {
+		s.streamGRPC(w, r)
+		return
+	}
+
+	call, ok := s.unaryMethods()[method]
+	if !ok {
+		writeGRPCStatus(w, codeUnimplemented, "unknown method "+method)
+		return
+	}
+
+	reply, err := call(req)
+	if err != nil {
+		var status *StatusError
+		if !errors.As(err, &status) {
+			status = &StatusError{Code: codeInternal, Message: err.Error()}
+		}
+		writeGRPCStatus(w, status.Code, status.Message)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+	w.Write(grpcFrame(reply))
+	setGRPCTrailer(w, codeOK, "")
+}
+
+// streamGRPC sends events as Event messages until the client cancels or the API stops
+func (s *Server) streamGRPC(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeGRPCStatus(w, codeInternal, "streaming not supported")
+		return
+	}
+
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+
+	events, cancel := s.service.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+			setGRPCTrailer(w, codeOK, "")
+			return
+		case data, ok := <-events:
+			if !ok {
+				setGRPCTrailer(w, codeOK, "")
+				return
+			}
+			var event Event
+			if err := json.Unmarshal(data, &event); err != nil {
+				continue
+			}
+			if _, err := w.Write(grpcFrame(marshalEvent(event))); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// grpcFrame prefixes a message with the gRPC length header; messages are not compressed
+func grpcFrame(msg []byte) []byte {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+// readGRPCMessage reads one length-prefixed message
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	if header[0] != 0 {
+		return nil, &StatusError{Code: codeUnimplemented, Message: "compressed messages are not supported"}
+	}
+
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxGRPCMessage {
+		return nil, &StatusError{Code: codeResourceExhausted, Message: "message too large"}
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	return msg, nil
+}
+
+// writeGRPCStatus ends a call without a reply, with the status in the headers
+func writeGRPCStatus(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Grpc-Status", strconv.Itoa(code))
+	w.Header().Set("Grpc-Message", encodeGRPCMessage(msg))
+	w.WriteHeader(http.StatusOK)
+}
+
+// setGRPCTrailer sends the status of a call that wrote a reply
+func setGRPCTrailer(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if msg != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", encodeGRPCMessage(msg))
+	}
+}
+
+// encodeGRPCMessage percent-encodes a status message as the gRPC protocol requires
+func encodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,189 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package control
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"golang.org/x/net/http2"
+)
+
+// GRPCClient is a Go client for the gRPC service of the control API
+type GRPCClient struct {
+	// Addr is the API address, e.g. 127.0.0.1:8766
+	Addr string
+
+	// Token is the API token
+	Token string
+
+	client *http.Client
+}
+
+// NewGRPCClient creates a gRPC client for the API at addr
+func NewGRPCClient(addr string, token string) *GRPCClient {
+	// The API speaks gRPC over cleartext HTTP/2
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network string, addr string, _ *tls.Config) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}
+
+	return &GRPCClient{
+		Addr:   addr,
+		Token:  token,
+		client: &http.Client{Transport: transport},
+	}
+}
+
+// StartCheck starts a check
+func (c *GRPCClient) StartCheck(ctx context.Context, req CheckRequest) (string, error) {
+	return c.reply(ctx, "StartCheck", marshalCheckRequest(req))
+}
+
+// StopCheck stops the running check gracefully, letting checks in flight finish
+func (c *GRPCClient) StopCheck(ctx context.Context) (string, error) {
+	return c.reply(ctx, "StopCheck", nil)
+}
+
+// ForceStopCheck stops the running check immediately, aborting checks in flight
+func (c *GRPCClient) ForceStopCheck(ctx context.Context) (string, error) {
+	return c.reply(ctx, "ForceStopCheck", nil)
+}
+
+// PauseCheck pauses the running check
+func (c *GRPCClient) PauseCheck(ctx context.Context) (string, error) {
+	return c.reply(ctx, "PauseCheck", nil)
+}
+
+// ResumeCheck resumes the paused check
+func (c *GRPCClient) ResumeCheck(ctx context.Context) (string, error) {
+	return c.reply(ctx, "ResumeCheck", nil)
+}
+
+// Results returns the current results
+func (c *GRPCClient) Results(ctx context.Context) ([]checker.ProxyResult, error) {
+	var results []checker.ProxyResult
+	err := c.call(ctx, "GetResults", nil, func(msg []byte) error {
+		var err error
+		results, err = unmarshalResults(msg)
+		return err
+	})
+	return results, err
+}
+
+// Stats returns the current statistics
+func (c *GRPCClient) Stats(ctx context.Context) (checker.Stats, error) {
+	var stats checker.Stats
+	err := c.call(ctx, "GetStats", nil, func(msg []byte) error {
+		var err error
+		stats, err = unmarshalStats(msg)
+		return err
+	})
+	return stats, err
+}
+
+// Stream calls fn for every event until ctx is cancelled or the server ends the stream
+func (c *GRPCClient) Stream(ctx context.Context, fn func(Event)) error {
+	err := c.call(ctx, "StreamEvents", nil, func(msg []byte) error {
+		event, err := unmarshalEvent(msg)
+		if err != nil {
+			return fmt.Errorf("invalid event: %w", err)
+		}
+		fn(event)
+		return nil
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// reply calls a method returning a Reply message
+func (c *GRPCClient) reply(ctx context.Context, method string, req []byte) (string, error) {
+	var reply Reply
+	err := c.call(ctx, method, req, func(msg []byte) error {
+		var err error
+		reply, err = unmarshalReply(msg)
+		return err
+	})
+	return reply.Message, err
+}
+
+// call sends one request message and passes every message of the reply to handle,
+// then checks the status of the call
+func (c *GRPCClient) call(ctx context.Context, method string, req []byte, handle func(msg []byte) error) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"http://"+c.Addr+"/"+grpcService+"/"+method, bytes.NewReader(grpcFrame(req)))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	httpReq.Header.Set("Te", "trailers")
+	httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("control API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("control API returned %s", resp.Status)
+	}
+
+	// A call that fails before replying carries its status in the headers
+	if err := grpcStatus(resp.Header); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := readGRPCMessage(resp.Body)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := handle(msg); err != nil {
+			return err
+		}
+	}
+
+	return grpcStatus(resp.Trailer)
+}
+
+// grpcStatus returns the call status in h as an error, or nil if it is OK or absent
+func grpcStatus(h http.Header) error {
+	code := h.Get("Grpc-Status")
+	if code == "" || code == "0" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return fmt.Errorf("invalid gRPC status %q", code)
+	}
+	msg, err := url.PathUnescape(h.Get("Grpc-Message"))
+	if err != nil {
+		msg = h.Get("Grpc-Message")
+	}
+	return &StatusError{Code: n, Message: msg}
+}
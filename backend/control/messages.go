@@ -0,0 +1,349 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package control
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// This file encodes the messages of control.proto to and from the API's Go types
+
+// unixMilli returns t in milliseconds since the epoch, or 0 for the zero time
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// fromUnixMilli is the inverse of unixMilli
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// sortedKeys returns the keys of m in order, so encoding is deterministic
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// marshalCheckRequest encodes a CheckRequest message
+func marshalCheckRequest(req CheckRequest) []byte {
+	var b []byte
+	for _, proxy := range req.ProxyList {
+		b = appendBytes(b, 1, []byte(proxy))
+	}
+	b = appendString(b, 2, req.ProxyType)
+	b = appendString(b, 3, req.Endpoint)
+	b = appendInt(b, 4, int64(req.Threads))
+	b = appendString(b, 5, req.UpstreamProxy)
+	b = appendString(b, 6, req.UpstreamType)
+	for _, k := range sortedKeys(req.Sources) {
+		entry := appendBytes(nil, 1, []byte(k))
+		entry = appendBytes(entry, 2, []byte(req.Sources[k]))
+		b = appendBytes(b, 7, entry)
+	}
+	b = appendString(b, 8, req.Order)
+	for _, country := range req.Countries {
+		b = appendBytes(b, 9, []byte(country))
+	}
+	b = appendBool(b, 10, req.ExcludeCountries)
+	b = appendString(b, 11, req.SourceAddress)
+	return b
+}
+
+// unmarshalCheckRequest decodes a CheckRequest message
+func unmarshalCheckRequest(data []byte) (CheckRequest, error) {
+	var req CheckRequest
+	err := readMessage(data, func(r *fieldReader, field int, wireType int) (bool, error) {
+		var err error
+		switch field {
+		case 1:
+			var proxy string
+			proxy, err = readString(r, wireType)
+			req.ProxyList = append(req.ProxyList, proxy)
+		case 2:
+			req.ProxyType, err = readString(r, wireType)
+		case 3:
+			req.Endpoint, err = readString(r, wireType)
+		case 4:
+			var threads int64
+			threads, err = readInt(r, wireType)
+			req.Threads = int(int32(threads))
+		case 5:
+			req.UpstreamProxy, err = readString(r, wireType)
+		case 6:
+			req.UpstreamType, err = readString(r, wireType)
+		case 7:
+			var value string
+			var key string
+			key, err = readMapEntry(r, wireType, func(r *fieldReader, wireType int) error {
+				var err error
+				value, err = readString(r, wireType)
+				return err
+			})
+			if req.Sources == nil {
+				req.Sources = make(map[string]string)
+			}
+			req.Sources[key] = value
+		case 8:
+			req.Order, err = readString(r, wireType)
+		case 9:
+			var country string
+			country, err = readString(r, wireType)
+			req.Countries = append(req.Countries, country)
+		case 10:
+			var v int64
+			v, err = readInt(r, wireType)
+			req.ExcludeCountries = v != 0
+		case 11:
+			req.SourceAddress, err = readString(r, wireType)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return req, err
+}
+
+// marshalReply encodes a Reply message
+func marshalReply(reply Reply) []byte {
+	return appendString(nil, 1, reply.Message)
+}
+
+// unmarshalReply decodes a Reply message
+func unmarshalReply(data []byte) (Reply, error) {
+	var reply Reply
+	err := readMessage(data, func(r *fieldReader, field int, wireType int) (bool, error) {
+		if field != 1 {
+			return false, nil
+		}
+		var err error
+		reply.Message, err = readString(r, wireType)
+		return true, err
+	})
+	return reply, err
+}
+
+// marshalProxyResult encodes a ProxyResult message
+func marshalProxyResult(res checker.ProxyResult) []byte {
+	var b []byte
+	b = appendString(b, 1, res.Proxy)
+	b = appendString(b, 2, string(res.Type))
+	b = appendString(b, 3, string(res.Status))
+	b = appendInt(b, 4, res.Latency)
+	b = appendString(b, 5, res.OutgoingIP)
+	b = appendString(b, 6, res.Country)
+	b = appendString(b, 7, res.CountryCode)
+	b = appendString(b, 8, res.Error)
+	b = appendString(b, 9, res.ErrorKind)
+	b = appendString(b, 10, res.Source)
+	b = appendInt(b, 11, unixMilli(res.Timestamp))
+	if data, err := json.Marshal(res); err == nil {
+		b = appendBytes(b, 15, data)
+	}
+	return b
+}
+
+// unmarshalProxyResult decodes a ProxyResult message; the complete JSON form wins
+// over the individual fields when present
+func unmarshalProxyResult(data []byte) (checker.ProxyResult, error) {
+	var res checker.ProxyResult
+	var full []byte
+	err := readMessage(data, func(r *fieldReader, field int, wireType int) (bool, error) {
+		var err error
+		var s string
+		switch field {
+		case 1:
+			res.Proxy, err = readString(r, wireType)
+		case 2:
+			s, err = readString(r, wireType)
+			res.Type = checker.ProxyType(s)
+		case 3:
+			s, err = readString(r, wireType)
+			res.Status = checker.ProxyStatus(s)
+		case 4:
+			res.Latency, err = readInt(r, wireType)
+		case 5:
+			res.OutgoingIP, err = readString(r, wireType)
+		case 6:
+			res.Country, err = readString(r, wireType)
+		case 7:
+			res.CountryCode, err = readString(r, wireType)
+		case 8:
+			res.Error, err = readString(r, wireType)
+		case 9:
+			res.ErrorKind, err = readString(r, wireType)
+		case 10:
+			res.Source, err = readString(r, wireType)
+		case 11:
+			var ms int64
+			ms, err = readInt(r, wireType)
+			res.Timestamp = fromUnixMilli(ms)
+		case 15:
+			if wireType != wireBytes {
+				return true, errInvalidMessage
+			}
+			full, err = r.bytes()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err == nil && len(full) > 0 {
+		err = json.Unmarshal(full, &res)
+	}
+	return res, err
+}
+
+// marshalResults encodes a Results message
+func marshalResults(results []checker.ProxyResult) []byte {
+	var b []byte
+	for _, res := range results {
+		b = appendBytes(b, 1, marshalProxyResult(res))
+	}
+	return b
+}
+
+// unmarshalResults decodes a Results message
+func unmarshalResults(data []byte) ([]checker.ProxyResult, error) {
+	results := []checker.ProxyResult{}
+	err := readMessage(data, func(r *fieldReader, field int, wireType int) (bool, error) {
+		if field != 1 {
+			return false, nil
+		}
+		if wireType != wireBytes {
+			return true, errInvalidMessage
+		}
+		msg, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		res, err := unmarshalProxyResult(msg)
+		results = append(results, res)
+		return true, err
+	})
+	return results, err
+}
+
+// marshalStats encodes a Stats message
+func marshalStats(stats checker.Stats) []byte {
+	var b []byte
+	b = appendInt(b, 1, int64(stats.Total))
+	b = appendInt(b, 2, int64(stats.Live))
+	b = appendInt(b, 3, int64(stats.Slow))
+	b = appendInt(b, 4, int64(stats.Dead))
+	b = appendInt(b, 5, int64(stats.Blocked))
+	b = appendInt(b, 6, int64(stats.Errors))
+	b = appendInt(b, 7, int64(stats.Aborted))
+	b = appendInt(b, 8, int64(stats.Pending))
+	b = appendInt(b, 9, int64(stats.Checking))
+	counts := make(map[string]int, len(stats.TypeCounts))
+	for t, n := range stats.TypeCounts {
+		counts[string(t)] = n
+	}
+	for _, k := range sortedKeys(counts) {
+		entry := appendBytes(nil, 1, []byte(k))
+		entry = appendInt(entry, 2, int64(counts[k]))
+		b = appendBytes(b, 10, entry)
+	}
+	if data, err := json.Marshal(stats); err == nil {
+		b = appendBytes(b, 15, data)
+	}
+	return b
+}
+
+// unmarshalStats decodes a Stats message; the complete JSON form wins over the
+// individual fields when present
+func unmarshalStats(data []byte) (checker.Stats, error) {
+	var stats checker.Stats
+	var full []byte
+	counters := map[int]*int{
+		1: &stats.Total, 2: &stats.Live, 3: &stats.Slow, 4: &stats.Dead, 5: &stats.Blocked,
+		6: &stats.Errors, 7: &stats.Aborted, 8: &stats.Pending, 9: &stats.Checking,
+	}
+	err := readMessage(data, func(r *fieldReader, field int, wireType int) (bool, error) {
+		if counter, ok := counters[field]; ok {
+			v, err := readInt(r, wireType)
+			*counter = int(v)
+			return true, err
+		}
+		switch field {
+		case 10:
+			var n int64
+			key, err := readMapEntry(r, wireType, func(r *fieldReader, wireType int) error {
+				var err error
+				n, err = readInt(r, wireType)
+				return err
+			})
+			if stats.TypeCounts == nil {
+				stats.TypeCounts = make(map[checker.ProxyType]int)
+			}
+			stats.TypeCounts[checker.ProxyType(key)] = int(n)
+			return true, err
+		case 15:
+			if wireType != wireBytes {
+				return true, errInvalidMessage
+			}
+			var err error
+			full, err = r.bytes()
+			return true, err
+		}
+		return false, nil
+	})
+	if err == nil && len(full) > 0 {
+		err = json.Unmarshal(full, &stats)
+	}
+	return stats, err
+}
+
+// marshalEvent encodes an Event message
+func marshalEvent(event Event) []byte {
+	var b []byte
+	b = appendString(b, 1, event.Name)
+	if len(event.Data) > 0 {
+		b = appendBytes(b, 2, event.Data)
+	}
+	return appendInt(b, 3, unixMilli(event.Time))
+}
+
+// unmarshalEvent decodes an Event message
+func unmarshalEvent(data []byte) (Event, error) {
+	var event Event
+	err := readMessage(data, func(r *fieldReader, field int, wireType int) (bool, error) {
+		var err error
+		switch field {
+		case 1:
+			event.Name, err = readString(r, wireType)
+		case 2:
+			var v string
+			v, err = readString(r, wireType)
+			event.Data = json.RawMessage(v)
+		case 3:
+			var ms int64
+			ms, err = readInt(r, wireType)
+			event.Time = fromUnixMilli(ms)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return event, err
+}
@@ -0,0 +1,194 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package control
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Protocol buffer wire types used by control.proto
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errInvalidMessage = errors.New("invalid protobuf message")
+
+// appendTag appends the key of a field
+func appendTag(b []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+// appendInt appends a varint field; zero values are omitted as in proto3
+func appendInt(b []byte, field int, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendTag(b, field, wireVarint)
+	return binary.AppendUvarint(b, uint64(v))
+}
+
+// appendBool appends a bool field; false is omitted as in proto3
+func appendBool(b []byte, field int, v bool) []byte {
+	if !v {
+		return b
+	}
+	return appendInt(b, field, 1)
+}
+
+// appendBytes appends a length-delimited field, even if empty
+func appendBytes(b []byte, field int, v []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// appendString appends a string field; empty strings are omitted as in proto3
+func appendString(b []byte, field int, s string) []byte {
+	if s == "" {
+		return b
+	}
+	return appendBytes(b, field, []byte(s))
+}
+
+// fieldReader walks the fields of an encoded message
+type fieldReader struct {
+	buf []byte
+}
+
+// next returns the number and wire type of the next field; ok is false at the end
+func (r *fieldReader) next() (field int, wireType int, ok bool, err error) {
+	if len(r.buf) == 0 {
+		return 0, 0, false, nil
+	}
+	key, err := r.varint()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	field = int(key >> 3)
+	if field <= 0 {
+		return 0, 0, false, errInvalidMessage
+	}
+	return field, int(key & 7), true, nil
+}
+
+// varint reads a varint value
+func (r *fieldReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		return 0, errInvalidMessage
+	}
+	r.buf = r.buf[n:]
+	return v, nil
+}
+
+// bytes reads a length-delimited value; it aliases the message buffer
+func (r *fieldReader) bytes() ([]byte, error) {
+	n, err := r.varint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.buf)) {
+		return nil, errInvalidMessage
+	}
+	v := r.buf[:n]
+	r.buf = r.buf[n:]
+	return v, nil
+}
+
+// skip skips the value of a field that is not known
+func (r *fieldReader) skip(wireType int) error {
+	var n int
+	switch wireType {
+	case wireVarint:
+		_, err := r.varint()
+		return err
+	case wireBytes:
+		_, err := r.bytes()
+		return err
+	case wireFixed64:
+		n = 8
+	case wireFixed32:
+		n = 4
+	default:
+		return errInvalidMessage
+	}
+	if len(r.buf) < n {
+		return errInvalidMessage
+	}
+	r.buf = r.buf[n:]
+	return nil
+}
+
+// readMessage calls fn for every field of an encoded message; fn reads the value of the
+// fields it knows and returns false for the others, which are skipped
+func readMessage(data []byte, fn func(r *fieldReader, field int, wireType int) (bool, error)) error {
+	r := &fieldReader{buf: data}
+	for {
+		field, wireType, ok, err := r.next()
+		if err != nil || !ok {
+			return err
+		}
+		known, err := fn(r, field, wireType)
+		if err != nil {
+			return err
+		}
+		if !known {
+			if err := r.skip(wireType); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readString reads a string field, checking its wire type
+func readString(r *fieldReader, wireType int) (string, error) {
+	if wireType != wireBytes {
+		return "", errInvalidMessage
+	}
+	v, err := r.bytes()
+	return string(v), err
+}
+
+// readInt reads a varint field, checking its wire type
+func readInt(r *fieldReader, wireType int) (int64, error) {
+	if wireType != wireVarint {
+		return 0, errInvalidMessage
+	}
+	v, err := r.varint()
+	return int64(v), err
+}
+
+// readMapEntry reads a map entry message into its key and value
+func readMapEntry(r *fieldReader, wireType int, value func(r *fieldReader, wireType int) error) (string, error) {
+	if wireType != wireBytes {
+		return "", errInvalidMessage
+	}
+	entry, err := r.bytes()
+	if err != nil {
+		return "", err
+	}
+
+	var key string
+	err = readMessage(entry, func(r *fieldReader, field int, wireType int) (bool, error) {
+		switch field {
+		case 1:
+			var err error
+			key, err = readString(r, wireType)
+			return true, err
+		case 2:
+			return true, value(r, wireType)
+		}
+		return false, nil
+	})
+	return key, err
+}
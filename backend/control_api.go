@@ -0,0 +1,148 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/control"
+)
+
+// controlService adapts the App bindings to the control API
+type controlService struct {
+	app *App
+}
+
+// StartCheck starts a check from an API request
+func (s *controlService) StartCheck(req control.CheckRequest) string {
+	return s.app.StartCheck(CheckParams{
+		ProxyList:        req.ProxyList,
+		ProxyType:        req.ProxyType,
+		Endpoint:         req.Endpoint,
+		Threads:          req.Threads,
+		UpstreamProxy:    req.UpstreamProxy,
+		UpstreamType:     req.UpstreamType,
+		Sources:          req.Sources,
+		Order:            req.Order,
+		Countries:        req.Countries,
+		ExcludeCountries: req.ExcludeCountries,
+		SourceAddress:    req.SourceAddress,
+	})
+}
+
+// StopCheck stops the running check
+func (s *controlService) StopCheck() string {
+	return s.app.StopCheck()
+}
+
+// ForceStopCheck stops the running check immediately
+func (s *controlService) ForceStopCheck() string {
+	return s.app.ForceStopCheck()
+}
+
+// PauseCheck pauses the running check
+func (s *controlService) PauseCheck() string {
+	return s.app.PauseCheck()
+}
+
+// ResumeCheck resumes the paused check
+func (s *controlService) ResumeCheck() string {
+	return s.app.ResumeCheck()
+}
+
+// Results returns the manager's current results
+func (s *controlService) Results() []checker.ProxyResult {
+	return s.app.manager.GetResults()
+}
+
+// Stats returns the manager's current statistics
+func (s *controlService) Stats() checker.Stats {
+	return s.app.manager.GetStats()
+}
+
+// Subscribe streams the app's events as JSON
+func (s *controlService) Subscribe() (<-chan []byte, func()) {
+	events := s.app.events.subscribe(256)
+	out := make(chan []byte, 256)
+
+	go func() {
+		defer close(out)
+		for event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			select {
+			case out <- data:
+			default:
+			}
+		}
+	}()
+
+	return out, func() { s.app.events.unsubscribe(events) }
+}
+
+// StartControlAPI starts the automation API and returns its listen address
+// A token is generated on first start if none is configured
+func (a *App) StartControlAPI() (string, error) {
+	token := a.config.GetConfig().ControlAPIToken
+	if token == "" {
+		var err error
+		if token, err = a.RegenerateControlAPIToken(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := a.controlAPI.Start(a.config.GetConfig().ControlAPIAddress, token); err != nil {
+		return "", err
+	}
+
+	a.emit("log", "Control API listening on http://"+a.controlAPI.Addr())
+	return a.controlAPI.Addr(), nil
+}
+
+// StopControlAPI stops the automation API
+func (a *App) StopControlAPI() error {
+	if err := a.controlAPI.Stop(); err != nil {
+		return err
+	}
+
+	a.emit("log", "Control API stopped")
+	return nil
+}
+
+// GetControlAPIToken returns the token clients must send to the automation API
+// It is empty while the app is locked
+func (a *App) GetControlAPIToken() string {
+	if a.lock.isLocked() {
+		return ""
+	}
+	return a.config.GetConfig().ControlAPIToken
+}
+
+// RegenerateControlAPIToken replaces the automation API token; a running API keeps the old one until restarted
+func (a *App) RegenerateControlAPIToken() (string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return "", err
+	}
+	token, err := control.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := a.config.UpdateConfig(func(c *config.Config) {
+		c.ControlAPIToken = token
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/sysinfo"
+)
+
+const (
+	// cpuPollInterval is how often the process CPU usage is sampled during a run
+	cpuPollInterval = 5 * time.Second
+
+	// cpuSustained is the number of consecutive samples above or below the threshold
+	// before the worker count is changed, so that short spikes are ignored
+	cpuSustained = 3
+
+	// cpuRecoverRatio is the fraction of the threshold the usage must fall below before
+	// workers are added back, leaving headroom so the count does not flap
+	cpuRecoverRatio = 0.75
+)
+
+// watchCPU samples the CPU usage of the process during the main run and halves the
+// active workers while it stays above the configured threshold, doubling them again
+// once it has settled well below it. Thousands of TLS handshakes a second can make a
+// laptop unusable during long runs
+func (a *App) watchCPU(generation int64) {
+	threshold := a.config.GetConfig().CPUBackoffThreshold
+	if threshold <= 0 {
+		return
+	}
+	last, ok := sysinfo.ProcessCPUTime()
+	if !ok {
+		a.emit("log", "CPU usage is not available on this system, CPU backoff is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(cpuPollInterval)
+	defer ticker.Stop()
+
+	lastAt := time.Now()
+	above, below := 0, 0
+	for range ticker.C {
+		if a.monitorGen.Load() != generation || !a.manager.IsRunning() {
+			return
+		}
+
+		used, ok := sysinfo.ProcessCPUTime()
+		if !ok {
+			return
+		}
+		now := time.Now()
+		usage := float64(used-last) / float64(now.Sub(lastAt)) / float64(runtime.NumCPU()) * 100
+		last, lastAt = used, now
+
+		// Paused runs use no CPU; their samples say nothing about the load
+		if a.manager.IsPaused() {
+			above, below = 0, 0
+			continue
+		}
+
+		switch {
+		case usage > threshold:
+			above, below = above+1, 0
+		case usage < threshold*cpuRecoverRatio:
+			above, below = 0, below+1
+		default:
+			above, below = 0, 0
+		}
+
+		total := a.manager.GetWorkerCount()
+		active := a.manager.ActiveWorkers()
+		switch {
+		case above >= cpuSustained && active > 1:
+			active = max(active/2, 1)
+			a.manager.SetActiveWorkers(active)
+			a.emit("log", fmt.Sprintf("CPU usage at %.0f%% is above %.0f%%, reducing active workers to %d of %d", usage, threshold, active, total))
+			above = 0
+		case below >= cpuSustained && active < total:
+			active = min(active*2, total)
+			a.manager.SetActiveWorkers(active)
+			a.emit("log", fmt.Sprintf("CPU usage is down to %.0f%%, raising active workers to %d of %d", usage, active, total))
+			below = 0
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/vault"
+)
+
+// vaultFile is the file in the data directory holding the encrypted credentials
+const vaultFile = "vault.json"
+
+// VaultStatus describes the credential vault
+type VaultStatus struct {
+	Exists   bool `json:"exists"`
+	Unlocked bool `json:"unlocked"`
+	Count    int  `json:"count"`
+}
+
+// GetVaultStatus returns whether the credential vault exists and is unlocked
+func (a *App) GetVaultStatus() VaultStatus {
+	status := VaultStatus{Exists: a.vault.Exists(), Unlocked: a.vault.Unlocked()}
+	if proxies, err := a.vault.Proxies(); err == nil {
+		status.Count = len(proxies)
+	}
+	return status
+}
+
+// UnlockVault opens the credential vault with the master password, creating it on first use
+// While unlocked, checks authenticate with the stored credentials
+func (a *App) UnlockVault(password string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	if err := a.vault.Unlock(password); err != nil {
+		return err
+	}
+	a.emit("log", "Credential vault unlocked")
+	return nil
+}
+
+// LockVault forgets the master key and the decrypted credentials
+func (a *App) LockVault() {
+	a.vault.Lock()
+	a.emit("log", "Credential vault locked")
+}
+
+// ChangeVaultPassword re-encrypts the unlocked vault under a new master password
+func (a *App) ChangeVaultPassword(password string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.vault.ChangePassword(password)
+}
+
+// StoreProxyCredentials moves the credentials of user:pass@host:port proxies into the vault
+// and returns the list without them, ready to be checked and exported without secrets
+func (a *App) StoreProxyCredentials(proxies []string) ([]string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	stripped := make([]string, len(proxies))
+	credentials := make(map[string]vault.Credential)
+
+	for i, p := range proxies {
+		addr := checker.StripProxyAuth(p)
+		stripped[i] = addr
+		if addr == p {
+			continue
+		}
+
+		// The password may contain any character, so it is not parsed as a URL
+		userinfo := p[:strings.LastIndex(p, "@")]
+		username, password, _ := strings.Cut(userinfo, ":")
+		if username == "" {
+			return nil, fmt.Errorf("invalid credentials for %s", addr)
+		}
+		credentials[addr] = vault.Credential{Username: username, Password: password}
+	}
+
+	if len(credentials) > 0 {
+		if err := a.vault.Set(credentials); err != nil {
+			return nil, err
+		}
+		a.emit("log", fmt.Sprintf("Stored the credentials of %d proxies in the vault", len(credentials)))
+	}
+	return stripped, nil
+}
+
+// SetProxyCredential stores the login of a proxy in the vault
+func (a *App) SetProxyCredential(proxy string, username string, password string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.vault.Set(map[string]vault.Credential{
+		checker.StripProxyAuth(proxy): {Username: username, Password: password},
+	})
+}
+
+// RemoveProxyCredentials deletes the stored logins of proxies
+func (a *App) RemoveProxyCredentials(proxies []string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	addrs := make([]string, len(proxies))
+	for i, p := range proxies {
+		addrs[i] = checker.StripProxyAuth(p)
+	}
+	return a.vault.Delete(addrs)
+}
+
+// GetVaultProxies returns the proxies with stored credentials
+func (a *App) GetVaultProxies() ([]string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	return a.vault.Proxies()
+}
+
+// vaultCredentials returns the stored login of a proxy, for checks
+func (a *App) vaultCredentials(proxy string) (string, string, bool) {
+	cred, ok := a.vault.Get(proxy)
+	return cred.Username, cred.Password, ok
+}
@@ -0,0 +1,147 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	runtimepprof "runtime/pprof"
+	"sync"
+	"time"
+)
+
+// profilesDir is the directory of the data directory profiles are dumped to
+const profilesDir = "profiles"
+
+// ErrDebugAddress is returned when the debug server would listen on a non-loopback address
+var ErrDebugAddress = errors.New("debug server must listen on a loopback address")
+
+// debugServer serves the net/http/pprof endpoints on localhost
+type debugServer struct {
+	mutex sync.Mutex
+	srv   *http.Server
+	addr  string
+}
+
+// start listens on addr, which must be a loopback address, and returns the listening address
+func (d *debugServer) start(addr string) (string, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid debug server address %s: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", fmt.Errorf("%w: %s", ErrDebugAddress, addr)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.srv != nil {
+		return d.addr, nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	d.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	d.addr = listener.Addr().String()
+	go d.srv.Serve(listener)
+	return d.addr, nil
+}
+
+// stop shuts the server down, if running
+func (d *debugServer) stop() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.srv == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	d.srv.Shutdown(ctx)
+	d.srv = nil
+	d.addr = ""
+}
+
+// startDebugServer starts the pprof endpoints if enabled in the config
+func (a *App) startDebugServer() {
+	cfg := a.config.GetConfig()
+	if !cfg.DebugServerEnabled {
+		return
+	}
+
+	addr, err := a.debug.start(cfg.DebugServerAddress)
+	if err != nil {
+		a.emit("log", "Failed to start debug server: "+err.Error())
+		return
+	}
+	a.emit("log", "Debug server listening on http://"+addr+"/debug/pprof/")
+}
+
+// DumpProfiles writes goroutine and heap profiles to the profiles directory of the config
+// directory and returns the written files
+func (a *App) DumpProfiles() ([]string, error) {
+	dir := filepath.Join(a.config.DataDir(), profilesDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+
+	stamp := time.Now().Format("20060102_150405")
+	profiles := []struct {
+		name  string
+		debug int
+		ext   string
+	}{
+		{"goroutine", 2, "txt"},
+		{"heap", 0, "pprof"},
+	}
+
+	paths := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		path := filepath.Join(dir, p.name+"_"+stamp+"."+p.ext)
+		if err := writeProfile(path, p.name, p.debug); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	a.emit("log", "Profiles written to "+dir)
+	return paths, nil
+}
+
+// writeProfile writes the named runtime profile to path
+func writeProfile(path string, name string, debug int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	defer file.Close()
+
+	if err := runtimepprof.Lookup(name).WriteTo(file, debug); err != nil {
+		return fmt.Errorf("failed to write %s profile: %w", name, err)
+	}
+	return file.Close()
+}
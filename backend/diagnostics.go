@@ -0,0 +1,290 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/sysinfo"
+)
+
+const (
+	// diagnosticsTimeout bounds every network test of the diagnostics
+	diagnosticsTimeout = 10 * time.Second
+
+	// connectivityTarget is dialed to test direct internet access
+	connectivityTarget = "1.1.1.1:443"
+
+	// clockSkewWarning is the skew against the endpoints above which the local clock is reported
+	clockSkewWarning = time.Minute
+)
+
+// DiagnosticCheck is the outcome of one test of the diagnostics
+type DiagnosticCheck struct {
+	Target  string `json:"target"`
+	OK      bool   `json:"ok"`
+	Latency int64  `json:"latency"` // in milliseconds
+	Detail  string `json:"detail,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// EndpointDiagnostic is the direct test of a judge endpoint
+type EndpointDiagnostic struct {
+	DiagnosticCheck
+	Addresses []string `json:"addresses,omitempty"`
+	ClockSkew int64    `json:"clockSkew"` // in seconds, endpoint clock minus local clock
+}
+
+// DiagnosticsReport describes the environment of the checks, to tell local problems
+// (no connectivity, broken judges, exhausted descriptors) from dead proxies
+type DiagnosticsReport struct {
+	Time               time.Time            `json:"time"`
+	OS                 string               `json:"os"`
+	Arch               string               `json:"arch"`
+	CPUs               int                  `json:"cpus"`
+	Internet           DiagnosticCheck      `json:"internet"`
+	DNS                DiagnosticCheck      `json:"dns"`
+	Endpoints          []EndpointDiagnostic `json:"endpoints"`
+	Upstream           *DiagnosticCheck     `json:"upstream,omitempty"`
+	SourceAddress      string               `json:"sourceAddress,omitempty"`
+	OpenFileLimit      uint64               `json:"openFileLimit"`
+	FileThreadLimit    int                  `json:"fileThreadLimit"`
+	RecommendedThreads int                  `json:"recommendedThreads"`
+	MaxThreads         int                  `json:"maxThreads"`
+	ClockSkew          int64                `json:"clockSkew"` // in seconds, largest skew against the endpoints
+	Problems           []string             `json:"problems"`
+}
+
+// RunDiagnostics tests direct internet access, the configured endpoints and the upstream
+// proxy, and reports the descriptor limits and local clock skew
+func (a *App) RunDiagnostics() DiagnosticsReport {
+	cfg := a.config.GetConfig()
+	report := DiagnosticsReport{
+		Time:               time.Now(),
+		OS:                 runtime.GOOS,
+		Arch:               runtime.GOARCH,
+		CPUs:               runtime.NumCPU(),
+		SourceAddress:      cfg.SourceAddress,
+		FileThreadLimit:    sysinfo.FileThreadLimit(),
+		RecommendedThreads: sysinfo.RecommendedThreads(),
+		MaxThreads:         cfg.MaxThreads,
+		Problems:           []string{},
+	}
+	report.OpenFileLimit, _ = sysinfo.OpenFileLimit()
+
+	dialer := &net.Dialer{Timeout: diagnosticsTimeout}
+	if source, err := checker.ResolveSourceAddr(cfg.SourceAddress); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	} else if source != nil {
+		dialer.LocalAddr = source
+	}
+
+	report.Internet = diagnoseConnectivity(dialer)
+	if !report.Internet.OK {
+		report.Problems = append(report.Problems, "No direct internet connectivity: "+report.Internet.Error)
+	}
+	report.DNS = diagnoseDNS("example.com")
+	if !report.DNS.OK {
+		report.Problems = append(report.Problems, "DNS resolution failed: "+report.DNS.Error)
+	}
+
+	report.Endpoints = diagnoseEndpoints(dialer, diagnosticEndpoints(cfg.LastEndpoint, cfg.DefaultEndpoints))
+	for _, endpoint := range report.Endpoints {
+		if !endpoint.OK {
+			report.Problems = append(report.Problems, fmt.Sprintf("Endpoint %s failed: %s", endpoint.Target, endpoint.Error))
+		}
+		if abs64(endpoint.ClockSkew) > abs64(report.ClockSkew) {
+			report.ClockSkew = endpoint.ClockSkew
+		}
+	}
+	if abs64(report.ClockSkew) >= int64(clockSkewWarning/time.Second) {
+		report.Problems = append(report.Problems, fmt.Sprintf("Local clock is off by %ds", report.ClockSkew))
+	}
+
+	if cfg.LastUpstreamProxy != "" {
+		upstream := diagnoseUpstream(cfg.LastUpstreamProxy, cfg.LastUpstreamProxyType, cfg.LastEndpoint)
+		report.Upstream = &upstream
+		if !upstream.OK {
+			report.Problems = append(report.Problems, "Upstream proxy failed: "+upstream.Error)
+		}
+	}
+
+	if report.FileThreadLimit > 0 && cfg.MaxThreads > report.FileThreadLimit {
+		report.Problems = append(report.Problems, fmt.Sprintf("The open file limit (%d) allows only %d threads, %d are allowed", report.OpenFileLimit, report.FileThreadLimit, cfg.MaxThreads))
+	}
+
+	a.emit("log", fmt.Sprintf("Diagnostics finished with %d problem(s)", len(report.Problems)))
+	return report
+}
+
+// diagnosticEndpoints returns the last used endpoint followed by the other configured ones
+func diagnosticEndpoints(last string, defaults []string) []string {
+	endpoints := make([]string, 0, len(defaults)+1)
+	seen := make(map[string]bool)
+	for _, endpoint := range append([]string{last}, defaults...) {
+		if endpoint != "" && !seen[endpoint] {
+			seen[endpoint] = true
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// diagnoseConnectivity dials a well-known address directly
+func diagnoseConnectivity(dialer *net.Dialer) DiagnosticCheck {
+	check := DiagnosticCheck{Target: connectivityTarget}
+	start := time.Now()
+	conn, err := dialer.Dial("tcp", connectivityTarget)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	conn.Close()
+
+	check.OK = true
+	check.Latency = time.Since(start).Milliseconds()
+	return check
+}
+
+// diagnoseDNS resolves a well-known host with the system resolver
+func diagnoseDNS(host string) DiagnosticCheck {
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+
+	check := DiagnosticCheck{Target: host}
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	check.OK = true
+	check.Latency = time.Since(start).Milliseconds()
+	check.Detail = strings.Join(addrs, ", ")
+	return check
+}
+
+// diagnoseEndpoints resolves and requests every endpoint directly, in parallel
+func diagnoseEndpoints(dialer *net.Dialer, endpoints []string) []EndpointDiagnostic {
+	results := make([]EndpointDiagnostic, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			results[i] = diagnoseEndpoint(dialer, endpoint)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// diagnoseEndpoint resolves an endpoint and checks that it answers with an IP address
+func diagnoseEndpoint(dialer *net.Dialer, endpoint string) EndpointDiagnostic {
+	result := EndpointDiagnostic{DiagnosticCheck: DiagnosticCheck{Target: endpoint}}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		result.Error = "invalid endpoint URL"
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+
+	result.Addresses, err = net.DefaultResolver.LookupHost(ctx, u.Hostname())
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: diagnosticsTimeout},
+		Timeout:   diagnosticsTimeout,
+	}
+	defer client.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+	result.Latency = time.Since(start).Milliseconds()
+
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		result.ClockSkew = int64(date.Sub(time.Now()).Round(time.Second) / time.Second)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result.Error = resp.Status
+		return result
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	ip := strings.TrimSpace(string(body))
+	if net.ParseIP(ip) == nil {
+		result.Error = "response is not an IP address"
+		return result
+	}
+
+	result.OK = true
+	result.Detail = ip
+	return result
+}
+
+// diagnoseUpstream requests the endpoint through the upstream proxy
+func diagnoseUpstream(address string, proxyType checker.ProxyType, endpoint string) DiagnosticCheck {
+	check := DiagnosticCheck{Target: checker.StripProxyAuth(address)}
+
+	start := time.Now()
+	ip, err := checker.NewUpstreamProxy(address, proxyType, diagnosticsTimeout).TestUpstreamConnection(endpoint)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	check.OK = true
+	check.Latency = time.Since(start).Milliseconds()
+	check.Detail = strings.TrimSpace(ip)
+	return check
+}
+
+// abs64 returns the absolute value of n
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
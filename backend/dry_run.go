@@ -0,0 +1,88 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// defaultBytesPerCheck is the estimated traffic of a check before any run was recorded:
+// a handshake, the judge request and a small plain-text response
+const defaultBytesPerCheck = 4096
+
+// DryRunReport describes what a check would do, computed without any network request
+type DryRunReport struct {
+	// Input is the number of proxies given
+	Input int `json:"input"`
+	// Duplicates is the number of repeated proxies that would be checked once
+	Duplicates int `json:"duplicates"`
+	// Filtered is the number of proxies dropped by the blocklist, allowlist and country filter
+	Filtered int `json:"filtered"`
+	// ToCheck is the number of proxies that would be checked
+	ToCheck int    `json:"toCheck"`
+	Threads int    `json:"threads"`
+	Error   string `json:"error,omitempty"`
+	// ChecksPerSecond is the expected throughput and RateSource where it comes from:
+	// "history" (past runs) or "timeout" (every check running to the timeout)
+	ChecksPerSecond float64 `json:"checksPerSecond"`
+	RateSource      string  `json:"rateSource"`
+	// EstimatedDuration is in milliseconds
+	EstimatedDuration int64 `json:"estimatedDuration"`
+	EstimatedBytes    int64 `json:"estimatedBytes"`
+}
+
+// DryRun parses, dedupes and filters a proxy list the way StartCheck would and estimates
+// the duration and traffic of the run, without checking anything
+func (a *App) DryRun(params CheckParams) (DryRunReport, error) {
+	report := DryRunReport{Input: len(params.ProxyList)}
+
+	seen := make(map[string]bool, len(params.ProxyList))
+	unique := make([]string, 0, len(params.ProxyList))
+	for _, proxy := range params.ProxyList {
+		if seen[proxy] {
+			report.Duplicates++
+			continue
+		}
+		seen[proxy] = true
+		unique = append(unique, proxy)
+	}
+	params.ProxyList = unique
+
+	if err := a.filterInput(&params); err != nil {
+		return report, err
+	}
+	report.Filtered = len(unique) - len(params.ProxyList)
+	report.ToCheck = len(params.ProxyList)
+
+	a.clampThreads(&params)
+	report.Threads = params.Threads
+	if err := a.buildCheckRequest(params).Validate(); err != nil {
+		report.Error = err.Error()
+	}
+
+	report.ChecksPerSecond = a.throughput.rate(params.Threads)
+	report.RateSource = "history"
+	if report.ChecksPerSecond <= 0 {
+		report.ChecksPerSecond = float64(params.Threads) / checker.DefaultCheckTimeout.Seconds()
+		report.RateSource = "timeout"
+	}
+	if report.ChecksPerSecond > 0 {
+		seconds := float64(report.ToCheck) / report.ChecksPerSecond
+		report.EstimatedDuration = time.Duration(seconds * float64(time.Second)).Milliseconds()
+	}
+
+	bytesPerCheck := a.throughput.bytesPerCheck()
+	if bytesPerCheck <= 0 {
+		bytesPerCheck = defaultBytesPerCheck
+	}
+	report.EstimatedBytes = int64(bytesPerCheck * float64(report.ToCheck))
+	return report, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// DuplicateRun describes a finished run identical to a check being started
+type DuplicateRun struct {
+	Fingerprint string    `json:"fingerprint"`
+	Finished    time.Time `json:"finished"`
+	Results     int       `json:"results"`
+	Live        int       `json:"live"`
+}
+
+// previousRun is the last run that checked its whole list
+type previousRun struct {
+	fingerprint string
+	finished    time.Time
+	params      CheckParams
+	results     []checker.ProxyResult
+}
+
+// runFingerprint hashes the proxy list and the parameters that decide the results of a
+// check. The list order and the thread count do not change the results, so they are left out
+func runFingerprint(params CheckParams) string {
+	proxies := append([]string(nil), params.ProxyList...)
+	sort.Strings(proxies)
+	countries := append([]string(nil), params.Countries...)
+	sort.Strings(countries)
+
+	h := sha256.New()
+	for _, field := range []string{
+		strings.Join(proxies, "\n"),
+		params.ProxyType,
+		params.Endpoint,
+		params.UpstreamProxy,
+		params.UpstreamType,
+		params.SourceAddress,
+		strings.Join(countries, ","),
+		fmt.Sprint(params.ExcludeCountries),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordPreviousRun remembers the results of a run that checked its whole list
+func (a *App) recordPreviousRun(stats checker.Stats, results []checker.ProxyResult) {
+	if stats.Pending > 0 || stats.Aborted > 0 || len(results) == 0 {
+		return
+	}
+
+	a.resultsMux.Lock()
+	defer a.resultsMux.Unlock()
+	a.previous = &previousRun{
+		fingerprint: runFingerprint(a.lastParams),
+		finished:    time.Now(),
+		params:      a.lastParams,
+		results:     results,
+	}
+}
+
+// duplicateRun returns the previous run if it has the same fingerprint as params and
+// finished within the configured window, or nil
+func (a *App) duplicateRun(params CheckParams) *DuplicateRun {
+	window := time.Duration(a.config.GetConfig().DuplicateRunWindow) * time.Minute
+	if window <= 0 || params.AllowDuplicate {
+		return nil
+	}
+
+	a.resultsMux.Lock()
+	defer a.resultsMux.Unlock()
+
+	prev := a.previous
+	if prev == nil || time.Since(prev.finished) > window || prev.fingerprint != runFingerprint(params) {
+		return nil
+	}
+
+	dup := &DuplicateRun{Fingerprint: prev.fingerprint, Finished: prev.finished, Results: len(prev.results)}
+	for _, r := range prev.results {
+		if r.Status == checker.StatusLive || r.Status == checker.StatusSlow {
+			dup.Live++
+		}
+	}
+	return dup
+}
+
+// ReloadPreviousRun loads the results of the last finished run into the results view
+// instead of checking the same list again
+func (a *App) ReloadPreviousRun() string {
+	a.resultsMux.Lock()
+	prev := a.previous
+	a.resultsMux.Unlock()
+	if prev == nil {
+		return "No previous run to reload"
+	}
+
+	if !a.manager.PrepareExternalRun(toCheckRequest(prev.params)) {
+		return "Check already in progress"
+	}
+
+	a.resultsMux.Lock()
+	a.results = make([]ProxyResult, 0, len(prev.results))
+	a.lastParams = prev.params
+	a.resultsMux.Unlock()
+
+	a.manager.AppendResults(prev.results)
+	a.updateResults()
+	a.updateStats()
+
+	a.emit("log", fmt.Sprintf("Reloaded %d results of the run finished at %s", len(prev.results), prev.finished.Format("15:04:05")))
+	return fmt.Sprintf("Reloaded %d results", len(prev.results))
+}
@@ -0,0 +1,77 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// defaultPTRConcurrency is the number of reverse DNS lookups run at the same time
+const defaultPTRConcurrency = 8
+
+// defaultRDAPConcurrency is the number of RDAP queries run at the same time; registries rate limit
+const defaultRDAPConcurrency = 2
+
+// rdapCacheTTL is how long RDAP registrations are cached
+const rdapCacheTTL = 7 * 24 * time.Hour
+
+// rdapCacheFile is the file in the data directory holding the RDAP cache
+const rdapCacheFile = "rdap_cache.json"
+
+// enrichResult adds the enabled lookups about the exit IP to a live result
+func (a *App) enrichResult(ctx context.Context, r *checker.ProxyResult) {
+	if r.OutgoingIP == "" {
+		return
+	}
+
+	cfg := a.config.GetConfig()
+	if cfg.ResolvePTR {
+		r.Hostname = a.ptr.Lookup(ctx, r.OutgoingIP)
+	}
+	if cfg.RDAPLookup {
+		info, err := a.rdap.Lookup(ctx, r.OutgoingIP)
+		if err == nil {
+			r.Network = info
+		}
+	}
+}
+
+// saveRDAPCache persists the RDAP cache if lookups are enabled
+func (a *App) saveRDAPCache() {
+	if !a.config.GetConfig().RDAPLookup {
+		return
+	}
+	if err := a.rdap.Save(filepath.Join(a.config.DataDir(), rdapCacheFile)); err != nil {
+		log.Printf("Failed to save RDAP cache: %v", err)
+	}
+}
+
+// probeOptions returns the advanced diagnostics enabled in the configuration
+func probeOptions(cfg config.Config) checker.ProbeOptions {
+	return checker.ProbeOptions{
+		MTUBytes:      cfg.MTUProbeBytes,
+		SoakDuration:  time.Duration(cfg.SoakSeconds) * time.Second,
+		SoakInterval:  time.Duration(cfg.SoakIntervalSeconds) * time.Second,
+		Connections:   cfg.CapacityProbeConnections,
+		FTPServer:     cfg.FTPProbeServer,
+		Ports:         cfg.PortMatrix,
+		PortHost:      cfg.PortMatrixHost,
+		DualStack:     cfg.DualStackProbe,
+		IPv4Echo:      cfg.IPv4EchoEndpoint,
+		IPv6Echo:      cfg.IPv6EchoEndpoint,
+		ConnectPolicy: cfg.ConnectPolicyProbe,
+		ConnectPorts:  cfg.ConnectPolicyPorts,
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package enrich looks up additional information about the exit IPs of live proxies.
+package enrich
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ptrTimeout bounds a single reverse DNS lookup
+const ptrTimeout = 5 * time.Second
+
+// ptrEntry is a cached reverse DNS answer
+type ptrEntry struct {
+	host    string
+	expires time.Time
+}
+
+// PTRResolver resolves the reverse DNS names of IP addresses
+// Answers, including failures, are cached for a while and at most a fixed number
+// of lookups run at the same time
+type PTRResolver struct {
+	mutex    sync.Mutex
+	cache    map[string]ptrEntry
+	ttl      time.Duration
+	sem      chan struct{}
+	resolver *net.Resolver
+}
+
+// NewPTRResolver creates a resolver running at most concurrency lookups at a time
+// and caching answers for ttl
+func NewPTRResolver(concurrency int, ttl time.Duration) *PTRResolver {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &PTRResolver{
+		cache:    make(map[string]ptrEntry),
+		ttl:      ttl,
+		sem:      make(chan struct{}, concurrency),
+		resolver: net.DefaultResolver,
+	}
+}
+
+// Lookup returns the first PTR name of ip without the trailing dot, or an empty string
+func (r *PTRResolver) Lookup(ctx context.Context, ip string) string {
+	if net.ParseIP(ip) == nil {
+		return ""
+	}
+
+	r.mutex.Lock()
+	entry, ok := r.cache[ip]
+	r.mutex.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.host
+	}
+
+	select {
+	case r.sem <- struct{}{}:
+		defer func() { <-r.sem }()
+	case <-ctx.Done():
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ptrTimeout)
+	defer cancel()
+
+	var host string
+	names, err := r.resolver.LookupAddr(ctx, ip)
+	if err == nil && len(names) > 0 {
+		host = strings.TrimSuffix(names[0], ".")
+	}
+
+	// Cancelled lookups say nothing about the address and are not cached
+	if ctx.Err() == nil || err == nil {
+		r.mutex.Lock()
+		r.cache[ip] = ptrEntry{host: host, expires: time.Now().Add(r.ttl)}
+		r.mutex.Unlock()
+	}
+
+	return host
+}
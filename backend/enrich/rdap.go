@@ -0,0 +1,273 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrRDAPFailed = errors.New("RDAP lookup failed")
+)
+
+// RDAPURL is the RDAP bootstrap service redirecting IP queries to the responsible registry
+var RDAPURL = "https://rdap.org/ip/"
+
+// rdapTimeout bounds a single RDAP query, including redirects
+const rdapTimeout = 15 * time.Second
+
+// rdapNetwork is a cached network block with its registration information
+type rdapNetwork struct {
+	Start   net.IP              `json:"start"`
+	End     net.IP              `json:"end"`
+	Info    checker.NetworkInfo `json:"info"`
+	Fetched time.Time           `json:"fetched"`
+}
+
+// RDAPClient looks up the registration of IP networks over RDAP
+// Answers are cached per network block, so one query covers every exit IP of a
+// provider's range, and the cache can be persisted between sessions
+type RDAPClient struct {
+	mutex    sync.Mutex
+	networks []rdapNetwork
+	ttl      time.Duration
+	sem      chan struct{}
+	client   *http.Client
+}
+
+// NewRDAPClient creates a client running at most concurrency queries at a time and
+// keeping answers for ttl
+func NewRDAPClient(concurrency int, ttl time.Duration) *RDAPClient {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &RDAPClient{
+		ttl:    ttl,
+		sem:    make(chan struct{}, concurrency),
+		client: &http.Client{Timeout: rdapTimeout},
+	}
+}
+
+// Lookup returns the registration of the network containing ip
+func (c *RDAPClient) Lookup(ctx context.Context, ip string) (*checker.NetworkInfo, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, fmt.Errorf("%w: invalid IP %s", ErrRDAPFailed, ip)
+	}
+
+	if info := c.cached(addr); info != nil {
+		return info, nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+		defer func() { <-c.sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// Another lookup may have fetched the network while this one waited
+	if info := c.cached(addr); info != nil {
+		return info, nil
+	}
+
+	network, err := c.fetch(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.networks = append(c.networks, *network)
+	c.mutex.Unlock()
+
+	info := network.Info
+	return &info, nil
+}
+
+// cached returns the unexpired cached registration of the network containing ip
+func (c *RDAPClient) cached(ip net.IP) *checker.NetworkInfo {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for i := len(c.networks) - 1; i >= 0; i-- {
+		n := c.networks[i]
+		if now.Sub(n.Fetched) > c.ttl {
+			continue
+		}
+		if bytes.Compare(ip.To16(), n.Start.To16()) >= 0 && bytes.Compare(ip.To16(), n.End.To16()) <= 0 {
+			info := n.Info
+			return &info
+		}
+	}
+	return nil
+}
+
+// rdapResponse is the part of an RDAP IP network object used here
+type rdapResponse struct {
+	Handle       string       `json:"handle"`
+	Name         string       `json:"name"`
+	StartAddress string       `json:"startAddress"`
+	EndAddress   string       `json:"endAddress"`
+	Country      string       `json:"country"`
+	Entities     []rdapEntity `json:"entities"`
+}
+
+// rdapEntity is a contact attached to an RDAP object
+type rdapEntity struct {
+	Roles      []string        `json:"roles"`
+	VCardArray json.RawMessage `json:"vcardArray"`
+	Entities   []rdapEntity    `json:"entities"`
+}
+
+// fetch queries the registration of the network containing ip
+func (c *RDAPClient) fetch(ctx context.Context, ip string) (*rdapNetwork, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, RDAPURL+ip, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRDAPFailed, err)
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRDAPFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrRDAPFailed, resp.Status)
+	}
+
+	var r rdapResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %v", ErrRDAPFailed, err)
+	}
+
+	network := &rdapNetwork{
+		Start:   net.ParseIP(r.StartAddress),
+		End:     net.ParseIP(r.EndAddress),
+		Fetched: time.Now(),
+		Info: checker.NetworkInfo{
+			Name:    r.Name,
+			Handle:  r.Handle,
+			Country: r.Country,
+		},
+	}
+
+	// Without a range, only the queried address can be cached
+	if network.Start == nil || network.End == nil {
+		network.Start = net.ParseIP(ip)
+		network.End = network.Start
+	}
+
+	walkEntities(r.Entities, func(e rdapEntity) {
+		for _, role := range e.Roles {
+			switch role {
+			case "registrant":
+				if network.Info.Org == "" {
+					network.Info.Org = vcardField(e.VCardArray, "fn")
+				}
+			case "abuse":
+				if network.Info.AbuseEmail == "" {
+					network.Info.AbuseEmail = vcardField(e.VCardArray, "email")
+				}
+			}
+		}
+	})
+
+	return network, nil
+}
+
+// walkEntities calls fn for every entity, including nested ones
+func walkEntities(entities []rdapEntity, fn func(rdapEntity)) {
+	for _, e := range entities {
+		fn(e)
+		walkEntities(e.Entities, fn)
+	}
+}
+
+// vcardField returns the first value of a property in a jCard ["vcard", [[name, params, type, value], ...]]
+func vcardField(raw json.RawMessage, name string) string {
+	var card []interface{}
+	if json.Unmarshal(raw, &card) != nil || len(card) < 2 {
+		return ""
+	}
+
+	properties, _ := card[1].([]interface{})
+	for _, p := range properties {
+		prop, _ := p.([]interface{})
+		if len(prop) < 4 || prop[0] != name {
+			continue
+		}
+		if value, ok := prop[3].(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// Save writes the unexpired cache to path
+func (c *RDAPClient) Save(path string) error {
+	c.mutex.Lock()
+	now := time.Now()
+	networks := make([]rdapNetwork, 0, len(c.networks))
+	for _, n := range c.networks {
+		if now.Sub(n.Fetched) <= c.ttl {
+			networks = append(networks, n)
+		}
+	}
+	c.mutex.Unlock()
+
+	data, err := json.Marshal(networks)
+	if err != nil {
+		return fmt.Errorf("failed to encode RDAP cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write RDAP cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write RDAP cache: %w", err)
+	}
+	return nil
+}
+
+// Load reads a cache written by Save; a missing file leaves the cache empty
+func (c *RDAPClient) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read RDAP cache: %w", err)
+	}
+
+	var networks []rdapNetwork
+	if err := json.Unmarshal(data, &networks); err != nil {
+		return fmt.Errorf("invalid RDAP cache: %w", err)
+	}
+
+	c.mutex.Lock()
+	c.networks = networks
+	c.mutex.Unlock()
+	return nil
+}
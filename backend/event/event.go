@@ -0,0 +1,238 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package event defines the versioned, typed event payloads published by the app.
+//
+// Every typed event is wrapped in an Envelope carrying the schema version, so
+// consumers (alternate frontends, the control API stream) can detect changes.
+// Fields are only ever added within a version; renaming or removing a field, or
+// changing its meaning, bumps SchemaVersion.
+package event
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// SchemaVersion is the version of the event payloads defined in this package
+const SchemaVersion = 1
+
+// Typed event names
+const (
+	NameResultAdded   = "event:result-added"
+	NameStatsUpdate   = "event:stats-update"
+	NameRunState      = "event:run-state"
+	NameCheckPanic    = "event:check-panic"
+	NameHealthAlert   = "event:health-alert"
+	NameNetworkChange = "event:network-change"
+	NameSystemResume  = "event:system-resume"
+)
+
+// Run states carried by RunState
+const (
+	StateRunning   = "running"
+	StatePausing   = "pausing"
+	StatePaused    = "paused"
+	StateStopping  = "stopping"
+	StateStopped   = "stopped"
+	StateCompleted = "completed"
+)
+
+// Envelope wraps a typed payload with its schema version
+type Envelope struct {
+	Version int         `json:"version"`
+	Name    string      `json:"name"`
+	RunID   string      `json:"runId"`
+	Time    time.Time   `json:"time"`
+	Data    interface{} `json:"data"`
+}
+
+// ResultAdded is published for every completed proxy check
+type ResultAdded struct {
+	Result checker.ProxyResult `json:"result"`
+}
+
+// StatsUpdate is published when the statistics of a run change
+type StatsUpdate struct {
+	Stats checker.Stats `json:"stats"`
+}
+
+// RunState is published when a run changes state
+type RunState struct {
+	State string `json:"state"`
+}
+
+// CheckPanic is published when a single check panicked; the proxy is recorded as an error
+type CheckPanic struct {
+	Panic checker.PanicInfo `json:"panic"`
+}
+
+// HealthAlert is published when the endpoint or the local network goes down during a run,
+// and again once it is back
+type HealthAlert struct {
+	Healthy  bool   `json:"healthy"`
+	Endpoint string `json:"endpoint"`
+	// Network is set when the local network itself is down rather than the endpoint
+	Network bool   `json:"network"`
+	Error   string `json:"error,omitempty"`
+	// Paused is set when the run was paused because of the outage, Resumed once it went on
+	Paused  bool `json:"paused"`
+	Resumed bool `json:"resumed"`
+}
+
+// NetworkChange is published when the local network interfaces changed during a run
+// (VPN connected or dropped, Wi-Fi switched); the run is paused until the user decides
+// whether the results checked since Since are kept
+type NetworkChange struct {
+	Since      time.Time `json:"since"`
+	DetectedAt time.Time `json:"detectedAt"`
+	Added      []string  `json:"added"`
+	Removed    []string  `json:"removed"`
+	PreviousIP string    `json:"previousIp,omitempty"`
+	PublicIP   string    `json:"publicIp,omitempty"`
+	Paused     bool      `json:"paused"`
+}
+
+// SystemResume is published when the system woke up from sleep during a run; the run is
+// paused while the network and endpoint are checked again, and the failures recorded
+// around the sleep are flagged as suspect
+type SystemResume struct {
+	SleptAt   time.Time `json:"sleptAt"`
+	ResumedAt time.Time `json:"resumedAt"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	Suspect   int       `json:"suspect"`
+	Paused    bool      `json:"paused"`
+	Resumed   bool      `json:"resumed"`
+}
+
+// New wraps a payload in an envelope of the current schema version
+func New(name string, runID string, data interface{}) Envelope {
+	return Envelope{
+		Version: SchemaVersion,
+		Name:    name,
+		RunID:   runID,
+		Time:    time.Now(),
+		Data:    data,
+	}
+}
+
+// Field describes a JSON field of a payload
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Spec describes a typed event
+type Spec struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Fields      []Field `json:"fields"`
+}
+
+// Schema describes all typed events of a schema version
+type Schema struct {
+	Version  int     `json:"version"`
+	Envelope []Field `json:"envelope"`
+	Events   []Spec  `json:"events"`
+}
+
+// GetSchema returns the schema of the typed events
+func GetSchema() Schema {
+	return Schema{
+		Version:  SchemaVersion,
+		Envelope: fields(reflect.TypeOf(Envelope{})),
+		Events: []Spec{
+			{
+				Name:        NameResultAdded,
+				Description: "A proxy check completed",
+				Fields:      fields(reflect.TypeOf(ResultAdded{})),
+			},
+			{
+				Name:        NameStatsUpdate,
+				Description: "Run statistics changed",
+				Fields:      fields(reflect.TypeOf(StatsUpdate{})),
+			},
+			{
+				Name:        NameRunState,
+				Description: "A run changed state (running, pausing, paused, stopping, stopped, completed)",
+				Fields:      fields(reflect.TypeOf(RunState{})),
+			},
+			{
+				Name:        NameCheckPanic,
+				Description: "A proxy check panicked and was recorded as an error",
+				Fields:      fields(reflect.TypeOf(CheckPanic{})),
+			},
+			{
+				Name:        NameHealthAlert,
+				Description: "The endpoint or local network went down during a run, or recovered",
+				Fields:      fields(reflect.TypeOf(HealthAlert{})),
+			},
+			{
+				Name:        NameNetworkChange,
+				Description: "The local network changed during a run, which was paused",
+				Fields:      fields(reflect.TypeOf(NetworkChange{})),
+			},
+			{
+				Name:        NameSystemResume,
+				Description: "The system woke up from sleep during a run, whose failures around the sleep were flagged as suspect",
+				Fields:      fields(reflect.TypeOf(SystemResume{})),
+			},
+		},
+	}
+}
+
+// fields lists the JSON fields of a struct type, flattening nested structs as "parent.child"
+func fields(t reflect.Type) []Field {
+	var result []Field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}) {
+			for _, sub := range fields(f.Type) {
+				result = append(result, Field{Name: name + "." + sub.Name, Type: sub.Type})
+			}
+			continue
+		}
+
+		result = append(result, Field{Name: name, Type: typeName(f.Type)})
+	}
+	return result
+}
+
+// typeName returns a JSON-oriented name of a Go type
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array<" + typeName(t.Elem()) + ">"
+	case reflect.Map:
+		return "object<" + typeName(t.Elem()) + ">"
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return "string (RFC 3339)"
+		}
+		return "object"
+	default:
+		return "any"
+	}
+}
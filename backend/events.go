@@ -0,0 +1,205 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// eventReplaySize is the number of events kept for replay
+const eventReplaySize = 2000
+
+// Event is an event emitted by the app, as delivered to non-Wails subscribers
+type Event struct {
+	// Seq numbers the replayable events in emission order; it is 0 for bulk updates
+	Seq  uint64      `json:"seq,omitempty"`
+	Name string      `json:"name"`
+	Data interface{} `json:"data"`
+	Time time.Time   `json:"time"`
+}
+
+// EventReplay is the list of events emitted after a sequence number
+type EventReplay struct {
+	Events []Event `json:"events"`
+	// Seq is the sequence number of the last event emitted
+	Seq uint64 `json:"seq"`
+	// Missed is set if events after the requested sequence number are no longer kept,
+	// in which case the state should be rebuilt from GetRunSnapshot
+	Missed bool `json:"missed"`
+}
+
+// eventBus fans out emitted events to subscribers (control API streams, headless modes)
+// and keeps the most recent ones for replay
+type eventBus struct {
+	mutex       sync.RWMutex
+	subscribers map[chan Event]struct{}
+	seq         uint64
+	replay      [eventReplaySize]Event
+}
+
+// replayable returns false for the bulk result and stats updates, which are superseded
+// by the next one and too large to keep; the results they carry are replayed through
+// the typed result events
+func replayable(name string) bool {
+	name, _, _ = strings.Cut(name, ":")
+	return name != "results-update" && name != "stats-update"
+}
+
+// record numbers a replayable event and keeps it for replay
+func (b *eventBus) record(e Event) Event {
+	if !replayable(e.Name) {
+		return e
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.seq++
+	e.Seq = b.seq
+	b.replay[e.Seq%eventReplaySize] = e
+	return e
+}
+
+// since returns the kept events numbered after seq
+func (b *eventBus) since(seq uint64) EventReplay {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	replay := EventReplay{Seq: b.seq}
+	if seq > b.seq {
+		seq = b.seq
+	}
+	first := seq + 1
+	if b.seq >= eventReplaySize && first <= b.seq-eventReplaySize {
+		first = b.seq - eventReplaySize + 1
+		replay.Missed = true
+	}
+	for s := first; s <= b.seq; s++ {
+		replay.Events = append(replay.Events, b.replay[s%eventReplaySize])
+	}
+	return replay
+}
+
+// subscribe registers a new subscriber; events are dropped if its buffer is full
+func (b *eventBus) subscribe(buffer int) chan Event {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = make(map[chan Event]struct{})
+	}
+
+	ch := make(chan Event, buffer)
+	b.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe removes a subscriber and closes its channel
+func (b *eventBus) unsubscribe(ch chan Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publish delivers an event to all subscribers without blocking
+func (b *eventBus) publish(event Event) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// emit sends an event to the frontend and to all subscribers
+// Sensitive substrings of log messages are redacted
+func (a *App) emit(name string, data interface{}) {
+	if msg, ok := data.(string); ok {
+		data = a.redact(msg)
+	}
+
+	// The sequence number is passed to the frontend as a second argument, to fetch the
+	// events it missed with GetEventsSince
+	e := a.events.record(Event{Name: name, Data: data, Time: time.Now()})
+	stream := true
+	if msg, ok := data.(string); ok && name == "log" {
+		stream = a.streamLog(a.logSearch.add(e.Time, msg))
+	}
+	if a.ctx != nil && stream {
+		if e.Seq > 0 {
+			runtime.EventsEmit(a.ctx, name, data, e.Seq)
+		} else {
+			runtime.EventsEmit(a.ctx, name, data)
+		}
+	}
+
+	if name == "log" {
+		a.logs.add(e)
+		a.appendRunLog(e)
+	}
+	a.events.publish(e)
+}
+
+// GetEventsSince returns the events emitted after sequence number seq, so the frontend
+// can catch up after a reload. Bulk result and stats updates are not replayed
+func (a *App) GetEventsSince(seq uint64) EventReplay {
+	return a.events.since(seq)
+}
+
+// GetEventSchema returns the schema of the typed events
+func (a *App) GetEventSchema() event.Schema {
+	return event.GetSchema()
+}
+
+// emitTyped sends a versioned typed event
+func (a *App) emitTyped(name string, runID string, data interface{}) {
+	a.emit(name, event.New(name, runID, data))
+}
+
+// setRunState announces a run state change as "check-status" (namespaced for parallel
+// runs) and as a typed run-state event
+func (a *App) setRunState(runID string, state string) {
+	if runID == MainRunID {
+		a.emit("check-status", state)
+	} else {
+		a.emitRun(runID, "check-status", state)
+	}
+	a.emitTyped(event.NameRunState, runID, event.RunState{State: state})
+}
+
+// resultHandler returns a handler publishing every completed check of a run
+func (a *App) resultHandler(runID string) func(checker.ProxyResult) {
+	return func(result checker.ProxyResult) {
+		// Inline user:pass@ credentials must not reach the frontend, the replay buffer
+		// or API subscribers
+		result.Proxy = a.redact(result.Proxy)
+		result.Error = a.redact(result.Error)
+		a.emitTyped(event.NameResultAdded, runID, event.ResultAdded{Result: result})
+	}
+}
+
+// panicHandler returns a handler publishing checks of a run that panicked
+func (a *App) panicHandler(runID string) func(checker.PanicInfo) {
+	return func(info checker.PanicInfo) {
+		a.emitTyped(event.NameCheckPanic, runID, event.CheckPanic{Panic: info})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// GetExitGroups returns groups of live proxies sharing the same outgoing IP
+// Only exits used by more than one proxy are returned
+func (a *App) GetExitGroups() []checker.ExitGroup {
+	var duplicates []checker.ExitGroup
+	for _, g := range checker.GroupByExit(export.LiveResults(a.manager.GetResults())) {
+		if len(g.Proxies) > 1 {
+			duplicates = append(duplicates, g)
+		}
+	}
+	return duplicates
+}
+
+// exportable applies export-time settings to live results
+// Quarantined proxies are dropped; with CollapseDuplicateExits, only the fastest proxy
+// of every outgoing IP is kept
+func (a *App) exportable(live []checker.ProxyResult) []checker.ProxyResult {
+	live = a.withoutQuarantined(live)
+	if a.config.GetConfig().CollapseDuplicateExits {
+		return checker.CollapseByExit(live)
+	}
+	return live
+}
+
+// exportSnapshot returns the live snapshot prepared for export
+func (a *App) exportSnapshot() []checker.ProxyResult {
+	return a.exportable(a.liveSnapshot())
+}
+
+// liveResults returns the working results used for exports
+// Slow proxies are included unless ExcludeSlow is set
+func (a *App) liveResults(results []checker.ProxyResult) []checker.ProxyResult {
+	return export.WorkingResults(results, !a.config.GetConfig().ExcludeSlow)
+}
+
+// exitCounts returns the number of live proxies using each outgoing IP
+func exitCounts(results []checker.ProxyResult) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range export.LiveResults(results) {
+		if r.OutgoingIP != "" {
+			counts[r.OutgoingIP]++
+		}
+	}
+	return counts
+}
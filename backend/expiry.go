@@ -0,0 +1,70 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ExpiringProxy is a purchased proxy whose subscription ends soon
+type ExpiringProxy struct {
+	Proxy    string    `json:"proxy"`
+	Provider string    `json:"provider"`
+	Status   string    `json:"status"`
+	Expires  time.Time `json:"expires"`
+	// DaysLeft is the number of whole days until expiry, negative once expired
+	DaysLeft int `json:"daysLeft"`
+}
+
+// GetUpcomingExpirations returns the proxies expiring within days, soonest first
+// Already expired proxies are included; days <= 0 uses the configured warning period
+func (a *App) GetUpcomingExpirations(days int) []ExpiringProxy {
+	if days <= 0 {
+		days = a.config.GetConfig().ExpiryWarningDays
+	}
+
+	now := time.Now()
+	limit := now.AddDate(0, 0, days)
+
+	var expiring []ExpiringProxy
+	for _, r := range a.manager.GetResults() {
+		if !r.Metadata.ExpiresBefore(limit) {
+			continue
+		}
+		expiring = append(expiring, ExpiringProxy{
+			Proxy:    r.Proxy,
+			Provider: r.Metadata.Provider,
+			Status:   string(r.Status),
+			Expires:  r.Metadata.Expires,
+			DaysLeft: int(r.Metadata.Expires.Sub(now).Hours() / 24),
+		})
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].Expires.Before(expiring[j].Expires)
+	})
+	return expiring
+}
+
+// warnExpirations logs and emits the proxies expiring within the warning period
+func (a *App) warnExpirations() {
+	if a.config.GetConfig().ExpiryWarningDays <= 0 {
+		return
+	}
+
+	expiring := a.GetUpcomingExpirations(0)
+	if len(expiring) == 0 {
+		return
+	}
+
+	a.emit("log", fmt.Sprintf("%d purchased proxies expire within %d days", len(expiring), a.config.GetConfig().ExpiryWarningDays))
+	a.emit("proxies-expiring", expiring)
+}
@@ -0,0 +1,118 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Options controls how an export is written to disk
+type Options struct {
+	// Gzip compresses every written file
+	Gzip bool `json:"gzip"`
+
+	// ChunkLines splits the export into files of at most this many proxies, 0 for one file
+	ChunkLines int `json:"chunkLines"`
+}
+
+// Index describes the chunks of a split export
+type Index struct {
+	Format string  `json:"format"`
+	Total  int     `json:"total"`
+	Chunks []Chunk `json:"chunks"`
+}
+
+// Chunk is one file of a split export
+type Chunk struct {
+	File  string `json:"file"`
+	Lines int    `json:"lines"`
+}
+
+// Save writes results to dir as name plus the format extension, applying opts
+// A split export is written as name_0001.txt, name_0002.txt... with a name_index.json
+// listing the chunks. Save returns the paths of the written files, the index last
+func (f *Formatter) Save(dir string, name string, results []checker.ProxyResult, format string, opts Options) ([]string, error) {
+	ext := Extension(format)
+	if opts.Gzip {
+		ext += ".gz"
+	}
+
+	if opts.ChunkLines <= 0 || len(results) <= opts.ChunkLines {
+		path := filepath.Join(dir, name+ext)
+		if err := f.writeExport(path, results, format, opts.Gzip); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}
+
+	index := Index{Format: format, Total: len(results)}
+	var paths []string
+
+	for start := 0; start < len(results); start += opts.ChunkLines {
+		end := min(start+opts.ChunkLines, len(results))
+
+		file := fmt.Sprintf("%s_%04d%s", name, len(index.Chunks)+1, ext)
+		path := filepath.Join(dir, file)
+		if err := f.writeExport(path, results[start:end], format, opts.Gzip); err != nil {
+			return paths, err
+		}
+
+		paths = append(paths, path)
+		index.Chunks = append(index.Chunks, Chunk{File: file, Lines: end - start})
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return paths, fmt.Errorf("failed to marshal export index: %w", err)
+	}
+
+	path := filepath.Join(dir, name+"_index.json")
+	if err := WriteFile(path, data); err != nil {
+		return paths, err
+	}
+
+	return append(paths, path), nil
+}
+
+// writeExport formats results and writes them to path, gzip compressed if requested
+func (f *Formatter) writeExport(path string, results []checker.ProxyResult, format string, compress bool) error {
+	data, err := f.Format(results, format)
+	if err != nil {
+		return err
+	}
+
+	if compress {
+		if data, err = Gzip(data); err != nil {
+			return err
+		}
+	}
+
+	return WriteFile(path, data)
+}
+
+// Gzip compresses export data
+func Gzip(data []byte) ([]byte, error) {
+	var b bytes.Buffer
+
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress export: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress export: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
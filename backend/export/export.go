@@ -0,0 +1,163 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format")
+	ErrUploadFailed  = errors.New("export upload failed")
+)
+
+// Supported export formats
+const (
+	FormatPlain    = "plain"
+	FormatWithType = "with-type"
+	FormatJSON     = "json"
+	FormatJSONL    = "jsonl"
+)
+
+// LiveResults returns the live results of a result set
+func LiveResults(results []checker.ProxyResult) []checker.ProxyResult {
+	live := make([]checker.ProxyResult, 0, len(results))
+	for _, r := range results {
+		if strings.EqualFold(string(r.Status), string(checker.StatusLive)) {
+			live = append(live, r)
+		}
+	}
+	return live
+}
+
+// WorkingResults returns the live results of a result set, plus slow ones if includeSlow is set
+func WorkingResults(results []checker.ProxyResult, includeSlow bool) []checker.ProxyResult {
+	if !includeSlow {
+		return LiveResults(results)
+	}
+
+	working := make([]checker.ProxyResult, 0, len(results))
+	for _, r := range results {
+		if strings.EqualFold(string(r.Status), string(checker.StatusLive)) ||
+			strings.EqualFold(string(r.Status), string(checker.StatusSlow)) {
+			working = append(working, r)
+		}
+	}
+	return working
+}
+
+// Format renders results in the given export format
+func Format(results []checker.ProxyResult, format string) ([]byte, error) {
+	switch format {
+	case FormatPlain, "":
+		var b strings.Builder
+		for _, r := range results {
+			b.WriteString(r.Proxy)
+			b.WriteByte('\n')
+		}
+		return []byte(b.String()), nil
+
+	case FormatWithType:
+		var b strings.Builder
+		for _, r := range results {
+			b.WriteString(string(r.Type) + "://" + r.Proxy)
+			b.WriteByte('\n')
+		}
+		return []byte(b.String()), nil
+
+	case FormatJSON:
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal results: %w", err)
+		}
+		return data, nil
+
+	case FormatJSONL:
+		var b bytes.Buffer
+		w := NewJSONLWriter(&b)
+		for _, r := range results {
+			if err := w.Write(r); err != nil {
+				return nil, err
+			}
+		}
+		return b.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// ContentType returns the MIME type of an export format
+func ContentType(format string) string {
+	switch format {
+	case FormatJSON:
+		return "application/json"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// Extension returns the file extension of an export format
+func Extension(format string) string {
+	switch format {
+	case FormatJSON:
+		return ".json"
+	case FormatJSONL:
+		return ".jsonl"
+	}
+	return ".txt"
+}
+
+// WriteFile atomically writes an export to path
+// The data is written to a temporary file first so readers never see a partial export
+func WriteFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace export: %w", err)
+	}
+
+	return nil
+}
+
+// Post uploads an export to a URL with an HTTP POST request
+func Post(url string, data []byte, contentType string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+
+	resp, err := client.Post(url, contentType, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUploadFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s", ErrUploadFailed, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package export
+
+import (
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Filter selects a subset of results for export
+// Empty fields do not restrict the selection
+type Filter struct {
+	// Statuses limits results to the given check statuses
+	Statuses []checker.ProxyStatus `json:"statuses"`
+
+	// Types limits results to the given proxy types
+	Types []checker.ProxyType `json:"types"`
+
+	// Countries limits results to the given ISO country codes or country names
+	Countries []string `json:"countries"`
+
+	// Regions limits results to the given region codes (e.g. US-NY or NY) or region names
+	Regions []string `json:"regions"`
+
+	// Timezones limits results to time zones starting with the given prefixes (e.g. America/New_York or America/)
+	Timezones []string `json:"timezones"`
+
+	// MaxLatency limits results to proxies at most this slow (ms), 0 for no limit
+	MaxLatency int64 `json:"maxLatency"`
+
+	// AnonymousOnly limits results to proxies that do not reveal the client IP
+	AnonymousOnly bool `json:"anonymousOnly"`
+
+	// IPVersion limits results to exits using IPv4 (4) or IPv6 (6), 0 for both
+	IPVersion int `json:"ipVersion"`
+}
+
+// Match returns true if a result passes the filter
+func (f *Filter) Match(r checker.ProxyResult) bool {
+	if len(f.Statuses) > 0 {
+		matched := false
+		for _, s := range f.Statuses {
+			if strings.EqualFold(string(s), string(r.Status)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Types) > 0 {
+		matched := false
+		for _, t := range f.Types {
+			if strings.EqualFold(string(t), string(r.Type)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Countries) > 0 {
+		matched := false
+		for _, c := range f.Countries {
+			if strings.EqualFold(c, r.CountryCode) || strings.EqualFold(c, r.Country) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Regions) > 0 {
+		matched := false
+		for _, region := range f.Regions {
+			if strings.EqualFold(region, r.RegionCode) || strings.EqualFold(region, r.CountryCode+"-"+r.RegionCode) ||
+				strings.EqualFold(region, r.Region) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Timezones) > 0 {
+		matched := false
+		for _, tz := range f.Timezones {
+			if tz != "" && strings.HasPrefix(strings.ToLower(r.Timezone), strings.ToLower(tz)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if f.MaxLatency > 0 && r.Latency > f.MaxLatency {
+		return false
+	}
+
+	if f.AnonymousOnly && !r.Anonymous {
+		return false
+	}
+
+	if f.IPVersion != 0 && checker.IPVersion(r.OutgoingIP) != f.IPVersion {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the results that pass the filter
+func (f *Filter) Apply(results []checker.ProxyResult) []checker.ProxyResult {
+	filtered := make([]checker.ProxyResult, 0, len(results))
+	for _, r := range results {
+		if f.Match(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,41 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// JSONLWriter streams results as JSON lines, one line per result as soon as it completes,
+// so pipelines can process them before the run ends. It is safe for concurrent use
+type JSONLWriter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONLWriter returns a writer streaming results to w
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write writes one result as a line
+func (w *JSONLWriter) Write(result checker.ProxyResult) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
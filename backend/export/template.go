@@ -0,0 +1,180 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package export
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrInvalidTemplate = errors.New("invalid export template")
+)
+
+// Placeholders are the fields available in export templates, written as {name}
+var Placeholders = []string{"ip", "port", "type", "user", "pass", "country", "latency", "score", "provider", "expires", "city", "region", "timezone", "hostname"}
+
+// Template renders one export line per result from a text with {placeholder} fields
+type Template struct {
+	// parts alternates literal text and placeholder names, starting with literal text
+	parts []string
+}
+
+// ParseTemplate parses a line template such as "{type}://{ip}:{port}"
+func ParseTemplate(text string) (*Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("%w: empty template", ErrInvalidTemplate)
+	}
+
+	t := &Template{}
+	rest := text
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			t.parts = append(t.parts, rest)
+			break
+		}
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed placeholder in %q", ErrInvalidTemplate, text)
+		}
+
+		name := strings.ToLower(strings.TrimSpace(rest[open+1 : open+end]))
+		if !isPlaceholder(name) {
+			return nil, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidTemplate, name)
+		}
+
+		t.parts = append(t.parts, rest[:open], name)
+		rest = rest[open+end+1:]
+	}
+
+	return t, nil
+}
+
+// isPlaceholder returns true if name is a supported placeholder
+func isPlaceholder(name string) bool {
+	for _, p := range Placeholders {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Render renders the template for a result with the given quality score
+func (t *Template) Render(r checker.ProxyResult, score float64) string {
+	user, pass, ip, port := splitProxy(r.Proxy)
+
+	var b strings.Builder
+	for i, part := range t.parts {
+		if i%2 == 0 {
+			b.WriteString(part)
+			continue
+		}
+
+		switch part {
+		case "ip":
+			b.WriteString(ip)
+		case "port":
+			b.WriteString(port)
+		case "type":
+			b.WriteString(string(r.Type))
+		case "user":
+			b.WriteString(user)
+		case "pass":
+			b.WriteString(pass)
+		case "country":
+			b.WriteString(r.CountryCode)
+		case "latency":
+			b.WriteString(strconv.FormatInt(r.Latency, 10))
+		case "score":
+			b.WriteString(strconv.FormatFloat(score, 'f', 1, 64))
+		case "hostname":
+			b.WriteString(r.Hostname)
+		case "city":
+			b.WriteString(r.City)
+		case "region":
+			b.WriteString(r.Region)
+		case "timezone":
+			b.WriteString(r.Timezone)
+		case "provider":
+			if r.Metadata != nil {
+				b.WriteString(r.Metadata.Provider)
+			}
+		case "expires":
+			if r.Metadata != nil && !r.Metadata.Expires.IsZero() {
+				b.WriteString(r.Metadata.Expires.Format("2006-01-02"))
+			}
+		}
+	}
+	return b.String()
+}
+
+// splitProxy splits a [user:pass@]ip:port proxy address into its parts
+func splitProxy(proxy string) (user, pass, ip, port string) {
+	addr := checker.StripProxyAuth(proxy)
+	if creds, ok := strings.CutSuffix(proxy, "@"+addr); ok {
+		user, pass, _ = strings.Cut(creds, ":")
+	}
+
+	ip, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		ip = addr
+	}
+	return user, pass, ip, port
+}
+
+// Formatter renders exports in the built-in formats and in named line templates
+type Formatter struct {
+	// Templates maps template names to template texts
+	Templates map[string]string
+
+	// Score returns the quality score of a result for the {score} placeholder
+	Score func(checker.ProxyResult) float64
+}
+
+// Format renders results in a built-in format or a named template
+func (f *Formatter) Format(results []checker.ProxyResult, format string) ([]byte, error) {
+	text, ok := f.Templates[format]
+	if !ok || IsBuiltin(format) {
+		return Format(results, format)
+	}
+
+	t, err := ParseTemplate(text)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", format, err)
+	}
+
+	var b strings.Builder
+	for _, r := range results {
+		var score float64
+		if f.Score != nil {
+			score = f.Score(r)
+		}
+		b.WriteString(t.Render(r, score))
+		b.WriteByte('\n')
+	}
+	return []byte(b.String()), nil
+}
+
+// IsBuiltin returns true if format names a built-in export format
+func IsBuiltin(format string) bool {
+	switch format {
+	case FormatPlain, FormatWithType, FormatJSON, FormatJSONL, "":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// ExportLive writes the live proxies to the export directory in the given format and
+// returns the written files, compressed and split as configured
+func (a *App) ExportLive(format string) ([]string, error) {
+	live := a.exportSnapshot()
+	if len(live) == 0 {
+		return nil, errors.New("no live proxies to export")
+	}
+
+	paths, err := a.saveExport("live_"+time.Now().Format("20060102_150405"), live, format)
+	if err != nil {
+		return nil, err
+	}
+
+	a.emit("log", fmt.Sprintf("Exported %d live proxies to %d file(s) in %s", len(live), len(paths), a.config.ExportDir()))
+	a.recordAudit("export", fmt.Sprintf("live=%d format=%s", len(live), format), strings.Join(paths, ", "))
+	return a.exportRunLogWith(paths), nil
+}
+
+// ExportResults writes the results matching filter to the export directory in the given
+// format and returns the written files. Without statuses in the filter, the working
+// proxies are exported
+func (a *App) ExportResults(filter export.Filter, format string) ([]string, error) {
+	results := a.manager.GetResults()
+	if len(filter.Statuses) == 0 {
+		results = a.liveResults(results)
+	}
+
+	selected := a.exportable(filter.Apply(results))
+	if len(selected) == 0 {
+		return nil, errors.New("no results match the export filter")
+	}
+
+	paths, err := a.saveExport("export_"+time.Now().Format("20060102_150405"), selected, format)
+	if err != nil {
+		return nil, err
+	}
+
+	a.emit("log", fmt.Sprintf("Exported %d proxies to %d file(s) in %s", len(selected), len(paths), a.config.ExportDir()))
+	a.recordAudit("export", fmt.Sprintf("proxies=%d format=%s", len(selected), format), strings.Join(paths, ", "))
+	return a.exportRunLogWith(paths), nil
+}
+
+// saveExport writes results to the export directory with the configured export options
+func (a *App) saveExport(name string, results []checker.ProxyResult, format string) ([]string, error) {
+	cfg := a.config.GetConfig()
+	return a.formatter().Save(a.config.ExportDir(), name, results, format, export.Options{
+		Gzip:       cfg.ExportGzip,
+		ChunkLines: cfg.ExportChunkLines,
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// GetExportTemplates returns the named export line templates
+func (a *App) GetExportTemplates() map[string]string {
+	return a.config.GetConfig().ExportTemplates
+}
+
+// GetExportPlaceholders returns the placeholders available in export templates
+func (a *App) GetExportPlaceholders() []string {
+	return export.Placeholders
+}
+
+// SaveExportTemplate adds or replaces a named export line template
+func (a *App) SaveExportTemplate(name string, text string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("template name is required")
+	}
+	if export.IsBuiltin(name) {
+		return fmt.Errorf("%s is a built-in export format", name)
+	}
+	if _, err := export.ParseTemplate(text); err != nil {
+		return err
+	}
+
+	return a.config.UpdateConfig(func(c *config.Config) {
+		// Copy the map so configs returned earlier are not modified
+		templates := make(map[string]string, len(c.ExportTemplates)+1)
+		for k, v := range c.ExportTemplates {
+			templates[k] = v
+		}
+		templates[name] = text
+		c.ExportTemplates = templates
+	})
+}
+
+// DeleteExportTemplate removes a named export line template
+func (a *App) DeleteExportTemplate(name string) error {
+	return a.config.UpdateConfig(func(c *config.Config) {
+		templates := make(map[string]string, len(c.ExportTemplates))
+		for k, v := range c.ExportTemplates {
+			if k != name {
+				templates[k] = v
+			}
+		}
+		c.ExportTemplates = templates
+	})
+}
+
+// PreviewExportTemplate renders a template for the first few live proxies
+func (a *App) PreviewExportTemplate(text string) (string, error) {
+	t, err := export.ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	live := a.exportSnapshot()
+	if len(live) > 5 {
+		live = live[:5]
+	}
+
+	weights := a.scoreWeights()
+	var b strings.Builder
+	for _, r := range live {
+		b.WriteString(t.Render(r, a.scoreOf(r, weights)))
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// formatter returns an export formatter with the configured templates and score weights
+func (a *App) formatter() *export.Formatter {
+	weights := a.scoreWeights()
+	return &export.Formatter{
+		Templates: a.config.GetConfig().ExportTemplates,
+		Score: func(r checker.ProxyResult) float64 {
+			return a.scoreOf(r, weights)
+		},
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// favoritesSource is the source label of rechecked favorites
+const favoritesSource = "favorites"
+
+// GetFavorites returns the favorites pool
+func (a *App) GetFavorites() []string {
+	if a.lock.isLocked() {
+		return nil
+	}
+	return a.config.GetConfig().Favorites
+}
+
+// AddFavorites adds proxies, e.g. selected results, to the favorites pool
+func (a *App) AddFavorites(proxies []string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.config.AddFavorites(proxies)
+}
+
+// RemoveFavorites removes proxies from the favorites pool
+func (a *App) RemoveFavorites(proxies []string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.config.RemoveFavorites(proxies)
+}
+
+// ClearFavorites empties the favorites pool
+func (a *App) ClearFavorites() error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.config.UpdateConfig(func(c *config.Config) {
+		c.Favorites = []string{}
+	})
+}
+
+// RecheckFavorites starts a check of the favorites pool
+// Missing proxy type, endpoint and upstream settings use the last used ones
+func (a *App) RecheckFavorites(params CheckParams) string {
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	cfg := a.config.GetConfig()
+	if len(cfg.Favorites) == 0 {
+		return "No favorites to recheck"
+	}
+
+	params.ProxyList = append([]string(nil), cfg.Favorites...)
+	params.Sources = make(map[string]string, len(params.ProxyList))
+	for _, proxy := range params.ProxyList {
+		params.Sources[proxy] = favoritesSource
+	}
+
+	if params.ProxyType == "" {
+		params.ProxyType = string(cfg.LastProxyType)
+	}
+	if params.Endpoint == "" {
+		params.Endpoint = cfg.LastEndpoint
+	}
+	if params.UpstreamProxy == "" && cfg.LastUpstreamProxy != "" {
+		params.UpstreamProxy = cfg.LastUpstreamProxy
+		params.UpstreamType = string(cfg.LastUpstreamProxyType)
+	}
+
+	a.emit("log", fmt.Sprintf("Rechecking %d favorites", len(params.ProxyList)))
+	return a.StartCheck(params)
+}
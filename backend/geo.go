@@ -0,0 +1,70 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/geoip"
+)
+
+// geoDB returns the local GeoIP database, loading it on first use
+// Returns nil if no database is installed
+func (a *App) geoDB() *geoip.DB {
+	a.geoMux.Lock()
+	defer a.geoMux.Unlock()
+
+	path := a.config.GeoIPDatabasePath()
+	if a.geo != nil && a.geo.Path() == path {
+		return a.geo
+	}
+
+	db, err := geoip.Open(path)
+	if err != nil {
+		return nil
+	}
+
+	a.geo = db
+	return a.geo
+}
+
+// geolocate fills the location of a result from the local GeoIP database, using the
+// outgoing IP seen by the judge when known and the proxy host otherwise
+func (a *App) geolocate(r *checker.ProxyResult) {
+	db := a.geoDB()
+	if db == nil {
+		return
+	}
+
+	host := r.OutgoingIP
+	if host == "" {
+		host = checker.ProxyHost(r.Proxy)
+	}
+
+	loc := db.Locate(host)
+	if loc == nil {
+		return
+	}
+
+	r.SetGeoInfo(loc.Country, loc.CountryCode)
+	r.City = loc.City
+	r.Region = loc.Region
+	r.RegionCode = loc.RegionCode
+	r.Timezone = loc.Timezone
+	r.Latitude = loc.Latitude
+	r.Longitude = loc.Longitude
+}
+
+// countryOf returns the ISO country code of a host from the local GeoIP database
+func (a *App) countryOf(host string) string {
+	db := a.geoDB()
+	if db == nil {
+		return ""
+	}
+	return db.CountryCode(host)
+}
@@ -0,0 +1,117 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/geoip"
+)
+
+// geoUpdateCheckInterval is how often the age of the GeoIP database is checked
+const geoUpdateCheckInterval = time.Hour
+
+// GeoIPStatus describes the installed GeoIP database
+type GeoIPStatus struct {
+	Path      string    `json:"path"`
+	Installed bool      `json:"installed"`
+	Type      string    `json:"type,omitempty"`
+	BuildTime time.Time `json:"buildTime"`
+	// Updated is when the database file was last written
+	Updated time.Time `json:"updated"`
+	// AgeDays is the number of days since the database was built
+	AgeDays int `json:"ageDays"`
+	// AutoUpdate is true if a license key is configured and updates are enabled
+	AutoUpdate bool `json:"autoUpdate"`
+}
+
+// GetGeoIPStatus returns the path, type and age of the GeoIP database
+func (a *App) GetGeoIPStatus() GeoIPStatus {
+	cfg := a.config.GetConfig()
+	status := GeoIPStatus{
+		Path:       a.config.GeoIPDatabasePath(),
+		AutoUpdate: cfg.GeoIPLicenseKey != "" && cfg.GeoIPUpdateDays > 0,
+	}
+
+	db := a.geoDB()
+	if db == nil {
+		return status
+	}
+
+	status.Installed = true
+	status.Type = db.Type()
+	status.BuildTime = db.BuildTime()
+	status.AgeDays = int(time.Since(status.BuildTime).Hours() / 24)
+	if info, err := os.Stat(status.Path); err == nil {
+		status.Updated = info.ModTime()
+	}
+	return status
+}
+
+// UpdateGeoIPDatabase downloads the configured GeoLite2 edition and reloads it
+func (a *App) UpdateGeoIPDatabase() error {
+	cfg := a.config.GetConfig()
+	path := a.config.GeoIPDatabasePath()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	a.emit("log", "Downloading "+cfg.GeoIPEdition+" database")
+	if err := geoip.Download(ctx, cfg.GeoIPEdition, cfg.GeoIPLicenseKey, path); err != nil {
+		a.emit("log", fmt.Sprintf("GeoIP update failed: %v", err))
+		return err
+	}
+
+	// Drop the loaded database so the next lookup opens the new file
+	a.geoMux.Lock()
+	a.geo = nil
+	a.geoMux.Unlock()
+
+	status := a.GetGeoIPStatus()
+	a.emit("log", fmt.Sprintf("GeoIP database updated (%s, built %s)", status.Type, status.BuildTime.Format("2006-01-02")))
+	a.emit("geoip-updated", status)
+	return nil
+}
+
+// geoUpdateLoop refreshes the GeoIP database whenever it is older than the configured
+// number of days, until stop is closed
+func (a *App) geoUpdateLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(geoUpdateCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if a.geoUpdateDue() {
+			a.UpdateGeoIPDatabase()
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// geoUpdateDue returns true if automatic updates are enabled and the database is
+// missing or older than GeoIPUpdateDays
+func (a *App) geoUpdateDue() bool {
+	cfg := a.config.GetConfig()
+	if cfg.GeoIPLicenseKey == "" || cfg.GeoIPUpdateDays <= 0 {
+		return false
+	}
+
+	info, err := os.Stat(a.config.GeoIPDatabasePath())
+	if err != nil {
+		return true
+	}
+	return time.Since(info.ModTime()) > time.Duration(cfg.GeoIPUpdateDays)*24*time.Hour
+}
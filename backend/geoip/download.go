@@ -0,0 +1,131 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package geoip
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrMissingLicenseKey = errors.New("a MaxMind license key is required")
+	ErrDownloadFailed    = errors.New("GeoIP database download failed")
+)
+
+// DownloadURL is the MaxMind download endpoint for GeoLite2 databases
+var DownloadURL = "https://download.maxmind.com/app/geoip_download"
+
+// maxDatabaseSize bounds the size of a downloaded database
+const maxDatabaseSize = 512 << 20
+
+// Download fetches the latest database of an edition (e.g. GeoLite2-Country) with a
+// MaxMind license key and installs it at dest. The new file is validated before it
+// replaces the current one
+func Download(ctx context.Context, edition string, licenseKey string, dest string) error {
+	if licenseKey == "" {
+		return ErrMissingLicenseKey
+	}
+
+	query := url.Values{}
+	query.Set("edition_id", edition)
+	query.Set("license_key", licenseKey)
+	query.Set("suffix", "tar.gz")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, DownloadURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDownloadFailed, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The error includes the URL and with it the license key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%w: %v", ErrDownloadFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s %s", ErrDownloadFailed, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create GeoIP directory: %w", err)
+	}
+
+	tmp := dest + ".download"
+	defer os.Remove(tmp)
+
+	if err := extractDatabase(resp.Body, tmp); err != nil {
+		return err
+	}
+
+	// Never replace a working database with a broken one
+	if _, err := Open(tmp); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, dest); err != nil {
+		return fmt.Errorf("failed to install GeoIP database: %w", err)
+	}
+	return nil
+}
+
+// extractDatabase writes the .mmdb file of a MaxMind tar.gz archive to path
+func extractDatabase(r io.Reader, path string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDownloadFailed, err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: no database in archive", ErrDownloadFailed)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrDownloadFailed, err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".mmdb") {
+			continue
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to write GeoIP database: %w", err)
+		}
+
+		n, err := io.Copy(file, io.LimitReader(archive, maxDatabaseSize+1))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write GeoIP database: %w", err)
+		}
+		if n > maxDatabaseSize {
+			return fmt.Errorf("%w: database too large", ErrDownloadFailed)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Location represents the geolocation of an IP address
+type Location struct {
+	// Country is the English country name
+	Country string `json:"country"`
+
+	// CountryCode is the ISO 3166-1 alpha-2 country code
+	CountryCode string `json:"countryCode"`
+
+	// City, Region and the coordinates are only available in city databases
+	City       string  `json:"city,omitempty"`
+	Region     string  `json:"region,omitempty"`
+	RegionCode string  `json:"regionCode,omitempty"`
+	Latitude   float64 `json:"latitude,omitempty"`
+	Longitude  float64 `json:"longitude,omitempty"`
+
+	// Timezone is the IANA time zone of the location (e.g. America/New_York)
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// DB is a local GeoIP database in MaxMind DB format (e.g. GeoLite2-Country.mmdb)
+type DB struct {
+	path   string
+	reader *reader
+}
+
+// Open loads a MaxMind database from disk
+func Open(path string) (*DB, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GeoIP database: %w", err)
+	}
+
+	r, err := newReader(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{path: path, reader: r}, nil
+}
+
+// Path returns the file the database was loaded from
+func (db *DB) Path() string {
+	return db.path
+}
+
+// Type returns the database type from the metadata (e.g. GeoLite2-Country)
+func (db *DB) Type() string {
+	return db.reader.databaseType
+}
+
+// BuildTime returns when the database was built
+func (db *DB) BuildTime() time.Time {
+	return time.Unix(int64(db.reader.buildEpoch), 0)
+}
+
+// Lookup returns the location of an IP address
+func (db *DB) Lookup(ip net.IP) (*Location, error) {
+	value, err := db.reader.lookup(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	loc := &Location{}
+
+	country := child(record, "country")
+	if country == nil {
+		country = child(record, "registered_country")
+	}
+	if country != nil {
+		loc.CountryCode, _ = country["iso_code"].(string)
+		loc.Country = englishName(country)
+	}
+
+	if city := child(record, "city"); city != nil {
+		loc.City = englishName(city)
+	}
+
+	// The first subdivision is the largest, e.g. the state
+	if subdivisions, ok := record["subdivisions"].([]interface{}); ok && len(subdivisions) > 0 {
+		if region, ok := subdivisions[0].(map[string]interface{}); ok {
+			loc.Region = englishName(region)
+			loc.RegionCode, _ = region["iso_code"].(string)
+		}
+	}
+
+	if location := child(record, "location"); location != nil {
+		loc.Latitude, _ = location["latitude"].(float64)
+		loc.Longitude, _ = location["longitude"].(float64)
+		loc.Timezone, _ = location["time_zone"].(string)
+	}
+
+	return loc, nil
+}
+
+// Locate returns the location of a host, or nil if it is not an IP address or not found
+func (db *DB) Locate(host string) *Location {
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip == nil {
+		return nil
+	}
+
+	loc, err := db.Lookup(ip)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// CountryCode returns the ISO country code of a host, or an empty string if unknown
+func (db *DB) CountryCode(host string) string {
+	loc := db.Locate(host)
+	if loc == nil {
+		return ""
+	}
+	return loc.CountryCode
+}
+
+// child returns a nested map field of a decoded record
+func child(record map[string]interface{}, key string) map[string]interface{} {
+	m, _ := record[key].(map[string]interface{})
+	return m
+}
+
+// englishName returns the English entry of a record's names map
+func englishName(record map[string]interface{}) string {
+	name, _ := child(record, "names")["en"].(string)
+	return name
+}
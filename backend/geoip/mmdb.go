@@ -0,0 +1,365 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+)
+
+var (
+	ErrInvalidDatabase = errors.New("invalid MaxMind database")
+	ErrNotFound        = errors.New("address not found in database")
+)
+
+// metadataMarker precedes the metadata section at the end of an MMDB file
+var metadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// Data section field types as defined by the MaxMind DB format
+const (
+	typeExtended = 0
+	typePointer  = 1
+	typeString   = 2
+	typeDouble   = 3
+	typeBytes    = 4
+	typeUint16   = 5
+	typeUint32   = 6
+	typeMap      = 7
+	typeInt32    = 8
+	typeUint64   = 9
+	typeUint128  = 10
+	typeArray    = 11
+	typeBool     = 14
+	typeFloat    = 15
+)
+
+// maxDecodeDepth limits the nesting of maps and arrays, as libmaxminddb does
+const maxDecodeDepth = 512
+
+// maxDecodeValues limits the number of values decoded for one record. Pointers let a
+// small crafted file reference the same large map many times, and every reference is
+// decoded again; real records hold a few hundred values at most
+const maxDecodeValues = 1 << 16
+
+// reader is a minimal MaxMind DB (MMDB) reader working on an in-memory file
+type reader struct {
+	buf          []byte
+	data         []byte
+	nodeCount    uint
+	recordSize   uint
+	ipVersion    uint
+	databaseType string
+	buildEpoch   uint64
+	ipv4Start    uint
+}
+
+// newReader parses the metadata and prepares the search tree of an MMDB file
+func newReader(buf []byte) (*reader, error) {
+	start := bytes.LastIndex(buf, metadataMarker)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: metadata not found", ErrInvalidDatabase)
+	}
+
+	metaBuf := buf[start+len(metadataMarker):]
+	meta, _, err := (&reader{data: metaBuf}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDatabase, err)
+	}
+
+	metaMap, ok := meta.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed metadata", ErrInvalidDatabase)
+	}
+
+	r := &reader{buf: buf}
+	r.nodeCount = uint(toUint(metaMap["node_count"]))
+	r.recordSize = uint(toUint(metaMap["record_size"]))
+	r.ipVersion = uint(toUint(metaMap["ip_version"]))
+	r.buildEpoch = toUint(metaMap["build_epoch"])
+	r.databaseType, _ = metaMap["database_type"].(string)
+
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, r.recordSize)
+	}
+
+	treeSize := r.nodeCount * r.recordSize / 4
+	if treeSize+16 > uint(start) {
+		return nil, fmt.Errorf("%w: truncated search tree", ErrInvalidDatabase)
+	}
+	r.data = buf[treeSize+16 : start]
+
+	// IPv4 addresses live under ::/96 in IPv6 databases
+	if r.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < r.nodeCount; i++ {
+			node = r.readNode(node, 0)
+		}
+		r.ipv4Start = node
+	}
+
+	return r, nil
+}
+
+// lookup finds the data record for an IP address
+func (r *reader) lookup(ip net.IP) (interface{}, error) {
+	node := uint(0)
+	bitCount := 128
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		bitCount = 32
+		if r.ipVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else if r.ipVersion == 4 {
+		return nil, ErrNotFound
+	}
+
+	for i := 0; i < bitCount && node < r.nodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-uint(i%8))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	if node <= r.nodeCount {
+		return nil, ErrNotFound
+	}
+
+	offset := node - r.nodeCount - 16
+	if offset >= uint(len(r.data)) {
+		return nil, fmt.Errorf("%w: record pointer out of range", ErrInvalidDatabase)
+	}
+
+	value, _, err := r.decode(offset)
+	return value, err
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of a search tree node
+func (r *reader) readNode(node uint, bit uint) uint {
+	switch r.recordSize {
+	case 24:
+		off := node*6 + bit*3
+		b := r.buf[off : off+3]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		off := node * 7
+		b := r.buf[off : off+7]
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		off := node*8 + bit*4
+		return uint(binary.BigEndian.Uint32(r.buf[off : off+4]))
+	}
+}
+
+// decode decodes the value at offset in the data section and returns the offset after it
+func (r *reader) decode(offset uint) (interface{}, uint, error) {
+	budget := maxDecodeValues
+	return r.decodeValue(offset, 0, false, &budget)
+}
+
+// decodeValue decodes the value at offset, depth containers deep; a pointer may not
+// point at another pointer, so a crafted file cannot make the reader loop. Every value
+// decoded uses up one of budget
+func (r *reader) decodeValue(offset uint, depth int, viaPointer bool, budget *int) (interface{}, uint, error) {
+	if depth > maxDecodeDepth {
+		return nil, 0, errors.New("data nested too deeply")
+	}
+	if *budget <= 0 {
+		return nil, 0, errors.New("record too large")
+	}
+	*budget--
+	if offset >= uint(len(r.data)) {
+		return nil, 0, errors.New("data offset out of range")
+	}
+
+	ctrl := r.data[offset]
+	offset++
+	kind := uint(ctrl >> 5)
+
+	if kind == typePointer {
+		if viaPointer {
+			return nil, 0, errors.New("pointer to a pointer")
+		}
+		pointer, next, err := r.decodePointer(ctrl, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, _, err := r.decodeValue(pointer, depth, true, budget)
+		return value, next, err
+	}
+
+	if kind == typeExtended {
+		if offset >= uint(len(r.data)) {
+			return nil, 0, errors.New("truncated extended type")
+		}
+		kind = 7 + uint(r.data[offset])
+		offset++
+	}
+
+	size, offset, err := r.decodeSize(ctrl, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Every map entry takes at least two bytes and every array element one, so a
+	// size beyond what is left of the data section is corrupt
+	remaining := uint(len(r.data)) - offset
+
+	switch kind {
+	case typeMap:
+		if size > remaining/2 {
+			return nil, 0, errors.New("map exceeds data section")
+		}
+		m := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			key, next, err := r.decodeValue(offset, depth+1, false, budget)
+			if err != nil {
+				return nil, 0, err
+			}
+			value, next, err := r.decodeValue(next, depth+1, false, budget)
+			if err != nil {
+				return nil, 0, err
+			}
+			keyStr, _ := key.(string)
+			m[keyStr] = value
+			offset = next
+		}
+		return m, offset, nil
+
+	case typeArray:
+		if size > remaining {
+			return nil, 0, errors.New("array exceeds data section")
+		}
+		a := make([]interface{}, 0, size)
+		for i := uint(0); i < size; i++ {
+			value, next, err := r.decodeValue(offset, depth+1, false, budget)
+			if err != nil {
+				return nil, 0, err
+			}
+			a = append(a, value)
+			offset = next
+		}
+		return a, offset, nil
+
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(r.data)) {
+		return nil, 0, errors.New("value exceeds data section")
+	}
+	raw := r.data[offset : offset+size]
+	next := offset + size
+
+	switch kind {
+	case typeString:
+		return string(raw), next, nil
+	case typeBytes:
+		return append([]byte(nil), raw...), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.New("invalid double size")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.New("invalid float size")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(raw))), next, nil
+	case typeUint16, typeUint32, typeUint64, typeUint128:
+		var v uint64
+		for _, b := range raw {
+			v = v<<8 | uint64(b)
+		}
+		return v, next, nil
+	case typeInt32:
+		var v uint32
+		for _, b := range raw {
+			v = v<<8 | uint32(b)
+		}
+		return int64(int32(v)), next, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported data type %d", kind)
+	}
+}
+
+// decodePointer resolves a pointer control byte into a data section offset
+func (r *reader) decodePointer(ctrl byte, offset uint) (uint, uint, error) {
+	size := uint((ctrl >> 3) & 0x3)
+	if offset+size+1 > uint(len(r.data)) {
+		return 0, 0, errors.New("truncated pointer")
+	}
+
+	b := r.data[offset : offset+size+1]
+	prefix := uint(ctrl & 0x7)
+
+	var pointer uint
+	switch size {
+	case 0:
+		pointer = prefix<<8 | uint(b[0])
+	case 1:
+		pointer = (prefix<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 2:
+		pointer = (prefix<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		pointer = uint(binary.BigEndian.Uint32(b))
+	}
+	if pointer >= uint(len(r.data)) {
+		return 0, 0, errors.New("pointer outside data section")
+	}
+
+	return pointer, offset + size + 1, nil
+}
+
+// decodeSize reads the payload size encoded in a control byte and its extension bytes
+func (r *reader) decodeSize(ctrl byte, offset uint) (uint, uint, error) {
+	size := uint(ctrl & 0x1f)
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	extra := size - 28
+	if offset+extra > uint(len(r.data)) {
+		return 0, 0, errors.New("truncated size")
+	}
+
+	var v uint
+	for _, b := range r.data[offset : offset+extra] {
+		v = v<<8 | uint(b)
+	}
+
+	switch size {
+	case 29:
+		return 29 + v, offset + extra, nil
+	case 30:
+		return 285 + v, offset + extra, nil
+	default:
+		return 65821 + v, offset + extra, nil
+	}
+}
+
+// toUint converts a decoded numeric value to uint64
+func toUint(v interface{}) uint64 {
+	switch n := v.(type) {
+	case uint64:
+		return n
+	case int64:
+		return uint64(n)
+	case float64:
+		return uint64(n)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+)
+
+// healthFailureThreshold is the number of failed health checks in a row that pause a run,
+// so a single lost request does not interrupt it
+const healthFailureThreshold = 2
+
+// watchHealth requests the endpoint of the main run directly every interval while the run
+// is in progress. When the endpoint (and every fallback the run may fail over to) or the
+// local network is down, the run is paused instead of recording every remaining proxy as
+// dead, and resumed once it is back if configured
+func (a *App) watchHealth(generation int64, req checker.ProxyCheckRequest) {
+	cfg := a.config.GetConfig()
+	if cfg.HealthCheckInterval <= 0 {
+		return
+	}
+
+	dialer := monitorDialer(req.SourceAddress)
+	endpoints := diagnosticEndpoints(req.Endpoint, req.FallbackEndpoints)
+	endpoint := req.Endpoint
+
+	ticker := time.NewTicker(time.Duration(cfg.HealthCheckInterval) * time.Second)
+	defer ticker.Stop()
+
+	failures := 0
+	paused := false
+	for range ticker.C {
+		// A newer run has its own monitor
+		if a.monitorGen.Load() != generation || !a.manager.IsRunning() {
+			return
+		}
+
+		alert := event.HealthAlert{Endpoint: endpoint}
+		if check := diagnoseConnectivity(dialer); !check.OK {
+			alert.Network = true
+			alert.Error = check.Error
+		} else {
+			alert.Healthy, alert.Error = anyEndpointUp(dialer, endpoints)
+		}
+
+		if !alert.Healthy {
+			failures++
+			if failures < healthFailureThreshold || paused || a.manager.IsPaused() {
+				continue
+			}
+
+			paused = a.manager.Pause()
+			alert.Paused = paused
+			if paused {
+				a.setRunState(MainRunID, event.StatePaused)
+			}
+			a.emit("log", fmt.Sprintf("Health check failed %d times in a row (%s), check paused", failures, healthProblem(alert)))
+			a.emitTyped(event.NameHealthAlert, MainRunID, alert)
+			continue
+		}
+
+		failures = 0
+		if !paused {
+			continue
+		}
+
+		paused = false
+		if a.config.GetConfig().HealthAutoResume && a.manager.IsPaused() && a.manager.Resume() {
+			alert.Resumed = true
+			a.setRunState(MainRunID, event.StateRunning)
+			a.emit("log", "Endpoint "+endpoint+" is reachable again, check resumed")
+		} else {
+			a.emit("log", "Endpoint "+endpoint+" is reachable again, resume the check to go on")
+		}
+		a.emitTyped(event.NameHealthAlert, MainRunID, alert)
+	}
+}
+
+// anyEndpointUp returns true if one of the endpoints answers, or else the error of the first
+func anyEndpointUp(dialer *net.Dialer, endpoints []string) (bool, string) {
+	var first string
+	for _, endpoint := range endpoints {
+		check := diagnoseEndpoint(dialer, endpoint)
+		if check.OK {
+			return true, ""
+		}
+		if first == "" {
+			first = check.Error
+		}
+	}
+	return false, first
+}
+
+// healthProblem describes a failed health check
+func healthProblem(alert event.HealthAlert) string {
+	if alert.Network {
+		return "local network is down: " + alert.Error
+	}
+	return "endpoint " + alert.Endpoint + " is down: " + alert.Error
+}
@@ -0,0 +1,39 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// SetSOCKS4Ident sets the userid sent to a SOCKS4 proxy, overriding the global ident;
+// an empty ident removes the override
+func (a *App) SetSOCKS4Ident(proxy string, ident string) error {
+	proxy = checker.StripProxyAuth(strings.TrimSpace(proxy))
+	if proxy == "" {
+		return checker.ErrInvalidProxyFormat
+	}
+
+	return a.config.UpdateConfig(func(c *config.Config) {
+		// Copy the map so configs returned earlier are not modified
+		idents := make(map[string]string, len(c.SOCKS4Idents)+1)
+		for k, v := range c.SOCKS4Idents {
+			idents[k] = v
+		}
+		if ident == "" {
+			delete(idents, proxy)
+		} else {
+			idents[proxy] = ident
+		}
+		c.SOCKS4Idents = idents
+	})
+}
@@ -0,0 +1,77 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+)
+
+// ImportFromFile imports a proxy list from a local file, tagging each proxy with the file name
+func (a *App) ImportFromFile(path string) (*importer.List, error) {
+	list, err := importer.FromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logImport(list)
+	return list, nil
+}
+
+// ImportFromURL imports a proxy list from a URL, tagging each proxy with the URL host
+func (a *App) ImportFromURL(url string) (*importer.List, error) {
+	list, err := importer.FromURL(url, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logImport(list)
+	return list, nil
+}
+
+// ImportFromText imports a pasted proxy list, tagging each proxy with the given source label
+func (a *App) ImportFromText(text string, source string) (*importer.List, error) {
+	list, err := importer.FromText(text, source)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logImport(list)
+	return list, nil
+}
+
+// ExpandImportRanges expands the address ranges an import held back for confirmation,
+// e.g. 1.2.3.0/16:8080, into individual proxies
+func (a *App) ExpandImportRanges(list *importer.List) (*importer.List, error) {
+	if list == nil {
+		return nil, importer.ErrEmptyList
+	}
+	before := len(list.Entries)
+	if err := list.ExpandRanges(); err != nil {
+		return nil, err
+	}
+
+	a.emit("log", fmt.Sprintf("Expanded address ranges into %d proxies", len(list.Entries)-before))
+	return list, nil
+}
+
+// logImport emits a log line summarizing an import
+func (a *App) logImport(list *importer.List) {
+	a.emit("log", fmt.Sprintf("Imported %d proxies (%d duplicates, %d invalid lines skipped)",
+		len(list.Entries), list.Duplicates, list.Invalid))
+	if len(list.Ranges) > 0 {
+		targets := 0
+		for _, r := range list.Ranges {
+			targets += r.Targets
+		}
+		a.emit("log", fmt.Sprintf("%d address ranges (%d targets) need confirmation before they are expanded", len(list.Ranges), targets))
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+)
+
+// ImportResults loads a previously exported JSON or CSV result file into the results view
+// Results keep their original timestamps, so they can be rechecked or compared
+func (a *App) ImportResults(path string) string {
+	results, err := importer.ResultsFromFile(path)
+	if err != nil {
+		return "Import failed: " + err.Error()
+	}
+	if len(results) == 0 {
+		return "No results in " + path
+	}
+
+	params := CheckParams{
+		ProxyList: make([]string, len(results)),
+		Sources:   make(map[string]string, len(results)),
+	}
+	for i, r := range results {
+		params.ProxyList[i] = r.Proxy
+		if r.Source != "" {
+			params.Sources[r.Proxy] = r.Source
+		}
+	}
+
+	if !a.manager.PrepareExternalRun(toCheckRequest(params)) {
+		return "Check already in progress"
+	}
+
+	a.resultsMux.Lock()
+	a.results = make([]ProxyResult, 0, len(results))
+	a.lastParams = params
+	a.resultsMux.Unlock()
+
+	a.manager.AppendResults(results)
+	a.updateResults()
+	a.updateStats()
+
+	a.emit("log", fmt.Sprintf("Loaded %d results from %s", len(results), path))
+	return fmt.Sprintf("Loaded %d results", len(results))
+}
@@ -0,0 +1,120 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	ErrNoListInArchive = errors.New("no proxy list found in archive")
+	ErrArchiveTooLarge = errors.New("archive too large")
+)
+
+// maxRemoteZipSize bounds zip archives fetched from a URL, which must be buffered in memory
+const maxRemoteZipSize = 256 << 20
+
+// listExtensions are the file extensions treated as proxy lists inside a zip archive
+var listExtensions = map[string]bool{"": true, ".txt": true, ".list": true, ".lst": true, ".csv": true}
+
+// archiveKind returns ".gz" or ".zip" for compressed list names, or an empty string
+func archiveKind(name string) string {
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".gz", ".zip":
+		return ext
+	default:
+		return ""
+	}
+}
+
+// eachList calls fn for every proxy list in r, named name, transparently decompressing
+// gzip files and walking the text files of zip archives. fn receives the list and its
+// label: the file name, or "archive.zip/entry.txt" for zip entries
+func eachList(name string, r io.Reader, fn func(list io.Reader, label string) error) error {
+	base := path.Base(strings.ReplaceAll(name, `\`, "/"))
+
+	switch archiveKind(name) {
+	case ".gz":
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("invalid gzip file: %w", err)
+		}
+		defer gz.Close()
+		return fn(gz, base)
+
+	case ".zip":
+		zr, err := openZip(r)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() || !listExtensions[strings.ToLower(path.Ext(f.Name))] {
+				continue
+			}
+
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open %s in archive: %w", f.Name, err)
+			}
+			err = fn(rc, base+"/"+f.Name)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+			found = true
+		}
+
+		if !found {
+			return ErrNoListInArchive
+		}
+		return nil
+
+	default:
+		return fn(r, base)
+	}
+}
+
+// openZip opens a zip archive from a file, or buffers it in memory for other readers
+func openZip(r io.Reader) (*zip.Reader, error) {
+	var ra io.ReaderAt
+	var size int64
+
+	if f, ok := r.(*os.File); ok {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		ra, size = f, info.Size()
+	} else {
+		data, err := io.ReadAll(io.LimitReader(r, maxRemoteZipSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if len(data) > maxRemoteZipSize {
+			return nil, fmt.Errorf("%w: more than %d MB", ErrArchiveTooLarge, maxRemoteZipSize>>20)
+		}
+		ra, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+	return zr, nil
+}
@@ -0,0 +1,373 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package importer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Caps on the targets a range line such as 1.2.3.0/24:8080, 1.2.3.4:8000-8100 or
+// proxy[01-20].example.com:3128 expands to
+const (
+	// ConfirmExpansion is the size above which a range is held back until the user confirms it
+	ConfirmExpansion = 1024
+
+	// MaxExpansion is the most targets the ranges of one import may expand to
+	MaxExpansion = 65536
+)
+
+var (
+	ErrInvalidRange      = errors.New("invalid address range")
+	ErrExpansionTooLarge = fmt.Errorf("ranges expand to more than %d targets", MaxExpansion)
+)
+
+// Range is a line of a list describing several targets, held back until confirmed
+type Range struct {
+	// Pattern is the line as written, e.g. 1.2.3.0/24:8080
+	Pattern string `json:"pattern"`
+
+	// Source is the label of the list the line came from
+	Source string `json:"source"`
+
+	// Targets is the number of targets the pattern expands to
+	Targets int `json:"targets"`
+}
+
+// hostSet is the host part of a range
+type hostSet interface {
+	// count returns the number of hosts, saturating at MaxExpansion+1
+	count() int
+	// each calls fn for every host until it returns false
+	each(fn func(host string) bool) bool
+}
+
+// targetRange is a parsed range line
+type targetRange struct {
+	auth      string // credentials prefix, including the '@'
+	hosts     hostSet
+	firstPort int
+	lastPort  int
+}
+
+// count returns the number of targets, saturating at MaxExpansion+1
+func (r *targetRange) count() int {
+	n := r.hosts.count() * (r.lastPort - r.firstPort + 1)
+	if n > MaxExpansion || n < 0 {
+		return MaxExpansion + 1
+	}
+	return n
+}
+
+// each calls fn for every target until it returns false
+func (r *targetRange) each(fn func(target string) bool) bool {
+	return r.hosts.each(func(host string) bool {
+		for port := r.firstPort; port <= r.lastPort; port++ {
+			if !fn(r.auth + net.JoinHostPort(host, strconv.Itoa(port))) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// parseRange parses a range line; ok is false for lines naming a single target
+func parseRange(line string) (r *targetRange, ok bool, err error) {
+	auth := ""
+	if i := strings.LastIndex(line, "@"); i >= 0 {
+		auth, line = line[:i+1], line[i+1:]
+	}
+	host, port, splitErr := splitPattern(line)
+	if splitErr != nil {
+		return nil, false, nil
+	}
+
+	hosts, isCIDR, err := parseCIDR(host)
+	if err != nil {
+		return nil, true, err
+	}
+	if !isCIDR {
+		hosts, isCIDR, err = parseHostPattern(host)
+		if err != nil {
+			return nil, true, err
+		}
+	}
+	isPortRange := strings.Contains(port, "-")
+	if !isCIDR && !isPortRange {
+		return nil, false, nil
+	}
+	if hosts == nil {
+		hosts = singleHost(host)
+	}
+
+	r = &targetRange{auth: auth, hosts: hosts}
+	if r.firstPort, r.lastPort, err = parsePorts(port); err != nil {
+		return nil, true, err
+	}
+	return r, true, nil
+}
+
+// splitPattern splits a host:port line whose host may be a pattern such as proxy[01-20].example.com
+func splitPattern(line string) (host string, port string, err error) {
+	if strings.HasPrefix(line, "[") || !strings.Contains(line, "[") {
+		return net.SplitHostPort(line)
+	}
+	i := strings.LastIndex(line, ":")
+	if i < 0 {
+		return "", "", ErrInvalidRange
+	}
+	return line[:i], line[i+1:], nil
+}
+
+// parsePorts parses a port or a first-last port range
+func parsePorts(s string) (first int, last int, err error) {
+	lo, hi, isRange := strings.Cut(s, "-")
+	first, err = strconv.Atoi(strings.TrimSpace(lo))
+	if err != nil || first < 1 || first > 65535 {
+		return 0, 0, fmt.Errorf("%w: bad port %q", ErrInvalidRange, lo)
+	}
+	if !isRange {
+		return first, first, nil
+	}
+	last, err = strconv.Atoi(strings.TrimSpace(hi))
+	if err != nil || last < first || last > 65535 {
+		return 0, 0, fmt.Errorf("%w: bad port range %q", ErrInvalidRange, s)
+	}
+	return first, last, nil
+}
+
+// singleHost is a host set of one host
+type singleHost string
+
+func (h singleHost) count() int { return 1 }
+
+func (h singleHost) each(fn func(host string) bool) bool { return fn(string(h)) }
+
+// cidrHosts is the host set of an IPv4 network
+// The network and broadcast addresses are skipped, except in /31 and /32 networks
+type cidrHosts struct {
+	first uint32
+	last  uint32
+}
+
+// parseCIDR parses an IPv4 network; isCIDR is false if host is not written as one
+func parseCIDR(host string) (hosts hostSet, isCIDR bool, err error) {
+	if !strings.Contains(host, "/") {
+		return nil, false, nil
+	}
+	ip, network, err := net.ParseCIDR(host)
+	if err != nil || ip.To4() == nil {
+		return nil, true, fmt.Errorf("%w: %q is not an IPv4 network", ErrInvalidRange, host)
+	}
+
+	ones, _ := network.Mask.Size()
+	first := binary.BigEndian.Uint32(network.IP.To4())
+	last := first | (1<<(32-ones) - 1)
+	if ones < 31 {
+		first++
+		last--
+	}
+	return cidrHosts{first: first, last: last}, true, nil
+}
+
+func (c cidrHosts) count() int {
+	n := uint64(c.last) - uint64(c.first) + 1
+	if n > MaxExpansion {
+		return MaxExpansion + 1
+	}
+	return int(n)
+}
+
+func (c cidrHosts) each(fn func(host string) bool) bool {
+	ip := make(net.IP, 4)
+	for n := uint64(c.first); n <= uint64(c.last); n++ {
+		binary.BigEndian.PutUint32(ip, uint32(n))
+		if !fn(ip.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+// patternHosts is the host set of a hostname pattern, the product of its parts
+// Each part lists its alternatives; literal text is a part with a single alternative
+type patternHosts [][]string
+
+// parseHostPattern parses a hostname with bracketed parts, either numeric ranges keeping
+// their zero padding (proxy[01-20].example.com) or lists (gw-[us,uk,de].example.com);
+// isPattern is false if host has no brackets
+func parseHostPattern(host string) (hosts hostSet, isPattern bool, err error) {
+	if !strings.Contains(host, "[") {
+		return nil, false, nil
+	}
+
+	if strings.Count(host, "[") != strings.Count(host, "]") {
+		return nil, true, fmt.Errorf("%w: unbalanced brackets in %q", ErrInvalidRange, host)
+	}
+
+	// Each part may expand to at most what the earlier parts leave of MaxExpansion, so
+	// a pattern over the limit is refused before its alternatives are built
+	var parts patternHosts
+	product := 1
+	rest := host
+	for rest != "" {
+		open := strings.Index(rest, "[")
+		if open < 0 {
+			parts = append(parts, []string{rest})
+			break
+		}
+		if open > 0 {
+			parts = append(parts, []string{rest[:open]})
+		}
+		end := strings.Index(rest[open:], "]")
+		if end < 0 {
+			return nil, true, fmt.Errorf("%w: unbalanced brackets in %q", ErrInvalidRange, host)
+		}
+		alternatives, err := parseAlternatives(rest[open+1:open+end], MaxExpansion/product)
+		if err != nil {
+			return nil, true, fmt.Errorf("%w in %q", err, host)
+		}
+		product *= len(alternatives)
+		parts = append(parts, alternatives)
+		rest = rest[open+end+1:]
+	}
+	return parts, true, nil
+}
+
+// parseAlternatives parses the inside of a bracketed part: a numeric range or a list of
+// at most limit alternatives
+func parseAlternatives(s string, limit int) ([]string, error) {
+	if lo, hi, isRange := strings.Cut(s, "-"); isRange && !strings.Contains(s, ",") {
+		first, err1 := strconv.Atoi(lo)
+		last, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil || first < 0 || last < first {
+			return nil, fmt.Errorf("%w: bad range [%s]", ErrInvalidRange, s)
+		}
+		if last-first >= limit {
+			return nil, fmt.Errorf("%w: range [%s]", ErrExpansionTooLarge, s)
+		}
+		width := 0
+		if len(lo) > 1 && lo[0] == '0' {
+			width = len(lo)
+		}
+		alternatives := make([]string, 0, last-first+1)
+		for n := first; n <= last; n++ {
+			alternatives = append(alternatives, fmt.Sprintf("%0*d", width, n))
+		}
+		return alternatives, nil
+	}
+
+	var alternatives []string
+	for _, alt := range strings.Split(s, ",") {
+		if alt = strings.TrimSpace(alt); alt != "" {
+			alternatives = append(alternatives, alt)
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil, fmt.Errorf("%w: empty brackets", ErrInvalidRange)
+	}
+	if len(alternatives) > limit {
+		return nil, fmt.Errorf("%w: list [%s]", ErrExpansionTooLarge, s)
+	}
+	return alternatives, nil
+}
+
+func (p patternHosts) count() int {
+	n := 1
+	for _, alternatives := range p {
+		n *= len(alternatives)
+		if n > MaxExpansion {
+			return MaxExpansion + 1
+		}
+	}
+	return n
+}
+
+func (p patternHosts) each(fn func(host string) bool) bool {
+	return p.expand("", fn)
+}
+
+// expand calls fn for every host starting with prefix and followed by the remaining parts
+func (p patternHosts) expand(prefix string, fn func(host string) bool) bool {
+	if len(p) == 0 {
+		return fn(prefix)
+	}
+	for _, alt := range p[0] {
+		if !p[1:].expand(prefix+alt, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsRange returns true if a list line describes several targets
+func IsRange(line string) bool {
+	line, _ = parseLine(line)
+	_, ok, _ := parseRange(line)
+	return ok
+}
+
+// Expand returns the targets a range line expands to, or the line itself if it names a
+// single target. It fails if the range is malformed or expands to more than limit targets
+func Expand(line string, limit int) ([]string, error) {
+	r, ok, err := parseRange(line)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return []string{line}, nil
+	}
+	if n := r.count(); n > limit {
+		return nil, fmt.Errorf("%w: %s expands to more than %d targets", ErrExpansionTooLarge, line, limit)
+	}
+
+	targets := make([]string, 0, r.count())
+	r.each(func(target string) bool {
+		targets = append(targets, target)
+		return true
+	})
+	return targets, nil
+}
+
+// ExpandRanges expands the ranges held back by an import into entries, once the user
+// confirmed them. Targets already in the list are skipped
+func (l *List) ExpandRanges() error {
+	total := 0
+	for _, r := range l.Ranges {
+		total += r.Targets
+	}
+	if total > MaxExpansion {
+		return ErrExpansionTooLarge
+	}
+
+	seen := make(map[string]bool, len(l.Entries)+total)
+	for _, e := range l.Entries {
+		seen[e.Proxy] = true
+	}
+	for _, r := range l.Ranges {
+		targets, err := Expand(r.Pattern, MaxExpansion)
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			if seen[target] {
+				l.Duplicates++
+				continue
+			}
+			seen[target] = true
+			l.Entries = append(l.Entries, Entry{Proxy: target, Source: r.Source})
+		}
+	}
+	l.Ranges = nil
+	return nil
+}
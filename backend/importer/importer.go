@@ -0,0 +1,346 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrEmptyList     = errors.New("no proxies found in source")
+	ErrBadHTTPStatus = errors.New("unexpected HTTP status while fetching list")
+	ErrNotModified   = errors.New("list not modified since the last fetch")
+)
+
+// Validators are the cache validators of a fetched list, sent back to only download it
+// again when it changed
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Entry is a single proxy line together with the source it came from
+type Entry struct {
+	// Proxy is the proxy address as written in the source (ip:port)
+	Proxy string `json:"proxy"`
+
+	// Source is a label describing where the proxy was imported from
+	Source string `json:"source"`
+
+	// Metadata is the purchase information given in extra columns of the line, if any
+	Metadata *checker.Metadata `json:"metadata,omitempty"`
+}
+
+// List is the result of importing one or more sources
+type List struct {
+	// Entries holds the imported proxies in their original order
+	Entries []Entry `json:"entries"`
+
+	// Duplicates is the number of lines skipped because they were already imported
+	Duplicates int `json:"duplicates"`
+
+	// Invalid is the number of lines skipped because they were not proxies
+	Invalid int `json:"invalid"`
+
+	// Ranges are the range lines too large to expand without confirmation, see ExpandRanges
+	Ranges []Range `json:"ranges,omitempty"`
+}
+
+// Proxies returns the proxy addresses of the list
+func (l *List) Proxies() []string {
+	proxies := make([]string, len(l.Entries))
+	for i, e := range l.Entries {
+		proxies[i] = e.Proxy
+	}
+	return proxies
+}
+
+// Sources returns a proxy -> source mapping of the list
+func (l *List) Sources() map[string]string {
+	sources := make(map[string]string, len(l.Entries))
+	for _, e := range l.Entries {
+		sources[e.Proxy] = e.Source
+	}
+	return sources
+}
+
+// Metadata returns a proxy -> metadata mapping of the entries that have metadata
+func (l *List) Metadata() map[string]checker.Metadata {
+	metadata := make(map[string]checker.Metadata)
+	for _, e := range l.Entries {
+		if e.Metadata != nil {
+			metadata[e.Proxy] = *e.Metadata
+		}
+	}
+	return metadata
+}
+
+// Merge appends the entries of other to the list, skipping proxies already present
+func (l *List) Merge(other *List) {
+	seen := make(map[string]bool, len(l.Entries))
+	for _, e := range l.Entries {
+		seen[e.Proxy] = true
+	}
+
+	for _, e := range other.Entries {
+		if seen[e.Proxy] {
+			l.Duplicates++
+			continue
+		}
+		seen[e.Proxy] = true
+		l.Entries = append(l.Entries, e)
+	}
+
+	l.Duplicates += other.Duplicates
+	l.Invalid += other.Invalid
+	l.Ranges = append(l.Ranges, other.Ranges...)
+}
+
+// Parse reads a proxy list from r, one proxy per line, tagging each entry with source
+// Blank lines and lines starting with '#' are ignored. A line may carry extra columns
+// separated by commas, semicolons or tabs: provider, purchase date and expiry date
+// Range lines are expanded into their targets, or held back in Ranges if they are larger
+// than ConfirmExpansion
+func Parse(r io.Reader, source string) (*List, error) {
+	list := &List{}
+	seen := make(map[string]bool)
+	expanded := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line, valid := parseLine(raw)
+		if line == "" {
+			continue
+		}
+
+		if !valid {
+			list.Invalid++
+			continue
+		}
+
+		if rng, ok, err := parseRange(line); err != nil {
+			list.Invalid++
+			continue
+		} else if ok {
+			n := rng.count()
+			if n > ConfirmExpansion || expanded+n > MaxExpansion {
+				list.Ranges = append(list.Ranges, Range{Pattern: line, Source: source, Targets: n})
+				continue
+			}
+			expanded += n
+			metadata := parseMetadata(raw)
+			rng.each(func(target string) bool {
+				if seen[target] {
+					list.Duplicates++
+					return true
+				}
+				seen[target] = true
+				list.Entries = append(list.Entries, Entry{Proxy: target, Source: source, Metadata: metadata})
+				return true
+			})
+			continue
+		}
+
+		if seen[line] {
+			list.Duplicates++
+			continue
+		}
+		seen[line] = true
+
+		list.Entries = append(list.Entries, Entry{Proxy: line, Source: source, Metadata: parseMetadata(raw)})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read proxy list: %w", err)
+	}
+
+	return list, nil
+}
+
+// parseLine returns the proxy of a list line; line is empty for blank lines and comments
+func parseLine(raw string) (line string, valid bool) {
+	line = strings.TrimSpace(raw)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	line = strings.TrimSpace(columns(line)[0])
+	return line, strings.Contains(line, ":")
+}
+
+// columns splits a list line on commas, semicolons and tabs
+// Separators inside brackets belong to a hostname pattern such as gw-[us,uk].example.com
+func columns(line string) []string {
+	depth := 0
+	return strings.FieldsFunc(line, func(r rune) bool {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		return depth <= 0 && (r == ',' || r == ';' || r == '\t')
+	})
+}
+
+// dateLayouts are the accepted formats of purchase and expiry dates
+var dateLayouts = []string{"2006-01-02", time.RFC3339, "2006/01/02", "02.01.2006"}
+
+// parseMetadata reads the provider, purchase date and expiry date columns of a line
+// It returns nil if the line has no extra columns
+func parseMetadata(raw string) *checker.Metadata {
+	cols := columns(strings.TrimSpace(raw))
+	if len(cols) < 2 {
+		return nil
+	}
+
+	md := &checker.Metadata{Provider: strings.TrimSpace(cols[1])}
+	if len(cols) > 2 {
+		md.Purchased = parseDate(cols[2])
+	}
+	if len(cols) > 3 {
+		md.Expires = parseDate(cols[3])
+	}
+	return md
+}
+
+// parseDate parses a date in one of dateLayouts, returning the zero time if it cannot
+func parseDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// FromFile imports a proxy list from a local file
+// .gz and .zip files are decompressed; the source label is the file name, or the
+// archive and entry name for lists inside a zip archive
+func FromFile(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open proxy list: %w", err)
+	}
+	defer file.Close()
+
+	list, err := parseAll(path, file, "file:")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list.Entries) == 0 && len(list.Ranges) == 0 {
+		return nil, ErrEmptyList
+	}
+
+	return list, nil
+}
+
+// FromURL imports a proxy list from a remote URL
+// The source label is the URL host
+func FromURL(rawURL string, timeout time.Duration) (*List, error) {
+	list, _, err := FromURLIfModified(rawURL, timeout, Validators{})
+	return list, err
+}
+
+// FromURLIfModified is like FromURL but sends the validators of a previous fetch as
+// If-None-Match/If-Modified-Since. It returns ErrNotModified if the list did not change,
+// and the validators of the new version otherwise
+func FromURLIfModified(rawURL string, timeout time.Duration, since Validators) (*List, Validators, error) {
+	client := &http.Client{Timeout: timeout}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, since, fmt.Errorf("invalid list URL: %w", err)
+	}
+	if since.ETag != "" {
+		req.Header.Set("If-None-Match", since.ETag)
+	}
+	if since.LastModified != "" {
+		req.Header.Set("If-Modified-Since", since.LastModified)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, since, fmt.Errorf("failed to fetch proxy list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, since, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, since, fmt.Errorf("%w: %s", ErrBadHTTPStatus, resp.Status)
+	}
+	validators := Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	// Compressed lists are recognised by the URL path or the content type
+	name := resp.Request.URL.Path
+	switch resp.Header.Get("Content-Type") {
+	case "application/gzip", "application/x-gzip":
+		name += ".gz"
+	case "application/zip", "application/x-zip-compressed":
+		name += ".zip"
+	}
+
+	var list *List
+	if archiveKind(name) == "" {
+		list, err = Parse(resp.Body, "url:"+resp.Request.URL.Host)
+	} else {
+		list, err = parseAll(name, resp.Body, "url:"+resp.Request.URL.Host+"/")
+	}
+	if err != nil {
+		return nil, since, err
+	}
+
+	if len(list.Entries) == 0 && len(list.Ranges) == 0 {
+		return nil, since, ErrEmptyList
+	}
+
+	return list, validators, nil
+}
+
+// parseAll parses every list in a possibly compressed source, labelling entries with
+// prefix followed by the list name
+func parseAll(name string, r io.Reader, prefix string) (*List, error) {
+	list := &List{}
+	err := eachList(name, r, func(lr io.Reader, label string) error {
+		parsed, err := Parse(lr, prefix+label)
+		if err != nil {
+			return err
+		}
+		list.Merge(parsed)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FromText imports a proxy list pasted by the user
+func FromText(text string, source string) (*List, error) {
+	if source == "" {
+		source = "manual"
+	}
+	return Parse(strings.NewReader(text), source)
+}
@@ -0,0 +1,142 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrUnknownResultFormat = errors.New("unknown result file format")
+	ErrMissingProxyColumn  = errors.New("result file has no proxy column")
+)
+
+// ResultsFromFile loads results from a JSON or CSV result export
+// JSON files hold an array of results; CSV files need a header row naming the
+// columns after the JSON result fields (proxy, type, status, latency, ...)
+func ResultsFromFile(path string) ([]checker.ProxyResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result file: %w", err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return readResultsJSON(file)
+	case ".csv":
+		return readResultsCSV(file)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownResultFormat, filepath.Ext(path))
+	}
+}
+
+// readResultsJSON reads a JSON array of results
+func readResultsJSON(r io.Reader) ([]checker.ProxyResult, error) {
+	var results []checker.ProxyResult
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, fmt.Errorf("invalid result file: %w", err)
+	}
+
+	kept := results[:0]
+	for _, res := range results {
+		if res.Proxy != "" {
+			kept = append(kept, res)
+		}
+	}
+	return kept, nil
+}
+
+// readResultsCSV reads results from CSV with a header row
+// Column names are matched case-insensitively, ignoring underscores and dashes
+func readResultsCSV(r io.Reader) ([]checker.ProxyResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid result file: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(name))
+		columns[name] = i
+	}
+	if _, ok := columns["proxy"]; !ok {
+		return nil, ErrMissingProxyColumn
+	}
+
+	var results []checker.ProxyResult
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid result file: %w", err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		res := checker.ProxyResult{
+			Proxy:       field("proxy"),
+			Type:        checker.ProxyType(strings.ToLower(field("type"))),
+			Status:      checker.ProxyStatus(field("status")),
+			OutgoingIP:  field("outgoingip"),
+			Country:     field("country"),
+			CountryCode: field("countrycode"),
+			Error:       field("error"),
+			ErrorKind:   field("errorkind"),
+			Source:      field("source"),
+			Vantage:     field("vantage"),
+		}
+		if res.Proxy == "" {
+			continue
+		}
+		res.IPVersion = checker.IPVersion(res.OutgoingIP)
+
+		if provider, purchased, expires := field("provider"), field("purchased"), field("expires"); provider != "" || purchased != "" || expires != "" {
+			res.Metadata = &checker.Metadata{
+				Provider:  provider,
+				Purchased: parseDate(purchased),
+				Expires:   parseDate(expires),
+			}
+		}
+
+		res.Latency, _ = strconv.ParseInt(field("latency"), 10, 64)
+		res.Anonymous, _ = strconv.ParseBool(field("anonymous"))
+		res.SupportsHTTPS, _ = strconv.ParseBool(field("supportshttps"))
+		if ts := field("timestamp"); ts != "" {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				res.Timestamp = t
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
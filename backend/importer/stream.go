@@ -0,0 +1,143 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// StreamStats counts the lines of a streamed import
+type StreamStats struct {
+	// Accepted is the number of proxies passed on
+	Accepted int `json:"accepted"`
+
+	// Duplicates is the number of lines skipped because they were already seen
+	Duplicates int `json:"duplicates"`
+
+	// Invalid is the number of lines skipped because they were not proxies
+	Invalid int `json:"invalid"`
+}
+
+// Stream reads a proxy list from r line by line and calls fn for every new proxy,
+// without holding the list in memory. Duplicates are detected by a 64-bit hash of
+// each line, so memory grows by 8 bytes per proxy rather than by the proxy itself.
+// Reading stops early, without error, as soon as fn returns false
+func Stream(r io.Reader, fn func(proxy string) bool) (StreamStats, error) {
+	s := newStreamer()
+	_, err := s.read(r, fn)
+	return s.stats, err
+}
+
+// StreamFile streams a proxy list from a local file, see Stream
+// .gz files are decompressed on the fly and the text files of .zip archives are read in turn
+func StreamFile(path string, fn func(proxy string) bool) (StreamStats, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return StreamStats{}, fmt.Errorf("failed to open proxy list: %w", err)
+	}
+	defer file.Close()
+
+	s := newStreamer()
+	err = eachList(path, file, func(r io.Reader, label string) error {
+		more, err := s.read(r, fn)
+		if err == nil && !more {
+			return errStreamStopped
+		}
+		return err
+	})
+	if errors.Is(err, errStreamStopped) {
+		err = nil
+	}
+	return s.stats, err
+}
+
+// errStreamStopped ends reading an archive once the consumer stopped the stream
+var errStreamStopped = errors.New("stream stopped")
+
+// streamer deduplicates and counts proxies across the lists of a stream
+type streamer struct {
+	seen  map[uint64]struct{}
+	stats StreamStats
+	// expanded is the number of targets range lines expanded to so far
+	expanded int
+}
+
+// newStreamer creates a streamer with no proxies seen
+func newStreamer() *streamer {
+	return &streamer{seen: make(map[uint64]struct{})}
+}
+
+// read passes the new proxies of r to fn; more is false if fn stopped the stream
+// Range lines are expanded without confirmation, up to MaxExpansion targets per stream
+func (s *streamer) read(r io.Reader, fn func(proxy string) bool) (more bool, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, valid := parseLine(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if !valid {
+			s.stats.Invalid++
+			continue
+		}
+
+		rng, ok, err := parseRange(line)
+		switch {
+		case err != nil:
+			s.stats.Invalid++
+		case ok:
+			n := rng.count()
+			if s.expanded+n > MaxExpansion {
+				s.stats.Invalid++
+				continue
+			}
+			s.expanded += n
+			if !rng.each(func(target string) bool { return s.accept(target, fn) }) {
+				return false, nil
+			}
+		default:
+			if !s.accept(line, fn) {
+				return false, nil
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read proxy list: %w", err)
+	}
+
+	return true, nil
+}
+
+// accept passes proxy to fn unless it was seen already; it returns false if fn stopped the stream
+func (s *streamer) accept(proxy string, fn func(proxy string) bool) bool {
+	h := fnv.New64a()
+	h.Write([]byte(proxy))
+	key := h.Sum64()
+	if _, ok := s.seen[key]; ok {
+		s.stats.Duplicates++
+		return true
+	}
+	s.seen[key] = struct{}{}
+
+	s.stats.Accepted++
+	return fn(proxy)
+}
+
+// FileSource returns the source label of proxies imported from path
+func FileSource(path string) string {
+	return "file:" + filepath.Base(path)
+}
@@ -0,0 +1,93 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrNoGeoIPDatabase = errors.New("no GeoIP database installed")
+)
+
+// filterInput drops proxies that must not be checked: blocklisted entries, entries outside
+// the allowlist and entries outside the requested countries
+func (a *App) filterInput(params *CheckParams) error {
+	params.ProxyList = a.applyBlocklist(params.ProxyList)
+
+	// In allowlist mode, never touch proxies outside the approved scope
+	allowed, err := a.applyAllowlist(params.ProxyList)
+	if err != nil {
+		return err
+	}
+	params.ProxyList = allowed
+
+	filtered, err := a.applyCountryFilter(params.ProxyList, params.Countries, params.ExcludeCountries)
+	if err != nil {
+		return err
+	}
+	params.ProxyList = filtered
+
+	return nil
+}
+
+// applyCountryFilter keeps proxies whose own IP is located in countries (or outside them
+// with exclude set) using the local GeoIP database, so unwanted proxies are never checked
+func (a *App) applyCountryFilter(proxies []string, countries []string, exclude bool) ([]string, error) {
+	if len(countries) == 0 {
+		return proxies, nil
+	}
+
+	if a.geoDB() == nil {
+		return nil, fmt.Errorf("country filter: %w", ErrNoGeoIPDatabase)
+	}
+
+	kept, dropped := checker.FilterByCountry(proxies, countries, exclude, a.countryOf)
+	if len(dropped) > 0 {
+		a.emit("log", fmt.Sprintf("Country filter: skipped %d proxies, %d left to check", len(dropped), len(kept)))
+	}
+
+	return kept, nil
+}
+
+// CountryCount is the number of input proxies located in a country
+type CountryCount struct {
+	CountryCode string `json:"countryCode"`
+	Count       int    `json:"count"`
+}
+
+// GetInputCountries returns how many proxies of a list are located in each country,
+// to help choose a country filter before checking. Unresolved proxies are counted under ""
+func (a *App) GetInputCountries(proxies []string) ([]CountryCount, error) {
+	if a.geoDB() == nil {
+		return nil, ErrNoGeoIPDatabase
+	}
+
+	counts := make(map[string]int)
+	for _, proxy := range proxies {
+		counts[a.countryOf(checker.ProxyHost(proxy))]++
+	}
+
+	result := make([]CountryCount, 0, len(counts))
+	for code, count := range counts {
+		result = append(result, CountryCount{CountryCode: code, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].CountryCode < result[j].CountryCode
+	})
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"net"
+)
+
+// NetworkInterface is a local interface checks can be bound to
+type NetworkInterface struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
+
+// GetNetworkInterfaces returns the interfaces that are up, with their addresses,
+// for choosing the source of checks
+func (a *App) GetNetworkInterfaces() []NetworkInterface {
+	result, err := upInterfaces()
+	if err != nil {
+		a.emit("log", "Failed to list network interfaces: "+err.Error())
+		return []NetworkInterface{}
+	}
+	return result
+}
+
+// upInterfaces returns the interfaces that are up and have a routable address
+func upInterfaces() ([]NetworkInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NetworkInterface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+
+		ni := NetworkInterface{Name: iface.Name, Addresses: []string{}}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLinkLocalUnicast() {
+				ni.Addresses = append(ni.Addresses, ipNet.IP.String())
+			}
+		}
+		if len(ni.Addresses) > 0 {
+			result = append(result, ni)
+		}
+	}
+	return result, nil
+}
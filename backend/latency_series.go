@@ -0,0 +1,229 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+const (
+	// latencySeriesFile is the file in the data directory holding the latency samples of
+	// the monitored proxies
+	latencySeriesFile = "latency_series.json"
+
+	// latencyRetention is how long latency samples are kept
+	latencyRetention = 7 * 24 * time.Hour
+
+	// maxLatencySamples caps the samples kept per proxy, a week of cycles five minutes apart
+	maxLatencySamples = 2016
+
+	// defaultSeriesPoints and maxSeriesPoints bound the points of a series query
+	defaultSeriesPoints = 200
+	maxSeriesPoints     = 2000
+)
+
+// latencySample is one monitoring check of a proxy, stored as [unix seconds, latency ms]
+// to keep the file small; the latency is -1 if the proxy was down
+type latencySample [2]int64
+
+// LatencyPoint is a bucket of a downsampled latency series
+type LatencyPoint struct {
+	// Time is the start of the bucket
+	Time time.Time `json:"time"`
+	// Min, Average and Max are the latencies (ms) of the live checks in the bucket
+	Min     int64 `json:"min"`
+	Average int64 `json:"average"`
+	Max     int64 `json:"max"`
+	// Live and Down are the number of checks in the bucket that passed and failed
+	Live int `json:"live"`
+	Down int `json:"down"`
+}
+
+// LatencySeries is the downsampled latency of a proxy over a time range
+type LatencySeries struct {
+	Proxy string    `json:"proxy"`
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Step is the width of a bucket; empty buckets are left out
+	Step   time.Duration  `json:"step"`
+	Points []LatencyPoint `json:"points"`
+}
+
+// latencySeries keeps the latency samples of the monitored proxies
+type latencySeries struct {
+	mutex   sync.Mutex
+	samples map[string][]latencySample
+}
+
+// record adds the results of a monitoring cycle, dropping samples past the retention
+func (s *latencySeries) record(results []checker.ProxyResult) {
+	cutoff := time.Now().Add(-latencyRetention).Unix()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.samples == nil {
+		s.samples = make(map[string][]latencySample)
+	}
+	for _, r := range results {
+		switch checker.ProxyStatus(strings.ToLower(string(r.Status))) {
+		case checker.StatusPending, checker.StatusChecking, checker.StatusAborted:
+			continue
+		}
+		latency := int64(-1)
+		if isUsable(r.Status) {
+			latency = r.Latency
+		}
+		s.samples[r.Proxy] = append(s.samples[r.Proxy], latencySample{r.Timestamp.Unix(), latency})
+	}
+
+	for proxy, samples := range s.samples {
+		i := sort.Search(len(samples), func(i int) bool { return samples[i][0] >= cutoff })
+		samples = samples[max(i, len(samples)-maxLatencySamples):]
+		if len(samples) == 0 {
+			delete(s.samples, proxy)
+			continue
+		}
+		s.samples[proxy] = samples
+	}
+}
+
+// query downsamples the samples of a proxy between since and until into at most points buckets
+func (s *latencySeries) query(proxy string, since time.Time, until time.Time, points int) LatencySeries {
+	series := LatencySeries{Proxy: proxy, Since: since, Until: until, Points: []LatencyPoint{}}
+	span := until.Sub(since)
+	if span <= 0 {
+		return series
+	}
+	series.Step = max(span/time.Duration(points), time.Second)
+
+	s.mutex.Lock()
+	samples := s.samples[proxy]
+	s.mutex.Unlock()
+
+	var point *LatencyPoint
+	var sum int64
+	flush := func() {
+		if point == nil {
+			return
+		}
+		if point.Live > 0 {
+			point.Average = sum / int64(point.Live)
+		}
+		series.Points = append(series.Points, *point)
+		point, sum = nil, 0
+	}
+	for _, sample := range samples {
+		t := time.Unix(sample[0], 0)
+		if t.Before(since) || t.After(until) {
+			continue
+		}
+		start := since.Add(t.Sub(since) / series.Step * series.Step)
+		if point != nil && !point.Time.Equal(start) {
+			flush()
+		}
+		if point == nil {
+			point = &LatencyPoint{Time: start}
+		}
+
+		latency := sample[1]
+		if latency < 0 {
+			point.Down++
+			continue
+		}
+		if point.Live == 0 || latency < point.Min {
+			point.Min = latency
+		}
+		point.Max = max(point.Max, latency)
+		point.Live++
+		sum += latency
+	}
+	flush()
+	return series
+}
+
+// save writes the samples to path
+func (s *latencySeries) save(path string) error {
+	s.mutex.Lock()
+	data, err := json.Marshal(s.samples)
+	s.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode latency series: %w", err)
+	}
+
+	return export.WriteFile(path, data)
+}
+
+// load reads the samples from path; a missing file leaves the series empty
+func (s *latencySeries) load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.mutex.Lock()
+		s.samples = nil
+		s.mutex.Unlock()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read latency series: %w", err)
+	}
+
+	var samples map[string][]latencySample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return fmt.Errorf("invalid latency series: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.samples = samples
+	return nil
+}
+
+// updateLatencySeries records a completed monitoring cycle in the latency series
+func (a *App) updateLatencySeries(results []checker.ProxyResult) {
+	if !a.IsMonitoring() {
+		return
+	}
+
+	a.latency.record(results)
+	if err := a.latency.save(a.latencySeriesPath()); err != nil {
+		a.emit("log", fmt.Sprintf("Failed to save latency series: %v", err))
+	}
+}
+
+// latencySeriesPath returns the path of the latency series file
+func (a *App) latencySeriesPath() string {
+	return filepath.Join(a.config.DataDir(), latencySeriesFile)
+}
+
+// GetLatencySeries returns the latency of a monitored proxy between since and until,
+// downsampled to at most points buckets (0 for the default) for charting. A zero until
+// means now and a zero since the start of the retention
+func (a *App) GetLatencySeries(proxy string, since time.Time, until time.Time, points int) LatencySeries {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	if since.IsZero() {
+		since = until.Add(-latencyRetention)
+	}
+	if points <= 0 {
+		points = defaultSeriesPoints
+	}
+	return a.latency.query(proxy, since, until, min(points, maxSeriesPoints))
+}
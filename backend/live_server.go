@@ -0,0 +1,36 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+// StartLiveServer starts the local HTTP server serving /live.txt and /live.json, and the
+// verified pool as /verified.txt and /verified.json
+func (a *App) StartLiveServer() error {
+	addr := a.config.GetConfig().LiveServerAddress
+	if err := a.liveServer.Start(addr); err != nil {
+		return err
+	}
+
+	a.emit("log", "Live list server listening on http://"+a.liveServer.Addr())
+	return nil
+}
+
+// StopLiveServer stops the local HTTP server
+func (a *App) StopLiveServer() error {
+	if err := a.liveServer.Stop(); err != nil {
+		return err
+	}
+
+	a.emit("log", "Live list server stopped")
+	return nil
+}
+
+// GetLiveServerAddress returns the address the live list server is listening on, or an empty string
+func (a *App) GetLiveServerAddress() string {
+	return a.liveServer.Addr()
+}
@@ -0,0 +1,180 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+const (
+	// maxStoredLogs is the number of log lines kept for QueryLogs; older lines are dropped
+	maxStoredLogs = 50000
+
+	// defaultLogQueryLimit is the number of lines returned when a query sets no limit
+	defaultLogQueryLimit = 500
+)
+
+// Log levels, lowest first
+const (
+	LogDebug = "debug"
+	LogInfo  = "info"
+	LogWarn  = "warn"
+	LogError = "error"
+)
+
+var ErrUnknownLogLevel = errors.New("unknown log level")
+
+// logLevels ranks the log levels
+var logLevels = map[string]int{LogDebug: 0, LogInfo: 1, LogWarn: 2, LogError: 3}
+
+// logLevelRules classify log lines by their lowercase wording, first match wins; lines
+// matching none are info
+var logLevelRules = []struct {
+	level    string
+	contains []string
+}{
+	{LogDebug, []string{"checking proxy: ", "worker ", "using the cached type", "auto-detected"}},
+	{LogError, []string{"failed", "panicked", "error", " is down", "could not", "cannot"}},
+	{LogWarn, []string{"timeout", "abandoned", "refused", "blocked", "suspect", "cache", "failing over", "reducing", "dropped", "skipped"}},
+}
+
+// LogLine is a stored log line
+type LogLine struct {
+	Seq     uint64    `json:"seq"`
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// LogQuery filters the stored log lines; empty fields match every line
+type LogQuery struct {
+	// Text is matched case-insensitively against the message
+	Text string `json:"text"`
+	// Level is the lowest level returned
+	Level string `json:"level"`
+	// Since and Until bound the time of the lines
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Proxy returns only the lines mentioning the proxy
+	Proxy string `json:"proxy"`
+	// AfterSeq returns only the lines logged after that sequence number, to follow the log
+	AfterSeq uint64 `json:"afterSeq"`
+	// Limit is the number of lines returned, the most recent matches; 0 for the default
+	Limit int `json:"limit"`
+}
+
+// LogPage is the result of a log query
+type LogPage struct {
+	// Lines are the matching lines, oldest first
+	Lines []LogLine `json:"lines"`
+	// Total is the number of matching lines, including those beyond the limit
+	Total int `json:"total"`
+	// LastSeq is the sequence number of the newest stored line, for the next AfterSeq
+	LastSeq uint64 `json:"lastSeq"`
+}
+
+// logStore keeps the log lines for searching
+type logStore struct {
+	mutex sync.Mutex
+	lines []LogLine
+	seq   uint64
+}
+
+// add stores a line, dropping the oldest ones when full
+func (s *logStore) add(t time.Time, msg string) LogLine {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.seq++
+	line := LogLine{Seq: s.seq, Time: t, Level: logLevel(msg), Message: msg}
+	s.lines = append(s.lines, line)
+	if len(s.lines) > maxStoredLogs {
+		s.lines = append([]LogLine(nil), s.lines[len(s.lines)-maxStoredLogs/2:]...)
+	}
+	return line
+}
+
+// query returns the lines matching q
+func (s *logStore) query(q LogQuery) LogPage {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultLogQueryLimit
+	}
+	text := strings.ToLower(q.Text)
+	minLevel := logLevels[q.Level]
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	page := LogPage{LastSeq: s.seq}
+	var matches []LogLine
+	for _, line := range s.lines {
+		switch {
+		case line.Seq <= q.AfterSeq,
+			logLevels[line.Level] < minLevel,
+			!q.Since.IsZero() && line.Time.Before(q.Since),
+			!q.Until.IsZero() && line.Time.After(q.Until),
+			q.Proxy != "" && !strings.Contains(line.Message, q.Proxy),
+			text != "" && !strings.Contains(strings.ToLower(line.Message), text):
+			continue
+		}
+		matches = append(matches, line)
+	}
+
+	page.Total = len(matches)
+	if len(matches) > limit {
+		matches = matches[len(matches)-limit:]
+	}
+	page.Lines = append([]LogLine{}, matches...)
+	return page
+}
+
+// logLevel classifies a log line
+func logLevel(msg string) string {
+	msg = strings.ToLower(msg)
+	for _, rule := range logLevelRules {
+		for _, s := range rule.contains {
+			if strings.Contains(msg, s) {
+				return rule.level
+			}
+		}
+	}
+	return LogInfo
+}
+
+// streamLog reports whether a log line is sent to the frontend as an event
+func (a *App) streamLog(line LogLine) bool {
+	return logLevels[line.Level] >= logLevels[a.config.GetConfig().LogEventLevel]
+}
+
+// QueryLogs searches the stored log lines, so the frontend can show filtered views of
+// large runs without receiving every line
+func (a *App) QueryLogs(q LogQuery) (LogPage, error) {
+	if _, ok := logLevels[q.Level]; q.Level != "" && !ok {
+		return LogPage{}, fmt.Errorf("%w: %s", ErrUnknownLogLevel, q.Level)
+	}
+	return a.logSearch.query(q), nil
+}
+
+// SetLogEventLevel sets the lowest level of the log lines sent as events; the others are
+// only stored and can be fetched with QueryLogs
+func (a *App) SetLogEventLevel(level string) error {
+	if _, ok := logLevels[level]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownLogLevel, level)
+	}
+	return a.config.UpdateConfig(func(c *config.Config) {
+		c.LogEventLevel = level
+	})
+}
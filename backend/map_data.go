@@ -0,0 +1,112 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"math"
+	"sort"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// defaultMapGrid is the size in degrees of the cells live proxies are grouped into
+const defaultMapGrid = 1.0
+
+// GeoPoint is a map marker aggregating the live proxies of one grid cell
+type GeoPoint struct {
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	Count          int     `json:"count"`
+	AverageLatency int64   `json:"averageLatency"`
+	// Label is the most common city of the cell, or its country
+	Label       string `json:"label"`
+	CountryCode string `json:"countryCode"`
+}
+
+// GetMapPoints groups the located live proxies into grid cells of gridDegrees
+// (1 degree if <= 0) and returns one point per cell, largest first
+// The point is placed at the average position of its proxies
+func (a *App) GetMapPoints(gridDegrees float64) []GeoPoint {
+	if gridDegrees <= 0 {
+		gridDegrees = defaultMapGrid
+	}
+	return mapPoints(a.liveResults(a.manager.GetResults()), gridDegrees)
+}
+
+// mapCell accumulates the proxies of one grid cell
+type mapCell struct {
+	latSum, lonSum float64
+	latencySum     int64
+	count          int
+	labels         map[string]int
+	countryCode    string
+}
+
+// mapPoints aggregates located results into grid cells
+func mapPoints(results []checker.ProxyResult, grid float64) []GeoPoint {
+	cells := make(map[[2]int]*mapCell)
+
+	for _, r := range results {
+		if r.Latitude == 0 && r.Longitude == 0 {
+			continue
+		}
+
+		key := [2]int{int(math.Floor(r.Latitude / grid)), int(math.Floor(r.Longitude / grid))}
+		cell, ok := cells[key]
+		if !ok {
+			cell = &mapCell{labels: make(map[string]int), countryCode: r.CountryCode}
+			cells[key] = cell
+		}
+
+		cell.latSum += r.Latitude
+		cell.lonSum += r.Longitude
+		cell.latencySum += r.Latency
+		cell.count++
+
+		label := r.City
+		if label == "" {
+			label = r.Country
+		}
+		if label != "" {
+			cell.labels[label]++
+		}
+	}
+
+	points := make([]GeoPoint, 0, len(cells))
+	for _, cell := range cells {
+		n := float64(cell.count)
+		points = append(points, GeoPoint{
+			Latitude:       cell.latSum / n,
+			Longitude:      cell.lonSum / n,
+			Count:          cell.count,
+			AverageLatency: cell.latencySum / int64(cell.count),
+			Label:          topLabel(cell.labels),
+			CountryCode:    cell.countryCode,
+		})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Count != points[j].Count {
+			return points[i].Count > points[j].Count
+		}
+		return points[i].Label < points[j].Label
+	})
+	return points
+}
+
+// topLabel returns the most frequent label, the alphabetically first on ties
+func topLabel(labels map[string]int) string {
+	best, bestCount := "", 0
+	for label, count := range labels {
+		if count > bestCount || (count == bestCount && label < best) {
+			best, bestCount = label, count
+		}
+	}
+	return best
+}
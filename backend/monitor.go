@@ -0,0 +1,110 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// MonitorParams represents the parameters for monitoring mode
+type MonitorParams struct {
+	CheckParams     CheckParams `json:"CheckParams"`
+	IntervalMinutes int         `json:"IntervalMinutes"`
+}
+
+// StartMonitoring rechecks the given proxies every interval until StopMonitoring is called
+func (a *App) StartMonitoring(params MonitorParams) string {
+	if err := a.requireLive(); err != nil {
+		return "Monitoring refused: " + err.Error()
+	}
+	if params.IntervalMinutes <= 0 {
+		return "Monitoring interval must be at least one minute"
+	}
+
+	a.monitorMux.Lock()
+	if a.monitorStop != nil {
+		a.monitorMux.Unlock()
+		return "Monitoring already running"
+	}
+	stop := make(chan struct{})
+	a.monitorStop = stop
+	a.monitorMux.Unlock()
+
+	a.emit("log", fmt.Sprintf("Monitoring %d proxies every %d minutes",
+		len(params.CheckParams.ProxyList), params.IntervalMinutes))
+	a.emit("monitor-status", "running")
+
+	go a.monitorLoop(params, stop)
+	if a.config.GetConfig().ScheduledExportEnabled {
+		go a.scheduledExportLoop(stop)
+	}
+
+	return "Monitoring started"
+}
+
+// StopMonitoring stops monitoring mode; a cycle in progress is left to finish
+func (a *App) StopMonitoring() string {
+	a.monitorMux.Lock()
+	defer a.monitorMux.Unlock()
+
+	if a.monitorStop == nil {
+		return "Monitoring not running"
+	}
+
+	close(a.monitorStop)
+	a.monitorStop = nil
+
+	a.emit("log", "Monitoring stopped")
+	a.emit("monitor-status", "stopped")
+	return "Monitoring stopped"
+}
+
+// IsMonitoring returns whether monitoring mode is active
+func (a *App) IsMonitoring() bool {
+	a.monitorMux.Lock()
+	defer a.monitorMux.Unlock()
+	return a.monitorStop != nil
+}
+
+// monitorLoop starts a check cycle immediately and then on every interval tick
+func (a *App) monitorLoop(params MonitorParams, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(params.IntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if a.manager.IsRunning() {
+			a.emit("log", "Previous monitoring cycle still running, skipping this cycle")
+		} else {
+			a.StartCheck(params.CheckParams)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// liveSnapshot returns the live proxies of the last completed run
+// While a run is in progress, the previous completed run is used so consumers never see a partial list
+func (a *App) liveSnapshot() []checker.ProxyResult {
+	a.resultsMux.Lock()
+	completed := a.completedLive
+	a.resultsMux.Unlock()
+
+	if a.manager.IsRunning() && completed != nil {
+		return completed
+	}
+
+	return a.liveResults(a.manager.GetResults())
+}
@@ -0,0 +1,166 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+)
+
+// networkPollInterval is how often the local interfaces are compared during a run
+const networkPollInterval = 5 * time.Second
+
+// watchNetwork pauses the main run when the local interfaces change (VPN connected or
+// dropped, Wi-Fi switched), detects the new public IP and records the change so the
+// user can decide whether the results checked during the transition are kept
+func (a *App) watchNetwork(generation int64, req checker.ProxyCheckRequest) {
+	before, err := interfaceAddrs()
+	if err != nil {
+		return
+	}
+	stable := time.Now()
+	dialer := monitorDialer(req.SourceAddress)
+	publicIP := diagnoseEndpoint(dialer, req.Endpoint).Detail
+
+	ticker := time.NewTicker(networkPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if a.monitorGen.Load() != generation || !a.manager.IsRunning() {
+			return
+		}
+
+		after, err := interfaceAddrs()
+		if err != nil {
+			continue
+		}
+		added, removed := diffAddrs(before, after)
+		if len(added) == 0 && len(removed) == 0 {
+			stable = time.Now()
+			continue
+		}
+		before = after
+
+		change := event.NetworkChange{
+			Since:      stable,
+			DetectedAt: time.Now(),
+			Added:      added,
+			Removed:    removed,
+			PreviousIP: publicIP,
+		}
+		if !a.manager.IsPaused() && a.manager.Pause() {
+			change.Paused = true
+			a.setRunState(MainRunID, event.StatePaused)
+		}
+
+		// The source address may have gone away with the interface
+		dialer = monitorDialer(req.SourceAddress)
+		publicIP = diagnoseEndpoint(dialer, req.Endpoint).Detail
+		change.PublicIP = publicIP
+		stable = time.Now()
+
+		a.networkMux.Lock()
+		a.networkChange = &change
+		a.networkMux.Unlock()
+
+		a.emit("log", fmt.Sprintf("Network changed during the check (public IP %s -> %s), check paused; results since %s may be wrong",
+			orNone(change.PreviousIP), orNone(change.PublicIP), change.Since.Format("15:04:05")))
+		a.emitTyped(event.NameNetworkChange, MainRunID, change)
+	}
+}
+
+// GetNetworkChange returns the last network change of the main run not yet handled, or nil
+func (a *App) GetNetworkChange() *event.NetworkChange {
+	a.networkMux.Lock()
+	defer a.networkMux.Unlock()
+	return a.networkChange
+}
+
+// InvalidateNetworkChange queues the proxies checked since the last network change for
+// another check and returns their number; the run goes on once resumed
+func (a *App) InvalidateNetworkChange() (int, error) {
+	a.networkMux.Lock()
+	change := a.networkChange
+	a.networkChange = nil
+	a.networkMux.Unlock()
+
+	if change == nil {
+		return 0, errors.New("no network change to invalidate")
+	}
+
+	n := a.manager.RequeueSince(change.Since)
+	a.updateResults()
+	a.updateStats()
+	a.emit("log", fmt.Sprintf("%d proxies checked during the network change will be checked again", n))
+	return n, nil
+}
+
+// DismissNetworkChange keeps the results checked during the last network change
+func (a *App) DismissNetworkChange() {
+	a.networkMux.Lock()
+	a.networkChange = nil
+	a.networkMux.Unlock()
+}
+
+// monitorDialer returns a direct dialer bound to the source address of a run, if valid
+func monitorDialer(source string) *net.Dialer {
+	dialer := &net.Dialer{Timeout: diagnosticsTimeout}
+	if addr, err := checker.ResolveSourceAddr(source); err == nil && addr != nil {
+		dialer.LocalAddr = addr
+	}
+	return dialer
+}
+
+// interfaceAddrs returns the "interface address" pairs of the interfaces that are up
+func interfaceAddrs() (map[string]bool, error) {
+	ifaces, err := upInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make(map[string]bool)
+	for _, iface := range ifaces {
+		for _, addr := range iface.Addresses {
+			addrs[iface.Name+" "+addr] = true
+		}
+	}
+	return addrs, nil
+}
+
+// diffAddrs returns the sorted addresses only in after and only in before
+func diffAddrs(before, after map[string]bool) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for addr := range after {
+		if !before[addr] {
+			added = append(added, addr)
+		}
+	}
+	for addr := range before {
+		if !after[addr] {
+			removed = append(removed, addr)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// orNone returns s, or "none" if it is empty
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
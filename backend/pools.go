@@ -0,0 +1,189 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrPoolNotFound = errors.New("pool not found")
+	ErrPoolName     = errors.New("pool name must not be empty")
+)
+
+// PoolHealth summarises the health of a named pool
+type PoolHealth struct {
+	Name string `json:"name"`
+	// Size is the number of proxies in the pool and Checked the number with a known outcome
+	Size    int `json:"size"`
+	Checked int `json:"checked"`
+	Live    int `json:"live"`
+	// LivePercent is the share of the checked proxies that are live or slow
+	LivePercent float64 `json:"livePercent"`
+	// MedianLatency is the median latency (ms) of the live proxies
+	MedianLatency int64 `json:"medianLatency"`
+	// Churn is the share of the proxies checked at least twice whose outcome changed
+	// between their last two checks
+	Churn float64 `json:"churn"`
+}
+
+// GetPools returns the named pools
+func (a *App) GetPools() map[string][]string {
+	if a.lock.isLocked() {
+		return nil
+	}
+	return a.config.GetConfig().Pools
+}
+
+// AddToPool adds proxies, e.g. selected results, to a named pool, creating it if needed
+func (a *App) AddToPool(name string, proxies []string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrPoolName
+	}
+	return a.config.AddToPool(name, proxies)
+}
+
+// RemoveFromPool removes proxies from a named pool
+func (a *App) RemoveFromPool(name string, proxies []string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.config.RemoveFromPool(name, proxies)
+}
+
+// DeletePool removes a named pool; its proxies are kept elsewhere
+func (a *App) DeletePool(name string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	return a.config.DeletePool(name)
+}
+
+// GetPoolHealth returns the health summary of a named pool
+func (a *App) GetPoolHealth(name string) (PoolHealth, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return PoolHealth{}, err
+	}
+	proxies, ok := a.config.GetConfig().Pools[name]
+	if !ok {
+		return PoolHealth{}, fmt.Errorf("%w: %s", ErrPoolNotFound, name)
+	}
+	return a.poolHealth(name, proxies, a.currentResults()), nil
+}
+
+// GetPoolsHealth returns the health summaries of all pools, by name
+func (a *App) GetPoolsHealth() []PoolHealth {
+	if a.lock.isLocked() {
+		return nil
+	}
+	pools := a.config.GetConfig().Pools
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	current := a.currentResults()
+	health := make([]PoolHealth, 0, len(names))
+	for _, name := range names {
+		health = append(health, a.poolHealth(name, pools[name], current))
+	}
+	return health
+}
+
+// currentResults returns the completed results of the current run by proxy
+func (a *App) currentResults() map[string]checker.ProxyResult {
+	results := make(map[string]checker.ProxyResult)
+	for _, r := range a.manager.GetResults() {
+		switch checker.ProxyStatus(strings.ToLower(string(r.Status))) {
+		case checker.StatusPending, checker.StatusChecking, checker.StatusAborted:
+			continue
+		}
+		results[r.Proxy] = r
+	}
+	return results
+}
+
+// poolHealth summarises a pool from the current results, falling back to the last
+// check in the history for the proxies the current run did not check
+func (a *App) poolHealth(name string, proxies []string, current map[string]checker.ProxyResult) PoolHealth {
+	health := PoolHealth{Name: name, Size: len(proxies)}
+
+	var latencies []int64
+	changed, repeated := 0, 0
+	for _, proxy := range proxies {
+		history := a.history.get(proxy)
+		if r, ok := current[proxy]; ok && (len(history) == 0 || r.Timestamp.After(history[len(history)-1].Time)) {
+			history = append(history, CheckRecord{Time: r.Timestamp, Status: r.Status, Latency: r.Latency})
+		}
+		if len(history) == 0 {
+			continue
+		}
+
+		last := history[len(history)-1]
+		health.Checked++
+		if isUsable(last.Status) {
+			health.Live++
+			latencies = append(latencies, last.Latency)
+		}
+		if len(history) >= 2 {
+			repeated++
+			if isUsable(history[len(history)-2].Status) != isUsable(last.Status) {
+				changed++
+			}
+		}
+	}
+
+	if health.Checked > 0 {
+		health.LivePercent = float64(health.Live) / float64(health.Checked) * 100
+	}
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		health.MedianLatency = latencies[len(latencies)/2]
+	}
+	if repeated > 0 {
+		health.Churn = float64(changed) / float64(repeated)
+	}
+	return health
+}
+
+// servePoolHealth serves the pool health summaries on the control API: /v1/pools lists
+// all pools and /v1/pools/{name} returns one
+func (a *App) servePoolHealth(w http.ResponseWriter, r *http.Request) {
+	var data interface{}
+	if name := strings.TrimPrefix(r.URL.Path, "/v1/pools/"); name != "" && name != r.URL.Path {
+		health, err := a.GetPoolHealth(name)
+		switch {
+		case errors.Is(err, ErrPoolNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		data = health
+	} else {
+		data = a.GetPoolsHealth()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
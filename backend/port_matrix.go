@@ -0,0 +1,64 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"sort"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// PortMatrix is the port capability table of the live proxies
+type PortMatrix struct {
+	// Ports are the columns of the table, ascending
+	Ports []int           `json:"ports"`
+	Rows  []PortMatrixRow `json:"rows"`
+	// OpenCounts is the number of proxies that can reach each port
+	OpenCounts map[int]int `json:"openCounts"`
+}
+
+// PortMatrixRow is the port capability of one proxy
+type PortMatrixRow struct {
+	Proxy string       `json:"proxy"`
+	Type  string       `json:"type"`
+	Open  map[int]bool `json:"open"`
+	Error string       `json:"error,omitempty"`
+}
+
+// GetPortMatrix returns the port capability table of the live proxies whose ports were probed
+func (a *App) GetPortMatrix() PortMatrix {
+	return portMatrix(a.liveResults(a.manager.GetResults()))
+}
+
+// portMatrix builds the port capability table of results
+func portMatrix(results []checker.ProxyResult) PortMatrix {
+	matrix := PortMatrix{Ports: []int{}, Rows: []PortMatrixRow{}, OpenCounts: make(map[int]int)}
+	seen := make(map[int]bool)
+
+	for _, r := range results {
+		if r.Probes == nil || r.Probes.Ports == nil {
+			continue
+		}
+
+		row := PortMatrixRow{Proxy: r.Proxy, Type: string(r.Type), Open: r.Probes.Ports.Open, Error: r.Probes.Ports.Error}
+		for port, open := range row.Open {
+			if !seen[port] {
+				seen[port] = true
+				matrix.Ports = append(matrix.Ports, port)
+			}
+			if open {
+				matrix.OpenCounts[port]++
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+
+	sort.Ints(matrix.Ports)
+	return matrix
+}
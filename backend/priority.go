@@ -0,0 +1,78 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Queue priority weights; a proxy matching several rules gets the sum
+const (
+	priorityCountry        = 1
+	priorityPreviouslyLive = 2
+	prioritySelected       = 4
+)
+
+// PriorityParams selects proxies to check before the rest of the list
+type PriorityParams struct {
+	// Proxies are user-selected proxies checked first
+	Proxies []string `json:"Proxies,omitempty"`
+
+	// Countries are ISO country codes checked before other countries
+	Countries []string `json:"Countries,omitempty"`
+
+	// PreviouslyLive checks proxies that were live in the last run first
+	PreviouslyLive bool `json:"PreviouslyLive,omitempty"`
+}
+
+// buildPriorities computes the queue priority of every proxy in the list
+func (a *App) buildPriorities(params CheckParams) map[string]int {
+	p := params.Priority
+	if p == nil || (len(p.Proxies) == 0 && len(p.Countries) == 0 && !p.PreviouslyLive) {
+		return nil
+	}
+
+	selected := make(map[string]bool, len(p.Proxies))
+	for _, proxy := range p.Proxies {
+		selected[proxy] = true
+	}
+
+	countries := make(map[string]bool, len(p.Countries))
+	for _, code := range p.Countries {
+		countries[strings.ToUpper(strings.TrimSpace(code))] = true
+	}
+
+	previous := make(map[string]bool)
+	if p.PreviouslyLive {
+		for _, r := range a.liveSnapshot() {
+			previous[r.Proxy] = true
+		}
+	}
+
+	priorities := make(map[string]int)
+	for _, proxy := range params.ProxyList {
+		priority := 0
+		if selected[proxy] {
+			priority += prioritySelected
+		}
+		if previous[proxy] {
+			priority += priorityPreviouslyLive
+		}
+		if len(countries) > 0 && countries[a.countryOf(checker.ProxyHost(proxy))] {
+			priority += priorityCountry
+		}
+		if priority > 0 {
+			priorities[proxy] = priority
+		}
+	}
+
+	return priorities
+}
@@ -0,0 +1,145 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/vault"
+)
+
+// ProfileInfo describes a user profile
+type ProfileInfo struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// GetProfiles returns the user profiles. Each profile has its own data directory holding
+// its configuration (favorites and blocklist included), caches, history and vault
+func (a *App) GetProfiles() ([]ProfileInfo, error) {
+	names, err := config.Profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	active := a.config.Profile()
+	profiles := make([]ProfileInfo, len(names))
+	for i, name := range names {
+		profiles[i] = ProfileInfo{Name: name, Active: name == active}
+	}
+	return profiles, nil
+}
+
+// CreateProfile creates a profile starting with the default settings
+func (a *App) CreateProfile(name string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	if err := config.CreateProfile(name); err != nil {
+		return err
+	}
+
+	a.recordAudit("create-profile", "name="+name, "")
+	return nil
+}
+
+// DeleteProfile removes a profile and all its data
+func (a *App) DeleteProfile(name string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	if err := a.config.DeleteProfile(name); err != nil {
+		return err
+	}
+
+	a.recordAudit("delete-profile", "name="+name, "")
+	return nil
+}
+
+// SwitchProfile saves the data of the active profile and loads the one of another. It is
+// refused while checks are running
+func (a *App) SwitchProfile(name string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	if a.checksRunning() {
+		return fmt.Errorf("cannot switch profile: %w", ErrRunActive)
+	}
+
+	a.recordAudit("switch-profile", "name="+name, "")
+	a.saveRDAPCache()
+	a.saveTypeCache()
+	a.vault.Lock()
+
+	if err := a.config.SwitchProfile(name); err != nil {
+		return err
+	}
+
+	a.vault = vault.New(filepath.Join(a.config.DataDir(), vaultFile))
+	a.resultsMux.Lock()
+	a.previous = nil
+	a.resultsMux.Unlock()
+	a.runLogMux.Lock()
+	a.runLog = nil
+	a.runLogMux.Unlock()
+	a.loadProfileData()
+
+	a.emit("log", "Switched to profile "+name)
+	a.emit("profile-changed", name)
+	return nil
+}
+
+// checksRunning returns true if the main run, a sharded run or a parallel run is active
+func (a *App) checksRunning() bool {
+	if a.manager.IsRunning() || a.shardedActive() {
+		return true
+	}
+
+	a.runsMux.Lock()
+	defer a.runsMux.Unlock()
+	for _, run := range a.runs {
+		if run.manager.IsRunning() {
+			return true
+		}
+	}
+	return false
+}
+
+// loadProfileData reads the persisted state of the active profile
+func (a *App) loadProfileData() {
+	a.verified.clear()
+	if err := a.verified.load(a.verifiedPath()); err != nil {
+		log.Printf("Failed to load verified pool: %v", err)
+	}
+	if err := a.rdap.Load(filepath.Join(a.config.DataDir(), rdapCacheFile)); err != nil {
+		log.Printf("Failed to load RDAP cache: %v", err)
+	}
+	a.typeCache.Clear()
+	if err := a.typeCache.Load(filepath.Join(a.config.DataDir(), typeCacheFile)); err != nil {
+		log.Printf("Failed to load type cache: %v", err)
+	}
+	if err := a.history.load(a.historyPath()); err != nil {
+		log.Printf("Failed to load proxy history: %v", err)
+	}
+	if err := a.latency.load(a.latencySeriesPath()); err != nil {
+		log.Printf("Failed to load latency series: %v", err)
+	}
+	if err := a.throughput.load(a.throughputPath()); err != nil {
+		log.Printf("Failed to load throughput history: %v", err)
+	}
+	if err := a.loadSubscriptions(); err != nil {
+		log.Printf("Failed to load subscriptions: %v", err)
+	}
+	if err := a.lock.load(a.appLockPath()); err != nil {
+		log.Printf("Failed to load app lock: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxResponseSize bounds a single API response
+	maxResponseSize = 32 << 20
+
+	// maxRetries is the number of times a rate-limited request is retried
+	maxRetries = 3
+
+	// defaultRetryAfter is the wait before retrying a rate-limited request without Retry-After
+	defaultRetryAfter = 5 * time.Second
+
+	// maxRetryAfter caps the wait requested by a provider
+	maxRetryAfter = time.Minute
+)
+
+var (
+	ErrRateLimited = errors.New("rate limited by provider")
+	ErrAuth        = errors.New("provider rejected the API key")
+	ErrBadStatus   = errors.New("unexpected HTTP status from provider")
+)
+
+// Client sends the API requests of the providers
+// Requests are spaced by at least Interval, and rate-limited requests (429, 503) are
+// retried after the delay given by Retry-After
+type Client struct {
+	HTTP     *http.Client
+	Interval time.Duration
+
+	mutex sync.Mutex
+	last  time.Time
+}
+
+// NewClient creates a client with the given request timeout and spacing between requests
+func NewClient(timeout time.Duration, interval time.Duration) *Client {
+	return &Client{HTTP: &http.Client{Timeout: timeout}, Interval: interval}
+}
+
+// GetJSON requests url and decodes the JSON response into v
+func (c *Client) GetJSON(ctx context.Context, url string, header http.Header, v interface{}) error {
+	body, err := c.Get(ctx, url, header)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+// Get requests url and returns the response body
+func (c *Client) Get(ctx context.Context, url string, header http.Header) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		resp, err := c.HTTP.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return body, nil
+		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %s", ErrAuth, resp.Status)
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+			if attempt >= maxRetries {
+				return nil, fmt.Errorf("%w: %s", ErrRateLimited, resp.Status)
+			}
+			if err := sleep(ctx, retryAfter(resp.Header.Get("Retry-After"))); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+		}
+	}
+}
+
+// wait spaces requests by at least Interval
+func (c *Client) wait(ctx context.Context) error {
+	c.mutex.Lock()
+	delay := time.Until(c.last.Add(c.Interval))
+	if delay < 0 {
+		delay = 0
+	}
+	c.last = time.Now().Add(delay)
+	c.mutex.Unlock()
+
+	return sleep(ctx, delay)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as a date
+func retryAfter(value string) time.Duration {
+	delay := defaultRetryAfter
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return delay
+}
+
+// sleep waits for d or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+const (
+	// geoNodeURL is the public proxy list of GeoNode
+	geoNodeURL = "https://proxylist.geonode.com/api/proxy-list?sort_by=lastChecked&sort_type=desc"
+
+	// geoNodePageSize is the number of proxies requested per page
+	geoNodePageSize = 500
+)
+
+// GeoNode pulls the public proxy list of GeoNode, page by page
+type GeoNode struct{}
+
+// geoNodeProxy is a proxy of the GeoNode list API
+type geoNodeProxy struct {
+	IP        string   `json:"ip"`
+	Port      string   `json:"port"`
+	Protocols []string `json:"protocols"`
+}
+
+// geoNodeList is a page of the GeoNode list API
+type geoNodeList struct {
+	Data  []geoNodeProxy `json:"data"`
+	Total int            `json:"total"`
+}
+
+func (GeoNode) Name() string      { return "geonode" }
+func (GeoNode) Label() string     { return "GeoNode" }
+func (GeoNode) RequiresKey() bool { return false }
+
+// Fetch follows the pages of the list until the end or the limit
+func (GeoNode) Fetch(ctx context.Context, client *Client, req Request) ([]string, error) {
+	filter := ""
+	switch req.Protocol {
+	case "", checker.Auto:
+	case checker.HTTP, checker.HTTPS, checker.SOCKS4, checker.SOCKS5:
+		filter = "&protocols=" + string(req.Protocol)
+	default:
+		return nil, ErrUnsupported
+	}
+
+	var proxies []string
+	for page := 1; req.Limit <= 0 || len(proxies) < req.Limit; page++ {
+		var list geoNodeList
+		url := fmt.Sprintf("%s&limit=%d&page=%d%s", geoNodeURL, geoNodePageSize, page, filter)
+		if err := client.GetJSON(ctx, url, nil, &list); err != nil {
+			return nil, err
+		}
+
+		for _, p := range list.Data {
+			proxies = append(proxies, net.JoinHostPort(p.IP, p.Port))
+		}
+		if len(list.Data) < geoNodePageSize || page*geoNodePageSize >= list.Total {
+			break
+		}
+	}
+	return proxies, nil
+}
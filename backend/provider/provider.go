@@ -0,0 +1,132 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package provider pulls proxy lists from the APIs of proxy providers.
+//
+// Every integration implements Provider and is registered by name; the built-in
+// ones are Webshare, ProxyScrape and GeoNode. Client handles the HTTP side shared
+// by all of them: timeouts, response size limits and rate limiting.
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown provider")
+	ErrMissingKey      = errors.New("provider requires an API key")
+	ErrUnsupported     = errors.New("protocol not offered by provider")
+)
+
+// Request describes the proxies to pull from a provider
+type Request struct {
+	// APIKey authenticates with the provider, if it needs one
+	APIKey string
+	// Protocol restricts the list to one proxy type; empty takes every type offered
+	Protocol checker.ProxyType
+	// Limit caps the number of proxies; 0 takes the whole list
+	Limit int
+}
+
+// Provider is an integration with the API of a proxy provider
+type Provider interface {
+	// Name is the registry key of the provider, e.g. "webshare"
+	Name() string
+	// Label is the display name of the provider
+	Label() string
+	// RequiresKey returns true if the provider needs an API key
+	RequiresKey() bool
+	// Fetch returns the proxies of the provider as [user:pass@]host:port
+	Fetch(ctx context.Context, client *Client, req Request) ([]string, error)
+}
+
+// Info describes a registered provider
+type Info struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	RequiresKey bool   `json:"requiresKey"`
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]Provider)
+)
+
+func init() {
+	Register(Webshare{})
+	Register(ProxyScrape{})
+	Register(GeoNode{})
+}
+
+// Register adds a provider, replacing one of the same name
+func Register(p Provider) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[p.Name()] = p
+}
+
+// Get returns the provider registered under name
+func Get(name string) (Provider, error) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	p, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// List returns the registered providers sorted by name
+func List() []Info {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	infos := make([]Info, 0, len(registry))
+	for _, p := range registry {
+		infos = append(infos, Info{Name: p.Name(), Label: p.Label(), RequiresKey: p.RequiresKey()})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Import pulls the proxies of a provider as an imported list labelled "provider:<name>",
+// with the provider recorded in the metadata of every entry
+func Import(ctx context.Context, client *Client, p Provider, req Request) (*importer.List, error) {
+	if p.RequiresKey() && req.APIKey == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingKey, p.Label())
+	}
+
+	proxies, err := p.Fetch(ctx, client, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.Label(), err)
+	}
+	if req.Limit > 0 && len(proxies) > req.Limit {
+		proxies = proxies[:req.Limit]
+	}
+
+	list, err := importer.FromText(strings.Join(proxies, "\n"), "provider:"+p.Name())
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entries) == 0 {
+		return nil, importer.ErrEmptyList
+	}
+	for i := range list.Entries {
+		list.Entries[i].Metadata = &checker.Metadata{Provider: p.Label()}
+	}
+	return list, nil
+}
@@ -0,0 +1,63 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+const (
+	// proxyScrapeFreeURL is the public proxy list of ProxyScrape
+	proxyScrapeFreeURL = "https://api.proxyscrape.com/v2/?request=displayproxies&timeout=10000&country=all"
+
+	// proxyScrapeAccountURL is the proxy list of a ProxyScrape premium account
+	proxyScrapeAccountURL = "https://api.proxyscrape.com/v2/account/datacenter_shared/proxy-list?type=getproxies&format=normal&status=online"
+)
+
+// ProxyScrape pulls the proxy list of a premium account when an API key is set, and the
+// public list otherwise. Both are plain text, one proxy per line, without pagination
+type ProxyScrape struct{}
+
+func (ProxyScrape) Name() string      { return "proxyscrape" }
+func (ProxyScrape) Label() string     { return "ProxyScrape" }
+func (ProxyScrape) RequiresKey() bool { return false }
+
+// Fetch requests the list of the protocol (all of them if not set)
+func (ProxyScrape) Fetch(ctx context.Context, client *Client, req Request) ([]string, error) {
+	protocol := "all"
+	switch req.Protocol {
+	case "", checker.Auto:
+	case checker.HTTP, checker.SOCKS4, checker.SOCKS5:
+		protocol = string(req.Protocol)
+	default:
+		return nil, ErrUnsupported
+	}
+
+	endpoint := proxyScrapeFreeURL + "&protocol=" + protocol
+	if req.APIKey != "" {
+		endpoint = proxyScrapeAccountURL + "&protocol=" + protocol + "&auth=" + url.QueryEscape(req.APIKey)
+	}
+
+	body, err := client.Get(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var proxies []string
+	for _, line := range strings.Split(string(body), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			proxies = append(proxies, line)
+		}
+	}
+	return proxies, nil
+}
@@ -0,0 +1,82 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// webshareURL is the first page of the proxy list of a Webshare account
+const webshareURL = "https://proxy.webshare.io/api/v2/proxy/list/?mode=direct&page=1&page_size=100"
+
+// Webshare pulls the proxies of a Webshare account, with their credentials
+// Webshare proxies accept both HTTP and SOCKS5
+type Webshare struct{}
+
+// webshareProxy is a proxy of the Webshare list API
+type webshareProxy struct {
+	Address  string `json:"proxy_address"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Valid    bool   `json:"valid"`
+}
+
+// webshareList is a page of the Webshare list API
+type webshareList struct {
+	Next    *string         `json:"next"`
+	Results []webshareProxy `json:"results"`
+}
+
+func (Webshare) Name() string      { return "webshare" }
+func (Webshare) Label() string     { return "Webshare" }
+func (Webshare) RequiresKey() bool { return true }
+
+// Fetch follows the pages of the list until the end or the limit
+func (Webshare) Fetch(ctx context.Context, client *Client, req Request) ([]string, error) {
+	switch req.Protocol {
+	case "", checker.Auto, checker.HTTP, checker.SOCKS5:
+	default:
+		return nil, ErrUnsupported
+	}
+
+	header := http.Header{"Authorization": {"Token " + req.APIKey}}
+
+	var proxies []string
+	next := webshareURL
+	for next != "" && (req.Limit <= 0 || len(proxies) < req.Limit) {
+		var page webshareList
+		if err := client.GetJSON(ctx, next, header, &page); err != nil {
+			return nil, err
+		}
+
+		for _, p := range page.Results {
+			if !p.Valid {
+				continue
+			}
+			addr := net.JoinHostPort(p.Address, strconv.Itoa(p.Port))
+			if p.Username != "" {
+				addr = url.UserPassword(p.Username, p.Password).String() + "@" + addr
+			}
+			proxies = append(proxies, addr)
+		}
+
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	return proxies, nil
+}
@@ -0,0 +1,92 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/provider"
+)
+
+const (
+	// providerTimeout bounds a whole provider import, pagination and retries included
+	providerTimeout = 5 * time.Minute
+
+	// providerRequestInterval spaces the requests sent to a provider
+	providerRequestInterval = time.Second
+)
+
+// ProviderInfo describes a proxy provider and whether its API key is set
+type ProviderInfo struct {
+	provider.Info
+	HasKey bool `json:"hasKey"`
+}
+
+// GetProviders returns the supported proxy providers
+func (a *App) GetProviders() []ProviderInfo {
+	keys := a.config.GetConfig().ProviderKeys
+
+	var providers []ProviderInfo
+	for _, info := range provider.List() {
+		providers = append(providers, ProviderInfo{Info: info, HasKey: keys[info.Name] != ""})
+	}
+	return providers
+}
+
+// SetProviderKey stores the API key of a provider; an empty key removes it
+func (a *App) SetProviderKey(name string, key string) error {
+	if _, err := provider.Get(name); err != nil {
+		return err
+	}
+
+	key = strings.TrimSpace(key)
+	return a.config.UpdateConfig(func(c *config.Config) {
+		// Copy the map so configs returned earlier are not modified
+		keys := make(map[string]string, len(c.ProviderKeys)+1)
+		for k, v := range c.ProviderKeys {
+			keys[k] = v
+		}
+		if key == "" {
+			delete(keys, name)
+		} else {
+			keys[name] = key
+		}
+		c.ProviderKeys = keys
+	})
+}
+
+// ImportFromProvider pulls up to limit proxies of the given protocol from a provider API,
+// tagging each proxy with the provider name. A limit of 0 imports the whole list
+func (a *App) ImportFromProvider(name string, protocol string, limit int) (*importer.List, error) {
+	p, err := provider.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), providerTimeout)
+	defer cancel()
+
+	client := provider.NewClient(30*time.Second, providerRequestInterval)
+	list, err := provider.Import(ctx, client, p, provider.Request{
+		APIKey:   a.config.GetConfig().ProviderKeys[name],
+		Protocol: checker.ProxyType(protocol),
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.logImport(list)
+	return list, nil
+}
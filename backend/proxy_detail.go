@@ -0,0 +1,335 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+const (
+	// historyFile is the file in the data directory holding the check history of the proxies
+	historyFile = "proxy_history.json"
+
+	// maxHistoryChecks is the number of checks kept per proxy
+	maxHistoryChecks = 50
+
+	// historyRetention is how long a check is kept in the history
+	historyRetention = 90 * 24 * time.Hour
+)
+
+var ErrUnknownProxy = errors.New("nothing is known about this proxy")
+
+// CheckRecord is one check of a proxy in its history
+type CheckRecord struct {
+	Time       time.Time           `json:"time"`
+	Status     checker.ProxyStatus `json:"status"`
+	Type       checker.ProxyType   `json:"type"`
+	Latency    int64               `json:"latency"`
+	OutgoingIP string              `json:"outgoingIp,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	ErrorKind  string              `json:"errorKind,omitempty"`
+	Vantage    string              `json:"vantage,omitempty"`
+}
+
+// LatencyTrend summarises the latency of the live checks of a proxy
+type LatencyTrend struct {
+	// Samples are the latencies (ms) of the live checks, oldest first
+	Samples []int64 `json:"samples"`
+	Min     int64   `json:"min"`
+	Max     int64   `json:"max"`
+	Average int64   `json:"average"`
+	// Slope is the change in ms per check of the least-squares fit; positive means slower
+	Slope float64 `json:"slope"`
+}
+
+// Capabilities are what the last successful check found the proxy supports
+type Capabilities struct {
+	Type          checker.ProxyType `json:"type"`
+	Anonymous     bool              `json:"anonymous"`
+	SupportsHTTPS bool              `json:"supportsHttps"`
+	IPVersion     int               `json:"ipVersion,omitempty"`
+	Probes        *checker.Probes   `json:"probes,omitempty"`
+}
+
+// ProxyDetail is everything known about a proxy across runs
+type ProxyDetail struct {
+	Proxy string `json:"proxy"`
+	// Latest is the most recent result of the proxy, from the current run or the verified pool
+	Latest *checker.ProxyResult `json:"latest,omitempty"`
+	// History is the check history across runs, oldest first
+	History []CheckRecord `json:"history"`
+	Latency LatencyTrend  `json:"latency"`
+	// Stability is the fraction of recent runs the proxy was live, or -1 if unknown
+	Stability float64 `json:"stability"`
+	// Location and network of the exit
+	Country     string               `json:"country,omitempty"`
+	CountryCode string               `json:"countryCode,omitempty"`
+	City        string               `json:"city,omitempty"`
+	Hostname    string               `json:"hostname,omitempty"`
+	Network     *checker.NetworkInfo `json:"network,omitempty"`
+	// Capabilities is nil if the proxy was never live
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+	// Errors are the failed checks, oldest first, and ErrorKinds their count by kind
+	Errors     []CheckRecord     `json:"errors"`
+	ErrorKinds map[string]int    `json:"errorKinds"`
+	Metadata   *checker.Metadata `json:"metadata,omitempty"`
+	// Tags are the labels the app attaches to the proxy (favorite, verified, quarantined, ...)
+	Tags []string `json:"tags"`
+}
+
+// proxyHistory remembers the checks of every proxy across runs
+type proxyHistory struct {
+	mutex  sync.Mutex
+	checks map[string][]CheckRecord
+}
+
+// record adds the results of a completed run, dropping checks past the retention
+func (h *proxyHistory) record(results []checker.ProxyResult) {
+	cutoff := time.Now().Add(-historyRetention)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.checks == nil {
+		h.checks = make(map[string][]CheckRecord)
+	}
+	for _, r := range results {
+		switch checker.ProxyStatus(strings.ToLower(string(r.Status))) {
+		case checker.StatusPending, checker.StatusChecking, checker.StatusAborted:
+			continue
+		}
+		h.checks[r.Proxy] = append(h.checks[r.Proxy], CheckRecord{
+			Time:       r.Timestamp,
+			Status:     r.Status,
+			Type:       r.Type,
+			Latency:    r.Latency,
+			OutgoingIP: r.OutgoingIP,
+			Error:      r.Error,
+			ErrorKind:  r.ErrorKind,
+			Vantage:    r.Vantage,
+		})
+	}
+
+	for proxy, checks := range h.checks {
+		i := sort.Search(len(checks), func(i int) bool { return checks[i].Time.After(cutoff) })
+		checks = checks[max(i, len(checks)-maxHistoryChecks):]
+		if len(checks) == 0 {
+			delete(h.checks, proxy)
+			continue
+		}
+		h.checks[proxy] = checks
+	}
+}
+
+// get returns a copy of the checks of a proxy
+func (h *proxyHistory) get(proxy string) []CheckRecord {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return slices.Clone(h.checks[proxy])
+}
+
+// save writes the history to path
+func (h *proxyHistory) save(path string) error {
+	h.mutex.Lock()
+	data, err := json.Marshal(h.checks)
+	h.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode proxy history: %w", err)
+	}
+
+	return export.WriteFile(path, data)
+}
+
+// load reads the history from path; a missing file leaves the history empty
+func (h *proxyHistory) load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		h.mutex.Lock()
+		h.checks = nil
+		h.mutex.Unlock()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read proxy history: %w", err)
+	}
+
+	var checks map[string][]CheckRecord
+	if err := json.Unmarshal(data, &checks); err != nil {
+		return fmt.Errorf("invalid proxy history: %w", err)
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.checks = checks
+	return nil
+}
+
+// updateHistory records a completed run in the proxy history
+func (a *App) updateHistory(results []checker.ProxyResult) {
+	a.history.record(results)
+	if err := a.history.save(a.historyPath()); err != nil {
+		a.emit("log", fmt.Sprintf("Failed to save proxy history: %v", err))
+	}
+}
+
+// historyPath returns the path of the proxy history file
+func (a *App) historyPath() string {
+	return filepath.Join(a.config.DataDir(), historyFile)
+}
+
+// GetProxyDetail returns everything known about a proxy across runs: its check history,
+// latency trend, location and network, capabilities, failures and tags
+func (a *App) GetProxyDetail(proxy string) (ProxyDetail, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return ProxyDetail{}, err
+	}
+
+	detail := ProxyDetail{
+		Proxy:      proxy,
+		History:    a.history.get(proxy),
+		Stability:  a.stability.stability(proxy),
+		Errors:     []CheckRecord{},
+		ErrorKinds: make(map[string]int),
+	}
+	if detail.History == nil {
+		detail.History = []CheckRecord{}
+	}
+	detail.Latest = a.latestResult(proxy)
+	if detail.Latest == nil && len(detail.History) == 0 {
+		return ProxyDetail{}, fmt.Errorf("%w: %s", ErrUnknownProxy, proxy)
+	}
+
+	for _, c := range detail.History {
+		if isUsable(c.Status) {
+			detail.Latency.Samples = append(detail.Latency.Samples, c.Latency)
+		} else {
+			detail.Errors = append(detail.Errors, c)
+			if c.ErrorKind != "" {
+				detail.ErrorKinds[c.ErrorKind]++
+			}
+		}
+	}
+	detail.Latency = latencyTrend(detail.Latency.Samples)
+
+	if r := detail.Latest; r != nil {
+		geo := r.Clone()
+		if geo.Country == "" {
+			a.geolocate(geo)
+		}
+		detail.Country, detail.CountryCode, detail.City = geo.Country, geo.CountryCode, geo.City
+		detail.Hostname = r.Hostname
+		detail.Network = r.Network
+		detail.Metadata = r.Metadata
+		if isUsable(r.Status) {
+			detail.Capabilities = &Capabilities{
+				Type:          r.Type,
+				Anonymous:     r.Anonymous,
+				SupportsHTTPS: r.SupportsHTTPS,
+				IPVersion:     r.IPVersion,
+				Probes:        r.Probes,
+			}
+		}
+	}
+	detail.Tags = a.proxyTags(proxy, detail.Latest)
+	return detail, nil
+}
+
+// latestResult returns the result of a proxy in the current run, or else in the verified pool
+func (a *App) latestResult(proxy string) *checker.ProxyResult {
+	for _, r := range a.manager.GetResults() {
+		if r.Proxy == proxy {
+			return r.Clone()
+		}
+	}
+	for _, r := range a.verified.snapshot() {
+		if r.Proxy == proxy {
+			return &r
+		}
+	}
+	return nil
+}
+
+// proxyTags returns the labels attached to a proxy
+func (a *App) proxyTags(proxy string, latest *checker.ProxyResult) []string {
+	tags := []string{}
+	if slices.Contains(a.config.GetConfig().Favorites, proxy) {
+		tags = append(tags, "favorite")
+	}
+	if slices.ContainsFunc(a.verified.snapshot(), func(r checker.ProxyResult) bool { return r.Proxy == proxy }) {
+		tags = append(tags, "verified")
+	}
+	if a.quarantine.isQuarantined(proxy) {
+		tags = append(tags, "quarantined")
+	}
+	if latest != nil {
+		if latest.Suspect {
+			tags = append(tags, "suspect")
+		}
+		if latest.Source != "" {
+			tags = append(tags, "source:"+latest.Source)
+		}
+		if latest.Vantage != "" {
+			tags = append(tags, "vantage:"+latest.Vantage)
+		}
+	}
+	return tags
+}
+
+// isUsable reports whether a status means the proxy worked, live or slow
+func isUsable(status checker.ProxyStatus) bool {
+	return strings.EqualFold(string(status), string(checker.StatusLive)) ||
+		strings.EqualFold(string(status), string(checker.StatusSlow))
+}
+
+// latencyTrend summarises latency samples, fitting a line to tell whether the proxy is
+// getting slower
+func latencyTrend(samples []int64) LatencyTrend {
+	trend := LatencyTrend{Samples: samples}
+	if trend.Samples == nil {
+		trend.Samples = []int64{}
+	}
+	if len(samples) == 0 {
+		return trend
+	}
+
+	trend.Min, trend.Max = samples[0], samples[0]
+	var sum int64
+	for _, s := range samples {
+		trend.Min = min(trend.Min, s)
+		trend.Max = max(trend.Max, s)
+		sum += s
+	}
+	trend.Average = sum / int64(len(samples))
+
+	n := float64(len(samples))
+	if n < 2 {
+		return trend
+	}
+	meanX, meanY := (n-1)/2, float64(sum)/n
+	var cov, varX float64
+	for i, s := range samples {
+		dx := float64(i) - meanX
+		cov += dx * (float64(s) - meanY)
+		varX += dx * dx
+	}
+	trend.Slope = cov / varX
+	return trend
+}
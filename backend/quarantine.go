@@ -0,0 +1,194 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// QuarantinedProxy is a proxy excluded from exports because it keeps flapping
+type QuarantinedProxy struct {
+	Proxy string    `json:"proxy"`
+	Flaps int       `json:"flaps"`
+	Since time.Time `json:"since"`
+}
+
+// flapRecord is the recent monitoring history of one proxy
+type flapRecord struct {
+	history     []bool
+	quarantined time.Time
+}
+
+// quarantineTracker counts live/dead transitions of proxies across monitoring cycles
+type quarantineTracker struct {
+	mutex   sync.Mutex
+	records map[string]*flapRecord
+}
+
+// record adds the outcomes of a monitoring cycle and returns the proxies that entered
+// and left quarantine. A proxy with at least threshold flaps in the last window cycles
+// is quarantined; it is released after stable cycles with the same outcome
+func (q *quarantineTracker) record(results []checker.ProxyResult, threshold int, window int, stable int) (entered, released []string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.records == nil {
+		q.records = make(map[string]*flapRecord)
+	}
+
+	now := time.Now()
+	for _, r := range results {
+		var live bool
+		switch strings.ToLower(string(r.Status)) {
+		case string(checker.StatusLive), string(checker.StatusSlow):
+			live = true
+		case string(checker.StatusDead), string(checker.StatusError):
+			live = false
+		default:
+			continue
+		}
+
+		rec, ok := q.records[r.Proxy]
+		if !ok {
+			rec = &flapRecord{}
+			q.records[r.Proxy] = rec
+		}
+
+		rec.history = append(rec.history, live)
+		if keep := max(window, stable); len(rec.history) > keep {
+			rec.history = rec.history[len(rec.history)-keep:]
+		}
+
+		switch {
+		case rec.quarantined.IsZero() && flaps(rec.history, window) >= threshold:
+			rec.quarantined = now
+			entered = append(entered, r.Proxy)
+		case !rec.quarantined.IsZero() && steady(rec.history, stable):
+			rec.quarantined = time.Time{}
+			rec.history = rec.history[len(rec.history)-stable:]
+			released = append(released, r.Proxy)
+		}
+	}
+
+	return entered, released
+}
+
+// flaps counts the outcome changes within the last window entries of history
+func flaps(history []bool, window int) int {
+	if len(history) > window {
+		history = history[len(history)-window:]
+	}
+
+	count := 0
+	for i := 1; i < len(history); i++ {
+		if history[i] != history[i-1] {
+			count++
+		}
+	}
+	return count
+}
+
+// steady returns true if the last n entries of history have the same outcome
+func steady(history []bool, n int) bool {
+	if n <= 0 || len(history) < n {
+		return false
+	}
+
+	last := history[len(history)-1]
+	for _, ok := range history[len(history)-n:] {
+		if ok != last {
+			return false
+		}
+	}
+	return true
+}
+
+// isQuarantined returns true if proxy is currently quarantined
+func (q *quarantineTracker) isQuarantined(proxy string) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	rec, ok := q.records[proxy]
+	return ok && !rec.quarantined.IsZero()
+}
+
+// list returns the quarantined proxies, most recently quarantined first
+func (q *quarantineTracker) list(window int) []QuarantinedProxy {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var list []QuarantinedProxy
+	for proxy, rec := range q.records {
+		if !rec.quarantined.IsZero() {
+			list = append(list, QuarantinedProxy{Proxy: proxy, Flaps: flaps(rec.history, window), Since: rec.quarantined})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Since.After(list[j].Since)
+	})
+	return list
+}
+
+// release takes proxies out of quarantine and forgets their history
+func (q *quarantineTracker) release(proxies []string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, proxy := range proxies {
+		delete(q.records, proxy)
+	}
+}
+
+// GetQuarantined returns the proxies quarantined for flapping
+func (a *App) GetQuarantined() []QuarantinedProxy {
+	return a.quarantine.list(a.config.GetConfig().FlapWindow)
+}
+
+// ReleaseFromQuarantine takes proxies out of quarantine so they are exported again
+func (a *App) ReleaseFromQuarantine(proxies []string) {
+	a.quarantine.release(proxies)
+	a.emit("log", fmt.Sprintf("Released %d proxies from quarantine", len(proxies)))
+}
+
+// updateQuarantine records a completed monitoring cycle
+func (a *App) updateQuarantine(results []checker.ProxyResult) {
+	cfg := a.config.GetConfig()
+	if cfg.FlapThreshold <= 0 || !a.IsMonitoring() {
+		return
+	}
+
+	entered, released := a.quarantine.record(results, cfg.FlapThreshold, cfg.FlapWindow, cfg.QuarantineReleaseCycles)
+	if len(entered) > 0 {
+		a.emit("log", fmt.Sprintf("Quarantined %d flapping proxies", len(entered)))
+	}
+	if len(released) > 0 {
+		a.emit("log", fmt.Sprintf("Released %d stabilized proxies from quarantine", len(released)))
+	}
+	if len(entered) > 0 || len(released) > 0 {
+		a.emit("quarantine-update", a.GetQuarantined())
+	}
+}
+
+// withoutQuarantined drops quarantined proxies from results
+func (a *App) withoutQuarantined(results []checker.ProxyResult) []checker.ProxyResult {
+	kept := make([]checker.ProxyResult, 0, len(results))
+	for _, r := range results {
+		if !a.quarantine.isQuarantined(r.Proxy) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,139 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// redacted replaces the sensitive substrings of log messages
+const redacted = "***"
+
+// credentialPattern matches the user:pass@ prefix of a proxy address
+var credentialPattern = regexp.MustCompile(`[^\s:/@]+:[^\s@/]+@`)
+
+// redactor hides sensitive substrings in log messages
+type redactor struct {
+	mutex    sync.Mutex
+	rules    []string
+	compiled []*regexp.Regexp
+}
+
+// redact hides the proxy credentials, the upstream proxy, the API keys and the matches
+// of the custom rules in a message, unless full verbosity is enabled
+func (r *redactor) redact(cfg config.Config, msg string) string {
+	if cfg.FullVerbosityLogs {
+		return msg
+	}
+
+	secrets := []string{
+		cfg.LastUpstreamProxy,
+		checker.StripProxyAuth(cfg.LastUpstreamProxy),
+		cfg.ControlAPIToken,
+		cfg.GeoIPLicenseKey,
+	}
+	for _, key := range cfg.ProviderKeys {
+		secrets = append(secrets, key)
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			msg = strings.ReplaceAll(msg, secret, redacted)
+		}
+	}
+
+	msg = credentialPattern.ReplaceAllString(msg, redacted+":"+redacted+"@")
+	for _, re := range r.compile(cfg.RedactionRules) {
+		msg = re.ReplaceAllString(msg, redacted)
+	}
+	return msg
+}
+
+// compile returns the compiled custom rules, recompiling them when they changed
+// Invalid rules are skipped; SetRedactionRules refuses them
+func (r *redactor) compile(rules []string) []*regexp.Regexp {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if equalStrings(r.rules, rules) {
+		return r.compiled
+	}
+
+	r.rules = append([]string(nil), rules...)
+	r.compiled = r.compiled[:0]
+	for _, rule := range rules {
+		if re, err := regexp.Compile(rule); err == nil {
+			r.compiled = append(r.compiled, re)
+		}
+	}
+	return r.compiled
+}
+
+// equalStrings returns true if both slices hold the same strings in the same order
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// redactingWriter redacts everything written to the standard logger
+type redactingWriter struct {
+	out    io.Writer
+	redact func(string) string
+}
+
+// Write writes the redacted log line
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.out, w.redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// redact hides sensitive substrings in a log message according to the configuration
+func (a *App) redact(msg string) string {
+	return a.redactor.redact(a.config.GetConfig(), msg)
+}
+
+// SetRedactionRules replaces the custom log redaction rules after validating them
+func (a *App) SetRedactionRules(rules []string) error {
+	for _, rule := range rules {
+		if _, err := regexp.Compile(rule); err != nil {
+			return fmt.Errorf("invalid redaction rule %q: %w", rule, err)
+		}
+	}
+	return a.config.UpdateConfig(func(c *config.Config) {
+		c.RedactionRules = rules
+	})
+}
+
+// SetFullVerbosityLogs turns log redaction off (true) or back on (false)
+func (a *App) SetFullVerbosityLogs(enabled bool) error {
+	if err := a.config.UpdateConfig(func(c *config.Config) {
+		c.FullVerbosityLogs = enabled
+	}); err != nil {
+		return err
+	}
+	if enabled {
+		a.emit("log", "Log redaction disabled: logs may contain credentials and API keys")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/report"
+)
+
+// GenerateReport writes a summary report of the current results and returns its path
+// Format is "markdown" or "html"; an empty format uses the configured default
+func (a *App) GenerateReport(format string) (string, error) {
+	if format == "" {
+		format = a.config.GetConfig().ReportFormat
+	}
+
+	return a.buildReport().Save(a.config.ExportDir(), format)
+}
+
+// GetStatsAggregates returns the current results aggregated by country, type and error category
+func (a *App) GetStatsAggregates() []report.Aggregate {
+	return report.Aggregates(a.manager.GetResults())
+}
+
+// ExportStatsCSV writes the aggregated statistics of the current results as CSV to the
+// export directory and returns the file path
+func (a *App) ExportStatsCSV() (string, error) {
+	data, err := report.AggregatesCSV(a.GetStatsAggregates())
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(a.config.ExportDir(), "stats_"+time.Now().Format("20060102_150405")+".csv")
+	if err := export.WriteFile(path, data); err != nil {
+		return "", err
+	}
+
+	a.emit("log", "Statistics exported to "+path)
+	return path, nil
+}
+
+// onCheckComplete is called by the manager once a run has finished
+func (a *App) onCheckComplete() {
+	defer func() {
+		select {
+		case a.runDone <- struct{}{}:
+		default:
+		}
+		go a.startNextQueued()
+	}()
+
+	a.updateStats()
+	a.setRunState(MainRunID, event.StateCompleted)
+	a.emit("check-complete", checkSummary(MainRunID, a.manager.GetStats()))
+	a.saveRunLog()
+	a.stopRoundTripCallback()
+
+	results := a.manager.GetResults()
+	a.recordPreviousRun(a.manager.GetStats(), results)
+	a.recordThroughput(a.manager.GetStats())
+	a.stability.record(results)
+	a.updateHistory(results)
+	a.updateVerifiedPool(results)
+	a.updateQuarantine(results)
+	a.updateLatencySeries(results)
+	a.saveRDAPCache()
+	a.saveTypeCache()
+	a.warnExpirations()
+
+	live := a.liveResults(results)
+	a.resultsMux.Lock()
+	a.completedLive = live
+	a.resultsMux.Unlock()
+
+	cfg := a.config.GetConfig()
+	if !cfg.GenerateReports {
+		return
+	}
+
+	path, err := a.buildReport().Save(a.config.ExportDir(), cfg.ReportFormat)
+	if err != nil {
+		a.emit("log", fmt.Sprintf("Failed to save run report: %v", err))
+		return
+	}
+
+	a.emit("log", "Run report saved to "+path)
+	a.emit("report-saved", path)
+}
+
+// buildReport builds a report from the manager's results and the last run parameters
+func (a *App) buildReport() *report.Report {
+	a.resultsMux.Lock()
+	params := a.lastParams
+	a.resultsMux.Unlock()
+
+	stats := a.manager.GetStats()
+	start := stats.StartTime
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	return report.Build(report.RunParams{
+		ProxyType:     params.ProxyType,
+		Endpoint:      params.Endpoint,
+		Threads:       params.Threads,
+		UpstreamProxy: params.UpstreamProxy,
+	}, start, time.Now(), a.manager.GetResults())
+}
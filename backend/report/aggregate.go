@@ -0,0 +1,136 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Aggregate dimensions
+const (
+	DimensionCountry = "country"
+	DimensionType    = "type"
+	DimensionError   = "error"
+)
+
+// Aggregate holds the counts and average latency of the results sharing a label
+type Aggregate struct {
+	Dimension      string `json:"dimension"`
+	Label          string `json:"label"`
+	Total          int    `json:"total"`
+	Live           int    `json:"live"`
+	Slow           int    `json:"slow"`
+	Dead           int    `json:"dead"`
+	Errors         int    `json:"errors"`
+	AverageLatency int64  `json:"averageLatency"`
+
+	latencySum int64
+}
+
+// Aggregates groups results by country, type and error category
+// Average latency covers live and slow results; failed results are only grouped by error category
+func Aggregates(results []checker.ProxyResult) []Aggregate {
+	groups := make(map[[2]string]*Aggregate)
+	add := func(dimension string, label string, res checker.ProxyResult) {
+		key := [2]string{dimension, label}
+		agg, ok := groups[key]
+		if !ok {
+			agg = &Aggregate{Dimension: dimension, Label: label}
+			groups[key] = agg
+		}
+
+		agg.Total++
+		switch strings.ToLower(string(res.Status)) {
+		case string(checker.StatusLive):
+			agg.Live++
+			agg.latencySum += res.Latency
+		case string(checker.StatusSlow):
+			agg.Slow++
+			agg.latencySum += res.Latency
+		case string(checker.StatusDead):
+			agg.Dead++
+		default:
+			agg.Errors++
+		}
+	}
+
+	for _, res := range results {
+		switch strings.ToLower(string(res.Status)) {
+		case string(checker.StatusPending), string(checker.StatusChecking), string(checker.StatusAborted):
+			continue
+		}
+
+		country := res.CountryCode
+		if country == "" {
+			country = "Unknown"
+		}
+		add(DimensionCountry, country, res)
+		add(DimensionType, string(res.Type), res)
+
+		switch strings.ToLower(string(res.Status)) {
+		case string(checker.StatusLive), string(checker.StatusSlow):
+		default:
+			add(DimensionError, resultCategory(res), res)
+		}
+	}
+
+	aggregates := make([]Aggregate, 0, len(groups))
+	for _, agg := range groups {
+		if working := agg.Live + agg.Slow; working > 0 {
+			agg.AverageLatency = agg.latencySum / int64(working)
+		}
+		aggregates = append(aggregates, *agg)
+	}
+
+	sort.Slice(aggregates, func(i, j int) bool {
+		a, b := aggregates[i], aggregates[j]
+		if a.Dimension != b.Dimension {
+			return a.Dimension < b.Dimension
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Label < b.Label
+	})
+
+	return aggregates
+}
+
+// AggregatesCSV renders aggregates as CSV with a header row
+func AggregatesCSV(aggregates []Aggregate) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+
+	w.Write([]string{"dimension", "label", "total", "live", "slow", "dead", "errors", "average_latency_ms"})
+	for _, a := range aggregates {
+		w.Write([]string{
+			a.Dimension,
+			a.Label,
+			strconv.Itoa(a.Total),
+			strconv.Itoa(a.Live),
+			strconv.Itoa(a.Slow),
+			strconv.Itoa(a.Dead),
+			strconv.Itoa(a.Errors),
+			strconv.FormatInt(a.AverageLatency, 10),
+		})
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write stats CSV: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,342 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package report
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown report format")
+)
+
+// Supported report formats
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// RunParams describes the parameters a run was started with
+type RunParams struct {
+	ProxyType     string `json:"proxyType"`
+	Endpoint      string `json:"endpoint"`
+	Threads       int    `json:"threads"`
+	UpstreamProxy string `json:"upstreamProxy,omitempty"`
+}
+
+// Count is a label with the number of occurrences
+type Count struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// Report is a summary of a completed run
+type Report struct {
+	Title          string        `json:"title"`
+	StartTime      time.Time     `json:"startTime"`
+	EndTime        time.Time     `json:"endTime"`
+	Duration       time.Duration `json:"duration"`
+	Params         RunParams     `json:"params"`
+	Total          int           `json:"total"`
+	Live           int           `json:"live"`
+	Slow           int           `json:"slow"`
+	Dead           int           `json:"dead"`
+	Errors         int           `json:"errors"`
+	SuccessRate    float64       `json:"successRate"`
+	AverageLatency int64         `json:"averageLatency"`
+	LatencyBuckets []Count       `json:"latencyBuckets"`
+	TopCountries   []Count       `json:"topCountries"`
+	ErrorBreakdown []Count       `json:"errorBreakdown"`
+	TypeBreakdown  []Count       `json:"typeBreakdown"`
+}
+
+// latencyBuckets are the upper bounds (ms) of the latency distribution
+var latencyBuckets = []struct {
+	label string
+	max   int64
+}{
+	{"< 500 ms", 500},
+	{"500 ms - 1 s", 1000},
+	{"1 s - 2 s", 2000},
+	{"2 s - 5 s", 5000},
+	{"> 5 s", -1},
+}
+
+// topCountryLimit is the number of countries listed in a report
+const topCountryLimit = 10
+
+// Build computes a report from the results of a run
+func Build(params RunParams, start time.Time, end time.Time, results []checker.ProxyResult) *Report {
+	r := &Report{
+		Title:     "SoxyChecker run report",
+		StartTime: start,
+		EndTime:   end,
+		Duration:  end.Sub(start).Round(time.Second),
+		Params:    params,
+		Total:     len(results),
+	}
+
+	buckets := make([]int, len(latencyBuckets))
+	countries := make(map[string]int)
+	errorCounts := make(map[string]int)
+	types := make(map[string]int)
+	var totalLatency int64
+
+	for _, res := range results {
+		types[string(res.Type)]++
+
+		switch strings.ToLower(string(res.Status)) {
+		case string(checker.StatusLive):
+			r.Live++
+			totalLatency += res.Latency
+			buckets[bucketIndex(res.Latency)]++
+
+			country := res.Country
+			if country == "" {
+				country = "Unknown"
+			}
+			countries[country]++
+		case string(checker.StatusSlow):
+			r.Slow++
+		case string(checker.StatusDead):
+			r.Dead++
+			errorCounts[resultCategory(res)]++
+		default:
+			r.Errors++
+			errorCounts[resultCategory(res)]++
+		}
+	}
+
+	if completed := r.Live + r.Slow + r.Dead + r.Errors; completed > 0 {
+		r.SuccessRate = float64(r.Live) / float64(completed) * 100
+	}
+	if r.Live > 0 {
+		r.AverageLatency = totalLatency / int64(r.Live)
+	}
+
+	for i, b := range latencyBuckets {
+		r.LatencyBuckets = append(r.LatencyBuckets, Count{Label: b.label, Count: buckets[i]})
+	}
+
+	r.TopCountries = sortedCounts(countries)
+	if len(r.TopCountries) > topCountryLimit {
+		r.TopCountries = r.TopCountries[:topCountryLimit]
+	}
+	r.ErrorBreakdown = sortedCounts(errorCounts)
+	r.TypeBreakdown = sortedCounts(types)
+
+	return r
+}
+
+// resultCategory returns the failure category of a result, falling back to its error
+// message for results recorded before categories were tracked
+func resultCategory(res checker.ProxyResult) string {
+	if res.ErrorKind != "" {
+		return res.ErrorKind
+	}
+	return ErrorCategory(res.Error)
+}
+
+// ErrorCategory maps an error message to a coarse category for reporting
+func ErrorCategory(msg string) string {
+	msg = strings.ToLower(msg)
+
+	switch {
+	case msg == "":
+		return "unknown"
+	case strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded"):
+		return "timeout"
+	case strings.Contains(msg, "connection refused"):
+		return "connection refused"
+	case strings.Contains(msg, "connection reset") || strings.Contains(msg, "eof"):
+		return "connection reset"
+	case strings.Contains(msg, "no such host"):
+		return "dns failure"
+	case strings.Contains(msg, "authentication"):
+		return "auth required"
+	case strings.Contains(msg, "hijacked"):
+		return "hijacked"
+	case strings.Contains(msg, "empty response"):
+		return "bad judge response"
+	case strings.Contains(msg, "unsupported"):
+		return "unsupported"
+	default:
+		return "other"
+	}
+}
+
+// bucketIndex returns the latency bucket a latency falls into
+func bucketIndex(latency int64) int {
+	for i, b := range latencyBuckets {
+		if b.max < 0 || latency < b.max {
+			return i
+		}
+	}
+	return len(latencyBuckets) - 1
+}
+
+// sortedCounts converts a count map into a list sorted by count, then label
+func sortedCounts(m map[string]int) []Count {
+	counts := make([]Count, 0, len(m))
+	for label, count := range m {
+		counts = append(counts, Count{Label: label, Count: count})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Label < counts[j].Label
+	})
+
+	return counts
+}
+
+// Markdown renders the report as Markdown
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	fmt.Fprintf(&b, "- **Started:** %s\n", r.StartTime.Format(time.RFC1123))
+	fmt.Fprintf(&b, "- **Finished:** %s\n", r.EndTime.Format(time.RFC1123))
+	fmt.Fprintf(&b, "- **Duration:** %s\n\n", r.Duration)
+
+	b.WriteString("## Parameters\n\n")
+	fmt.Fprintf(&b, "| Setting | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Proxy type | %s |\n", r.Params.ProxyType)
+	fmt.Fprintf(&b, "| Endpoint | %s |\n", r.Params.Endpoint)
+	fmt.Fprintf(&b, "| Threads | %d |\n", r.Params.Threads)
+	if r.Params.UpstreamProxy != "" {
+		fmt.Fprintf(&b, "| Upstream proxy | %s |\n", checker.StripProxyAuth(r.Params.UpstreamProxy))
+	}
+
+	b.WriteString("\n## Totals\n\n")
+	fmt.Fprintf(&b, "| Total | Live | Slow | Dead | Errors | Success rate | Avg latency |\n|---|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d | %d | %.1f%% | %d ms |\n",
+		r.Total, r.Live, r.Slow, r.Dead, r.Errors, r.SuccessRate, r.AverageLatency)
+
+	writeCountTable(&b, "Latency distribution (live proxies)", "Latency", r.LatencyBuckets)
+	writeCountTable(&b, "Proxy types", "Type", r.TypeBreakdown)
+	writeCountTable(&b, "Top countries", "Country", r.TopCountries)
+	writeCountTable(&b, "Error breakdown", "Category", r.ErrorBreakdown)
+
+	return b.String()
+}
+
+// writeCountTable writes a two-column Markdown table, skipping empty tables
+func writeCountTable(b *strings.Builder, title string, column string, counts []Count) {
+	if len(counts) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n## %s\n\n| %s | Count |\n|---|---|\n", title, column)
+	for _, c := range counts {
+		fmt.Fprintf(b, "| %s | %d |\n", c.Label, c.Count)
+	}
+}
+
+// htmlFuncs are the helper functions available to the HTML template
+var htmlFuncs = template.FuncMap{
+	"counts": func(title string, counts []Count) interface{} {
+		return struct {
+			Title  string
+			Counts []Count
+		}{title, counts}
+	},
+	"stripAuth": checker.StripProxyAuth,
+}
+
+// htmlTemplate renders a report as a standalone HTML page
+var htmlTemplate = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #1b2636; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 12px; text-align: left; }
+th { background: #eef; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Started {{.StartTime.Format "Mon, 02 Jan 2006 15:04:05 MST"}}, finished {{.EndTime.Format "Mon, 02 Jan 2006 15:04:05 MST"}} ({{.Duration}})</p>
+<h2>Parameters</h2>
+<table>
+<tr><th>Proxy type</th><td>{{.Params.ProxyType}}</td></tr>
+<tr><th>Endpoint</th><td>{{.Params.Endpoint}}</td></tr>
+<tr><th>Threads</th><td>{{.Params.Threads}}</td></tr>
+{{if .Params.UpstreamProxy}}<tr><th>Upstream proxy</th><td>{{stripAuth .Params.UpstreamProxy}}</td></tr>{{end}}
+</table>
+<h2>Totals</h2>
+<table>
+<tr><th>Total</th><th>Live</th><th>Slow</th><th>Dead</th><th>Errors</th><th>Success rate</th><th>Avg latency</th></tr>
+<tr><td>{{.Total}}</td><td>{{.Live}}</td><td>{{.Slow}}</td><td>{{.Dead}}</td><td>{{.Errors}}</td><td>{{printf "%.1f" .SuccessRate}}%</td><td>{{.AverageLatency}} ms</td></tr>
+</table>
+{{template "counts" (counts "Latency distribution (live proxies)" .LatencyBuckets)}}
+{{template "counts" (counts "Proxy types" .TypeBreakdown)}}
+{{template "counts" (counts "Top countries" .TopCountries)}}
+{{template "counts" (counts "Error breakdown" .ErrorBreakdown)}}
+</body>
+</html>
+{{define "counts"}}{{if .Counts}}<h2>{{.Title}}</h2>
+<table>
+{{range .Counts}}<tr><td>{{.Label}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>{{end}}{{end}}
+`))
+
+// HTML renders the report as a standalone HTML page
+func (r *Report) HTML() (string, error) {
+	var b strings.Builder
+
+	if err := htmlTemplate.Execute(&b, r); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// Save writes the report to dir in the given format and returns the file path
+func (r *Report) Save(dir string, format string) (string, error) {
+	var content, ext string
+
+	switch format {
+	case FormatMarkdown, "":
+		content, ext = r.Markdown(), ".md"
+	case FormatHTML:
+		html, err := r.HTML()
+		if err != nil {
+			return "", err
+		}
+		content, ext = html, ".html"
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	path := filepath.Join(dir, "report_"+r.EndTime.Format("20060102_150405")+ext)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return path, nil
+}
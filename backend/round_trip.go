@@ -0,0 +1,57 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// roundTripCallback returns the callback listener of the round-trip probe for a starting
+// run, restarting it if its settings changed; nil if it cannot listen. The listener is
+// closed when the run completes
+func (a *App) roundTripCallback(cfg config.Config) *checker.CallbackListener {
+	a.callbackMux.Lock()
+	defer a.callbackMux.Unlock()
+
+	if a.callback != nil {
+		if a.callbackAddr == cfg.CallbackListenAddress && a.callback.PublicURL() == cfg.CallbackPublicURL {
+			return a.callback
+		}
+		a.callback.Close()
+		a.callback = nil
+	}
+
+	callback, err := checker.NewCallbackListener(cfg.CallbackListenAddress, cfg.CallbackPublicURL)
+	if err != nil {
+		a.emit("log", fmt.Sprintf("Round-trip probe disabled: %v", err))
+		return nil
+	}
+	a.callback = callback
+	a.callbackAddr = cfg.CallbackListenAddress
+	a.emit("log", fmt.Sprintf("Round-trip callback listening on %s, reachable at %s", callback.Addr(), callback.PublicURL()))
+	return callback
+}
+
+// stopRoundTripCallback closes the callback listener, if running
+func (a *App) stopRoundTripCallback() {
+	a.callbackMux.Lock()
+	defer a.callbackMux.Unlock()
+
+	if a.callback == nil {
+		return
+	}
+	if err := a.callback.Close(); err != nil {
+		log.Printf("Failed to stop round-trip callback: %v", err)
+	}
+	a.callback = nil
+}
@@ -0,0 +1,209 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+const (
+	// runLogDir is the directory in the data directory holding the logs of past runs
+	runLogDir = "run_logs"
+
+	// maxRunLogLines caps the lines kept for a run; later lines are counted but dropped
+	maxRunLogLines = 100000
+
+	// maxRunLogs is the number of run logs kept on disk
+	maxRunLogs = 50
+)
+
+var ErrNoRunLog = errors.New("no run log")
+
+// RunLogInfo describes the saved log of a run
+type RunLogInfo struct {
+	ID       string    `json:"id"`
+	Started  time.Time `json:"started"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// runLog holds the log lines of a main run
+type runLog struct {
+	id      string
+	started time.Time
+	lines   []string
+	dropped int
+}
+
+// text returns the log as text, one line per message
+func (l *runLog) text() string {
+	text := strings.Join(l.lines, "\n") + "\n"
+	if l.dropped > 0 {
+		text += fmt.Sprintf("[%d more lines not kept]\n", l.dropped)
+	}
+	return text
+}
+
+// beginRunLog starts capturing the log lines of a new main run
+func (a *App) beginRunLog() {
+	now := time.Now()
+
+	a.runLogMux.Lock()
+	defer a.runLogMux.Unlock()
+	a.runLog = &runLog{id: now.Format("20060102_150405"), started: now}
+}
+
+// appendRunLog attaches a log line to the current main run
+func (a *App) appendRunLog(e Event) {
+	msg, ok := e.Data.(string)
+	if !ok {
+		return
+	}
+
+	a.runLogMux.Lock()
+	defer a.runLogMux.Unlock()
+
+	l := a.runLog
+	if l == nil {
+		return
+	}
+	if len(l.lines) >= maxRunLogLines {
+		l.dropped++
+		return
+	}
+	l.lines = append(l.lines, e.Time.Format("2006-01-02 15:04:05.000")+" "+msg)
+}
+
+// saveRunLog writes the log of the current main run to the data directory and removes
+// the oldest logs beyond maxRunLogs
+func (a *App) saveRunLog() {
+	a.runLogMux.Lock()
+	l := a.runLog
+	var id, text string
+	if l != nil {
+		id, text = l.id, l.text()
+	}
+	a.runLogMux.Unlock()
+	if l == nil {
+		return
+	}
+
+	dir := filepath.Join(a.config.DataDir(), runLogDir)
+	if err := export.WriteFile(filepath.Join(dir, id+".log"), []byte(text)); err != nil {
+		log.Printf("Failed to save run log: %v", err)
+		return
+	}
+
+	logs, err := a.runLogs()
+	if err != nil {
+		return
+	}
+	for i := maxRunLogs; i < len(logs); i++ {
+		os.Remove(filepath.Join(dir, logs[i].ID+".log"))
+	}
+}
+
+// GetRunLogs returns the saved run logs, newest first
+func (a *App) GetRunLogs() ([]RunLogInfo, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	return a.runLogs()
+}
+
+// runLogs lists the saved run logs, newest first
+func (a *App) runLogs() ([]RunLogInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(a.config.DataDir(), runLogDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []RunLogInfo
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".log")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		started, _ := time.ParseInLocation("20060102_150405", id, time.Local)
+		logs = append(logs, RunLogInfo{ID: id, Started: started, Size: info.Size(), Modified: info.ModTime()})
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].ID > logs[j].ID })
+	return logs, nil
+}
+
+// ExportRunLog writes the full log of a run to the export directory and returns its path
+// An empty id exports the log of the current or last main run
+func (a *App) ExportRunLog(id string) (string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return "", err
+	}
+	var text string
+	if id == "" {
+		a.runLogMux.Lock()
+		if a.runLog != nil {
+			id, text = a.runLog.id, a.runLog.text()
+		}
+		a.runLogMux.Unlock()
+		if id == "" {
+			return "", ErrNoRunLog
+		}
+	} else {
+		if filepath.Base(id) != id {
+			return "", fmt.Errorf("%w: %s", ErrNoRunLog, id)
+		}
+		data, err := os.ReadFile(filepath.Join(a.config.DataDir(), runLogDir, id+".log"))
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrNoRunLog, id)
+		}
+		if err != nil {
+			return "", err
+		}
+		text = string(data)
+	}
+
+	path := filepath.Join(a.config.ExportDir(), "log_"+id+".txt")
+	if err := export.WriteFile(path, []byte(text)); err != nil {
+		return "", err
+	}
+
+	a.emit("log", "Run log exported to "+path)
+	return path, nil
+}
+
+// exportRunLogWith writes the log of the current run next to exported results when enabled
+func (a *App) exportRunLogWith(paths []string) []string {
+	if !a.config.GetConfig().ExportRunLog {
+		return paths
+	}
+
+	path, err := a.ExportRunLog("")
+	if err != nil {
+		if !errors.Is(err, ErrNoRunLog) {
+			a.emit("log", fmt.Sprintf("Failed to export run log: %v", err))
+		}
+		return paths
+	}
+	return append(paths, path)
+}
@@ -0,0 +1,102 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"time"
+)
+
+// QueuedCheck is a check waiting for the current run to finish
+type QueuedCheck struct {
+	ID      string    `json:"id"`
+	Proxies int       `json:"proxies"`
+	Type    string    `json:"type"`
+	Queued  time.Time `json:"queued"`
+
+	params CheckParams
+}
+
+// GetQueuedChecks returns the checks waiting to start, in start order
+func (a *App) GetQueuedChecks() []QueuedCheck {
+	a.queueMux.Lock()
+	defer a.queueMux.Unlock()
+
+	queued := make([]QueuedCheck, len(a.checkQueue))
+	copy(queued, a.checkQueue)
+	return queued
+}
+
+// RemoveQueuedCheck removes a check from the queue
+func (a *App) RemoveQueuedCheck(id string) string {
+	a.queueMux.Lock()
+	defer a.queueMux.Unlock()
+
+	for i, q := range a.checkQueue {
+		if q.ID == id {
+			a.checkQueue = append(a.checkQueue[:i], a.checkQueue[i+1:]...)
+			a.emit("queue-update", len(a.checkQueue))
+			return "Queued check removed"
+		}
+	}
+	return "Queued check not found"
+}
+
+// ClearCheckQueue removes all queued checks
+func (a *App) ClearCheckQueue() string {
+	a.queueMux.Lock()
+	a.checkQueue = nil
+	a.queueMux.Unlock()
+
+	a.emit("queue-update", 0)
+	return "Check queue cleared"
+}
+
+// enqueueCheck adds a check to the queue and returns its position
+func (a *App) enqueueCheck(params CheckParams) int {
+	a.queueMux.Lock()
+	defer a.queueMux.Unlock()
+
+	a.queueSeq++
+	a.checkQueue = append(a.checkQueue, QueuedCheck{
+		ID:      fmt.Sprintf("queued-%d", a.queueSeq),
+		Proxies: len(params.ProxyList),
+		Type:    params.ProxyType,
+		Queued:  time.Now(),
+		params:  params,
+	})
+
+	a.emit("queue-update", len(a.checkQueue))
+	return len(a.checkQueue)
+}
+
+// startNextQueued starts the next queued check once the main run has finished
+// Sharded runs drive the manager themselves, so the queue waits for them to end
+func (a *App) startNextQueued() {
+	a.shardMux.Lock()
+	sharded := a.sharded != nil
+	a.shardMux.Unlock()
+	if sharded || a.closing.Load() || a.manager.IsRunning() {
+		return
+	}
+
+	a.queueMux.Lock()
+	if len(a.checkQueue) == 0 {
+		a.queueMux.Unlock()
+		return
+	}
+	next := a.checkQueue[0]
+	a.checkQueue = a.checkQueue[1:]
+	remaining := len(a.checkQueue)
+	a.queueMux.Unlock()
+
+	a.emit("queue-update", remaining)
+	a.emit("log", fmt.Sprintf("Starting queued check %s (%d remaining in queue)", next.ID, remaining))
+	a.startCheck(next.params)
+}
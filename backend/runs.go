@@ -0,0 +1,278 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+)
+
+// MainRunID is the ID of the run driven by StartCheck and the other unnamespaced bindings
+const MainRunID = "main"
+
+var (
+	ErrUnknownRun = errors.New("unknown run")
+	ErrRunActive  = errors.New("run is still active")
+)
+
+// RunInfo describes a check run
+type RunInfo struct {
+	ID      string    `json:"id"`
+	Label   string    `json:"label"`
+	Running bool      `json:"running"`
+	Paused  bool      `json:"paused"`
+	Started time.Time `json:"started"`
+	Stats   Stats     `json:"stats"`
+}
+
+// parallelRun is a run with its own manager, started alongside the main run
+type parallelRun struct {
+	id      string
+	label   string
+	manager *checker.Manager
+	started time.Time
+}
+
+// StartRun starts a labelled check that runs in parallel with the main run and any other
+// runs. Its events are emitted as "<event>:<run ID>", e.g. "results-update:run-2"
+func (a *App) StartRun(label string, params CheckParams) (string, error) {
+	if a.closing.Load() {
+		return "", errors.New("application is shutting down")
+	}
+	if err := a.requireLive(); err != nil {
+		return "", err
+	}
+	if a.shardedActive() {
+		return "", ErrShardedRunActive
+	}
+	if err := a.filterInput(&params); err != nil {
+		return "", err
+	}
+	a.clampThreads(&params)
+	if err := a.buildCheckRequest(params).Validate(); err != nil {
+		return "", err
+	}
+
+	a.runsMux.Lock()
+	if a.runs == nil {
+		a.runs = make(map[string]*parallelRun)
+	}
+	a.runSeq++
+	run := &parallelRun{
+		id:      fmt.Sprintf("run-%d", a.runSeq),
+		label:   label,
+		manager: checker.NewManager(),
+		started: time.Now(),
+	}
+	if run.label == "" {
+		run.label = run.id
+	}
+	a.runs[run.id] = run
+	a.runsMux.Unlock()
+
+	run.manager.SetResultHandler(a.resultHandler(run.id))
+	run.manager.SetPanicHandler(a.panicHandler(run.id))
+	run.manager.SetCompletionHandler(func() {
+		a.setRunState(run.id, event.StateCompleted)
+		a.emitRun(run.id, "check-complete", checkSummary(run.id, run.manager.GetStats()))
+	})
+
+	a.recordAudit("start-run", fmt.Sprintf("id=%s label=%q %s", run.id, run.label, checkDetails(params)), "")
+	a.emitRun(run.id, "log", fmt.Sprintf("Starting run %q with %d proxies, type: %s, threads: %d",
+		run.label, len(params.ProxyList), params.ProxyType, params.Threads))
+
+	go run.manager.Start(a.buildCheckRequest(params),
+		func(msg string) {
+			a.emitRun(run.id, "log", msg)
+		},
+		func() {
+			a.emitRun(run.id, "results-update", a.convertResults(run.manager.GetResults()))
+			stats := run.manager.GetStats()
+			a.emitRun(run.id, "stats-update", convertStats(stats))
+			a.emitTyped(event.NameStatsUpdate, run.id, event.StatsUpdate{Stats: stats})
+		})
+
+	a.setRunState(run.id, "running")
+	return run.id, nil
+}
+
+// StopRun stops a run
+func (a *App) StopRun(id string) error {
+	if id == MainRunID {
+		a.StopCheck()
+		return nil
+	}
+
+	run, err := a.getRun(id)
+	if err != nil {
+		return err
+	}
+
+	run.manager.Stop(false)
+	a.recordAudit("stop-run", "id="+id, "")
+	a.setRunState(id, event.StateStopping)
+	return nil
+}
+
+// ForceStopRun stops a run immediately, aborting its checks in flight
+func (a *App) ForceStopRun(id string) error {
+	if id == MainRunID {
+		a.ForceStopCheck()
+		return nil
+	}
+
+	run, err := a.getRun(id)
+	if err != nil {
+		return err
+	}
+
+	run.manager.Stop(true)
+	a.recordAudit("force-stop-run", "id="+id, "")
+	a.setRunState(id, "stopped")
+	return nil
+}
+
+// PauseRun pauses a run
+func (a *App) PauseRun(id string) error {
+	if id == MainRunID {
+		a.PauseCheck()
+		return nil
+	}
+
+	run, err := a.getRun(id)
+	if err != nil {
+		return err
+	}
+
+	if run.manager.Pause() {
+		a.recordAudit("pause-run", "id="+id, "")
+		a.setRunState(id, "paused")
+	}
+	return nil
+}
+
+// ResumeRun resumes a paused run
+func (a *App) ResumeRun(id string) error {
+	if id == MainRunID {
+		a.ResumeCheck()
+		return nil
+	}
+
+	run, err := a.getRun(id)
+	if err != nil {
+		return err
+	}
+
+	if run.manager.Resume() {
+		a.recordAudit("resume-run", "id="+id, "")
+		a.setRunState(id, "running")
+	}
+	return nil
+}
+
+// GetRunResults returns the results of a run
+func (a *App) GetRunResults(id string) ([]ProxyResult, error) {
+	manager, err := a.runManager(id)
+	if err != nil {
+		return nil, err
+	}
+	return a.convertResults(manager.GetResults()), nil
+}
+
+// GetRunStats returns the statistics of a run
+func (a *App) GetRunStats(id string) (Stats, error) {
+	manager, err := a.runManager(id)
+	if err != nil {
+		return Stats{}, err
+	}
+	return convertStats(manager.GetStats()), nil
+}
+
+// ListRuns returns the main run followed by the parallel runs in start order
+func (a *App) ListRuns() []RunInfo {
+	runs := []RunInfo{runInfo(MainRunID, "Main", a.manager, a.manager.GetStats().StartTime)}
+
+	a.runsMux.Lock()
+	parallel := make([]*parallelRun, 0, len(a.runs))
+	for _, run := range a.runs {
+		parallel = append(parallel, run)
+	}
+	a.runsMux.Unlock()
+
+	sort.Slice(parallel, func(i, j int) bool { return parallel[i].started.Before(parallel[j].started) })
+	for _, run := range parallel {
+		runs = append(runs, runInfo(run.id, run.label, run.manager, run.started))
+	}
+
+	return runs
+}
+
+// RemoveRun discards a finished parallel run and its results
+func (a *App) RemoveRun(id string) error {
+	run, err := a.getRun(id)
+	if err != nil {
+		return err
+	}
+
+	if run.manager.IsRunning() {
+		return ErrRunActive
+	}
+
+	a.runsMux.Lock()
+	delete(a.runs, id)
+	a.runsMux.Unlock()
+	return nil
+}
+
+// getRun returns a parallel run by ID
+func (a *App) getRun(id string) (*parallelRun, error) {
+	a.runsMux.Lock()
+	defer a.runsMux.Unlock()
+
+	run, ok := a.runs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRun, id)
+	}
+	return run, nil
+}
+
+// runManager returns the manager of a run, including the main run
+func (a *App) runManager(id string) (*checker.Manager, error) {
+	if id == MainRunID {
+		return a.manager, nil
+	}
+
+	run, err := a.getRun(id)
+	if err != nil {
+		return nil, err
+	}
+	return run.manager, nil
+}
+
+// runInfo describes the state of a run's manager
+func runInfo(id string, label string, manager *checker.Manager, started time.Time) RunInfo {
+	return RunInfo{
+		ID:      id,
+		Label:   label,
+		Running: manager.IsRunning(),
+		Paused:  manager.IsPaused(),
+		Started: started,
+		Stats:   convertStats(manager.GetStats()),
+	}
+}
+
+// emitRun emits an event namespaced to a run
+func (a *App) emitRun(id string, name string, data interface{}) {
+	a.emit(name+":"+id, data)
+}
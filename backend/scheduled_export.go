@@ -0,0 +1,68 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// RunScheduledExport writes the current live list to the configured path and/or URL immediately
+func (a *App) RunScheduledExport() error {
+	cfg := a.config.GetConfig()
+	if cfg.ScheduledExportPath == "" && cfg.ScheduledExportURL == "" {
+		return errors.New("no scheduled export path or URL configured")
+	}
+
+	live := a.exportSnapshot()
+	data, err := a.formatter().Format(live, cfg.ScheduledExportFormat)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ScheduledExportPath != "" {
+		if err := export.WriteFile(cfg.ScheduledExportPath, data); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ScheduledExportURL != "" {
+		if err := export.Post(cfg.ScheduledExportURL, data, export.ContentType(cfg.ScheduledExportFormat), 30*time.Second); err != nil {
+			return err
+		}
+	}
+
+	a.emit("log", fmt.Sprintf("Scheduled export wrote %d live proxies", len(live)))
+	return nil
+}
+
+// scheduledExportLoop exports the live list every configured interval until stop is closed
+func (a *App) scheduledExportLoop(stop <-chan struct{}) {
+	interval := a.config.GetConfig().ScheduledExportInterval
+	if interval <= 0 {
+		interval = 5
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := a.RunScheduledExport(); err != nil {
+				a.emit("log", fmt.Sprintf("Scheduled export failed: %v", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package score computes a composite quality score for live proxies.
+package score
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+)
+
+// Unknown marks a score component without data; it is left out of the weighting
+const Unknown = -1.0
+
+// maxLatency is the latency (ms) at or above which the latency component is zero
+const maxLatency = 5000
+
+// Weights are the relative weights of the score components
+type Weights struct {
+	Latency    float64 `json:"latency"`
+	Anonymity  float64 `json:"anonymity"`
+	Stability  float64 `json:"stability"`
+	Reputation float64 `json:"reputation"`
+	HTTPS      float64 `json:"https"`
+}
+
+// Input is a live proxy with the data that is not part of a single check result
+type Input struct {
+	Result checker.ProxyResult
+
+	// Stability is the fraction of recent checks the proxy was live (0-1, or Unknown)
+	Stability float64
+
+	// Reputation is 1 for a clean address and 0 for a listed one (0-1, or Unknown)
+	Reputation float64
+}
+
+// Score returns the quality score of a live proxy from 0 to 100
+// Proxies that are neither live nor slow score 0
+func Score(in Input, w Weights) float64 {
+	r := in.Result
+	if !strings.EqualFold(string(r.Status), string(checker.StatusLive)) &&
+		!strings.EqualFold(string(r.Status), string(checker.StatusSlow)) {
+		return 0
+	}
+
+	latency := 1 - float64(r.Latency)/maxLatency
+	if latency < 0 {
+		latency = 0
+	}
+
+	anonymity := 0.0
+	if r.Anonymous {
+		anonymity = 1
+	}
+
+	https := 0.0
+	if r.SupportsHTTPS || r.Type == checker.HTTPS || r.Type == checker.SOCKS4 || r.Type == checker.SOCKS5 {
+		https = 1
+	}
+
+	components := []struct{ value, weight float64 }{
+		{latency, w.Latency},
+		{anonymity, w.Anonymity},
+		{in.Stability, w.Stability},
+		{in.Reputation, w.Reputation},
+		{https, w.HTTPS},
+	}
+
+	var total, weights float64
+	for _, c := range components {
+		if c.value == Unknown || c.weight <= 0 {
+			continue
+		}
+		total += c.value * c.weight
+		weights += c.weight
+	}
+
+	if weights == 0 {
+		return 0
+	}
+	return total / weights * 100
+}
+
+// Ranked is a proxy result with its score
+type Ranked struct {
+	checker.ProxyResult
+	Score float64 `json:"score"`
+}
+
+// Rank scores the inputs and returns them sorted by score, best first
+func Rank(inputs []Input, w Weights) []Ranked {
+	ranked := make([]Ranked, len(inputs))
+	for i, in := range inputs {
+		ranked[i] = Ranked{ProxyResult: in.Result, Score: Score(in, w)}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	return ranked
+}
+
+// Top returns the n best ranked results (all if n <= 0)
+func Top(ranked []Ranked, n int) []checker.ProxyResult {
+	if n <= 0 || n > len(ranked) {
+		n = len(ranked)
+	}
+
+	top := make([]checker.ProxyResult, n)
+	for i := range top {
+		top[i] = ranked[i].ProxyResult
+	}
+	return top
+}
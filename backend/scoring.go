@@ -0,0 +1,85 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/score"
+)
+
+// GetTopByScore returns the n best live proxies by quality score (all if n <= 0)
+func (a *App) GetTopByScore(n int) []score.Ranked {
+	ranked := a.rankLive()
+	if n > 0 && n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// ExportTopByScore writes the n best live proxies by score to the export directory
+// and returns the file path
+func (a *App) ExportTopByScore(n int, format string) (string, error) {
+	top := score.Top(a.rankLive(), n)
+
+	data, err := a.formatter().Format(top, format)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("top%d_%s%s", len(top), time.Now().Format("20060102_150405"), export.Extension(format))
+	path := filepath.Join(a.config.ExportDir(), name)
+	if err := export.WriteFile(path, data); err != nil {
+		return "", err
+	}
+
+	a.emit("log", fmt.Sprintf("Exported top %d proxies by score to %s", len(top), path))
+	return path, nil
+}
+
+// rankLive scores the live proxies of the current results, best first
+func (a *App) rankLive() []score.Ranked {
+	live := a.exportable(a.liveResults(a.manager.GetResults()))
+
+	inputs := make([]score.Input, len(live))
+	for i, r := range live {
+		inputs[i] = a.scoreInput(r)
+	}
+	return score.Rank(inputs, a.scoreWeights())
+}
+
+// scoreOf returns the quality score of a result
+func (a *App) scoreOf(r checker.ProxyResult, w score.Weights) float64 {
+	return score.Score(a.scoreInput(r), w)
+}
+
+// scoreInput collects the scoring data of a result
+func (a *App) scoreInput(r checker.ProxyResult) score.Input {
+	return score.Input{
+		Result:     r,
+		Stability:  a.stability.stability(r.Proxy),
+		Reputation: score.Unknown,
+	}
+}
+
+// scoreWeights returns the configured score weights
+func (a *App) scoreWeights() score.Weights {
+	cfg := a.config.GetConfig()
+	return score.Weights{
+		Latency:    cfg.ScoreWeightLatency,
+		Anonymity:  cfg.ScoreWeightAnonymity,
+		Stability:  cfg.ScoreWeightStability,
+		Reputation: cfg.ScoreWeightReputation,
+		HTTPS:      cfg.ScoreWeightHTTPS,
+	}
+}
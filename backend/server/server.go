@@ -0,0 +1,200 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+var (
+	ErrAlreadyRunning = errors.New("server already running")
+	ErrNotRunning     = errors.New("server not running")
+)
+
+// LiveSource returns the current live proxies
+type LiveSource func() []checker.ProxyResult
+
+// Server is a small local HTTP server exposing the checker's live proxy list
+type Server struct {
+	mutex    sync.Mutex
+	mux      *http.ServeMux
+	srv      *http.Server
+	listener net.Listener
+}
+
+// New creates a server serving the proxies returned by live
+func New(live LiveSource) *Server {
+	s := &Server{mux: http.NewServeMux()}
+
+	s.HandleList("live", live)
+
+	return s
+}
+
+// HandleList serves the proxies returned by source as /name.txt and /name.json
+func (s *Server) HandleList(name string, source LiveSource) {
+	s.mux.HandleFunc("/"+name+".txt", func(w http.ResponseWriter, r *http.Request) {
+		s.serveList(w, r, source, export.FormatPlain)
+	})
+	s.mux.HandleFunc("/"+name+".json", func(w http.ResponseWriter, r *http.Request) {
+		s.serveList(w, r, source, export.FormatJSON)
+	})
+}
+
+// Handle registers an additional handler on the server
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start starts listening on addr (e.g. 127.0.0.1:8765)
+func (s *Server) Start(addr string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.srv != nil {
+		return ErrAlreadyRunning
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s.listener = listener
+	s.srv = &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go s.srv.Serve(listener)
+	return nil
+}
+
+// Stop shuts the server down
+func (s *Server) Stop() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.srv == nil {
+		return ErrNotRunning
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := s.srv.Shutdown(ctx)
+	s.srv = nil
+	s.listener = nil
+	return err
+}
+
+// Addr returns the address the server is listening on, or an empty string if stopped
+func (s *Server) Addr() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// serveList writes the filtered proxies of source in the given format
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, source LiveSource, format string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := ParseFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := export.Format(filter.Apply(source()), format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(data)
+}
+
+// ParseFilter builds an export filter from the type, country, region, timezone, status,
+// anonymous, ip_version and max_latency query parameters
+// Multiple values may be given comma-separated or by repeating the parameter
+func ParseFilter(r *http.Request) (*export.Filter, error) {
+	query := r.URL.Query()
+	filter := &export.Filter{}
+
+	for _, t := range splitValues(query["type"]) {
+		filter.Types = append(filter.Types, checker.ProxyType(strings.ToLower(t)))
+	}
+
+	filter.Countries = splitValues(query["country"])
+	filter.Regions = splitValues(query["region"])
+	filter.Timezones = splitValues(query["timezone"])
+
+	for _, s := range splitValues(query["status"]) {
+		filter.Statuses = append(filter.Statuses, checker.ProxyStatus(strings.ToLower(s)))
+	}
+
+	if v := query.Get("anonymous"); v != "" {
+		anonymous, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anonymous: %s", v)
+		}
+		filter.AnonymousOnly = anonymous
+	}
+
+	if v := query.Get("ip_version"); v != "" {
+		version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(v), "ipv"))
+		if err != nil || (version != 4 && version != 6) {
+			return nil, fmt.Errorf("invalid ip_version: %s", v)
+		}
+		filter.IPVersion = version
+	}
+
+	if v := query.Get("max_latency"); v != "" {
+		maxLatency, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || maxLatency < 0 {
+			return nil, fmt.Errorf("invalid max_latency: %s", v)
+		}
+		filter.MaxLatency = maxLatency
+	}
+
+	return filter, nil
+}
+
+// splitValues flattens repeated and comma-separated query values
+func splitValues(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
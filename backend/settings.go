@@ -0,0 +1,105 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// settingsFormat identifies settings bundles
+const settingsFormat = "soxychecker-settings"
+
+// ErrNotSettingsBundle is returned when importing a file that is not a settings bundle
+var ErrNotSettingsBundle = errors.New("not a settings bundle")
+
+// SettingsBundle is the portable form of the settings, for moving them between machines
+type SettingsBundle struct {
+	Format        string          `json:"format"`
+	SchemaVersion int             `json:"schemaVersion"`
+	ExportedAt    time.Time       `json:"exportedAt"`
+	WithSecrets   bool            `json:"withSecrets"`
+	Config        json.RawMessage `json:"config"`
+}
+
+// ExportSettings writes the settings as a JSON bundle to the export directory and returns
+// the file path. API keys, tokens and proxy credentials are left out unless withSecrets is set
+func (a *App) ExportSettings(withSecrets bool) (string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return "", err
+	}
+	cfg := a.config.GetConfig()
+	if !withSecrets {
+		cfg = cfg.WithoutSecrets()
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(SettingsBundle{
+		Format:        settingsFormat,
+		SchemaVersion: config.SchemaVersion,
+		ExportedAt:    time.Now(),
+		WithSecrets:   withSecrets,
+		Config:        raw,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(a.config.ExportDir(), "settings_"+time.Now().Format("20060102_150405")+".json")
+	if err := export.WriteFile(path, data); err != nil {
+		return "", err
+	}
+
+	a.emit("log", "Settings exported to "+path)
+	return path, nil
+}
+
+// ImportSettings replaces the settings with those of a bundle, migrating bundles written
+// by older versions. The local secrets are kept when the bundle has none
+func (a *App) ImportSettings(path string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	var bundle SettingsBundle
+	if err := json.Unmarshal(data, &bundle); err != nil || bundle.Format != settingsFormat {
+		return ErrNotSettingsBundle
+	}
+
+	imported, _, err := config.Parse(bundle.Config)
+	if err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
+	if err := a.config.UpdateConfig(func(c *config.Config) {
+		if !bundle.WithSecrets {
+			imported.KeepSecrets(*c)
+		}
+		*c = *imported
+	}); err != nil {
+		return err
+	}
+
+	a.emit("log", "Settings imported from "+path)
+	return nil
+}
@@ -0,0 +1,499 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+const (
+	// shardCheckpointFile is the name of the checkpoint file in a sharded run directory
+	shardCheckpointFile = "checkpoint.json"
+
+	// shardListFile is the name of the file a sharded run given as a list is written to,
+	// so that it can be read back one shard at a time
+	shardListFile = "proxies.txt"
+)
+
+var ErrShardedRunActive = errors.New("a sharded run is in progress")
+
+// ShardedRunParams represents the parameters of a sharded run
+// The proxies are read from SourcePath, one per line, if set, and otherwise taken from
+// CheckParams.ProxyList
+type ShardedRunParams struct {
+	CheckParams CheckParams `json:"CheckParams"`
+	ShardSize   int         `json:"ShardSize"`
+	SourcePath  string      `json:"SourcePath,omitempty"`
+}
+
+// ShardCheckpoint records the progress of a sharded run
+// The proxies stay in Source; Offsets are the byte offsets of the first line of each shard
+type ShardCheckpoint struct {
+	ID        string           `json:"id"`
+	Params    ShardedRunParams `json:"params"`
+	Source    string           `json:"source"`
+	Offsets   []int64          `json:"offsets"`
+	Total     int              `json:"total"`
+	Shards    int              `json:"shards"`
+	Completed []int            `json:"completed"`
+	Live      int              `json:"live"`
+	Created   time.Time        `json:"created"`
+	Updated   time.Time        `json:"updated"`
+}
+
+// ShardedRunStatus represents the state of the active sharded run
+type ShardedRunStatus struct {
+	Running   bool   `json:"running"`
+	ID        string `json:"id"`
+	Current   int    `json:"current"`
+	Shards    int    `json:"shards"`
+	Completed int    `json:"completed"`
+	Live      int    `json:"live"`
+}
+
+// shardedRun is the state of the active sharded run
+type shardedRun struct {
+	checkpoint *ShardCheckpoint
+	current    int
+	stop       chan struct{}
+	stopping   bool
+	// finished is closed once the shard loop has returned
+	finished chan struct{}
+}
+
+// StartShardedRun splits a huge list into shards of ShardSize proxies and checks them as
+// sequential sub-runs. Only the current shard is held in memory: the list stays in its
+// source file, or is written to the run directory once, and each shard is read back from
+// it when its turn comes. Every finished shard is exported to its own file and recorded
+// in a checkpoint so the run can be resumed
+func (a *App) StartShardedRun(params ShardedRunParams) string {
+	if a.closing.Load() {
+		return "Check refused: application is shutting down"
+	}
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	if params.ShardSize <= 0 {
+		return "Shard size must be positive"
+	}
+	if len(params.CheckParams.Countries) > 0 && a.geoDB() == nil {
+		return "Check refused: country filter: " + ErrNoGeoIPDatabase.Error()
+	}
+	// Report invalid parameters before anything is written; the proxies are only read
+	// shard by shard, so the list itself is not checked here
+	a.clampThreads(&params.CheckParams)
+	if err := a.buildCheckRequest(params.CheckParams).ValidateStream(); err != nil {
+		a.emit("log", err.Error())
+		return "Check refused: " + err.Error()
+	}
+
+	now := time.Now()
+	cp := &ShardCheckpoint{
+		ID:      "shards_" + now.Format("20060102_150405"),
+		Source:  params.SourcePath,
+		Created: now,
+		Updated: now,
+	}
+	if cp.Source == "" {
+		if len(params.CheckParams.ProxyList) == 0 {
+			return "No proxies to check"
+		}
+		cp.Source = filepath.Join(a.config.ExportDir(), cp.ID, shardListFile)
+		if err := writeShardList(cp.Source, params.CheckParams.ProxyList); err != nil {
+			return err.Error()
+		}
+	}
+	params.CheckParams.ProxyList = nil
+	cp.Params = params
+
+	if err := cp.index(); err != nil {
+		return err.Error()
+	}
+	if cp.Total == 0 {
+		return "No proxies to check"
+	}
+
+	if err := a.saveShardCheckpoint(cp); err != nil {
+		return err.Error()
+	}
+
+	return a.runShards(cp)
+}
+
+// writeShardList writes the proxies of a sharded run to path, one per line
+func writeShardList(path string, proxies []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create sharded run directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write proxy list: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	for _, proxy := range proxies {
+		w.WriteString(proxy)
+		w.WriteByte('\n')
+	}
+	err = w.Flush()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write proxy list: %w", err)
+	}
+	return nil
+}
+
+// shardLine returns the proxy on a line of a shard source, or "" for blank and comment lines
+func shardLine(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	return line
+}
+
+// index counts the proxies of the source and records the offset of each shard
+func (cp *ShardCheckpoint) index() error {
+	file, err := os.Open(cp.Source)
+	if err != nil {
+		return fmt.Errorf("failed to read proxy list: %w", err)
+	}
+	defer file.Close()
+
+	cp.Offsets, cp.Total = nil, 0
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		line, err := reader.ReadString('\n')
+		if shardLine(line) != "" {
+			if cp.Total%cp.Params.ShardSize == 0 {
+				cp.Offsets = append(cp.Offsets, offset)
+			}
+			cp.Total++
+		}
+		offset += int64(len(line))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read proxy list: %w", err)
+		}
+	}
+	cp.Shards = len(cp.Offsets)
+	return nil
+}
+
+// readShard reads the proxies of shard i from the source
+func (cp *ShardCheckpoint) readShard(i int) ([]string, error) {
+	file, err := os.Open(cp.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proxy list: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Seek(cp.Offsets[i], io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read proxy list: %w", err)
+	}
+
+	proxies := make([]string, 0, cp.Params.ShardSize)
+	scanner := bufio.NewScanner(file)
+	for len(proxies) < cp.Params.ShardSize && scanner.Scan() {
+		if proxy := shardLine(scanner.Text()); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read proxy list: %w", err)
+	}
+	return proxies, nil
+}
+
+// ResumeShardedRun continues a stopped sharded run from its checkpoint
+func (a *App) ResumeShardedRun(id string) string {
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	cp, err := a.loadShardCheckpoint(id)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(cp.Completed) >= cp.Shards {
+		return "Sharded run already complete"
+	}
+
+	return a.runShards(cp)
+}
+
+// StopShardedRun stops the sharded run; the current shard's partial results are
+// exported separately and the shard is checked again on resume
+func (a *App) StopShardedRun() string {
+	a.shardMux.Lock()
+	defer a.shardMux.Unlock()
+
+	if a.sharded == nil || a.sharded.stopping {
+		return "No sharded run in progress"
+	}
+
+	// The run stays registered until the partial shard is exported, then the queued
+	// checks go on
+	a.sharded.stopping = true
+	close(a.sharded.stop)
+	a.manager.Stop(false)
+	return "Sharded run stopping"
+}
+
+// shardedActive reports whether a sharded run is in progress; other checks must wait
+// for it, as every shard is a run of the main manager
+func (a *App) shardedActive() bool {
+	a.shardMux.Lock()
+	defer a.shardMux.Unlock()
+	return a.sharded != nil
+}
+
+// GetShardedRunStatus returns the progress of the active sharded run
+func (a *App) GetShardedRunStatus() ShardedRunStatus {
+	a.shardMux.Lock()
+	defer a.shardMux.Unlock()
+
+	if a.sharded == nil {
+		return ShardedRunStatus{}
+	}
+
+	cp := a.sharded.checkpoint
+	return ShardedRunStatus{
+		Running:   true,
+		ID:        cp.ID,
+		Current:   a.sharded.current + 1,
+		Shards:    cp.Shards,
+		Completed: len(cp.Completed),
+		Live:      cp.Live,
+	}
+}
+
+// ListShardedRuns returns the checkpoints of previous sharded runs, newest first
+func (a *App) ListShardedRuns() []ShardCheckpoint {
+	dirs, err := filepath.Glob(filepath.Join(a.config.ExportDir(), "shards_*", shardCheckpointFile))
+	if err != nil {
+		return nil
+	}
+
+	var runs []ShardCheckpoint
+	for _, path := range dirs {
+		cp, err := a.loadShardCheckpoint(filepath.Base(filepath.Dir(path)))
+		if err != nil {
+			continue
+		}
+		runs = append(runs, *cp)
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Created.After(runs[j].Created) })
+	return runs
+}
+
+// runShards starts the shard loop for a checkpoint
+func (a *App) runShards(cp *ShardCheckpoint) string {
+	if a.manager.IsRunning() {
+		return "Check already in progress"
+	}
+
+	a.shardMux.Lock()
+	if a.sharded != nil {
+		a.shardMux.Unlock()
+		return "Sharded run already in progress"
+	}
+	run := &shardedRun{checkpoint: cp, stop: make(chan struct{}), finished: make(chan struct{})}
+	a.sharded = run
+	a.shardMux.Unlock()
+
+	a.emit("log", fmt.Sprintf("Sharded run %s: %d proxies in %d shards of %d",
+		cp.ID, cp.Total, cp.Shards, cp.Params.ShardSize))
+
+	go a.shardLoop(run)
+	return "Sharded run started"
+}
+
+// shardLoop checks the remaining shards one after another
+func (a *App) shardLoop(run *shardedRun) {
+	defer func() {
+		a.shardMux.Lock()
+		if a.sharded == run {
+			a.sharded = nil
+		}
+		a.shardMux.Unlock()
+		close(run.finished)
+		a.startNextQueued()
+	}()
+
+	cp := run.checkpoint
+	done := make(map[int]bool, len(cp.Completed))
+	for _, i := range cp.Completed {
+		done[i] = true
+	}
+
+	for i := 0; i < cp.Shards; i++ {
+		if done[i] {
+			continue
+		}
+
+		// A stop between shards ends the run before the next one is read
+		select {
+		case <-run.stop:
+			a.emit("log", fmt.Sprintf("Sharded run %s stopped before shard %d of %d", cp.ID, i+1, cp.Shards))
+			a.emit("sharded-run-status", "stopped")
+			return
+		default:
+		}
+
+		a.shardMux.Lock()
+		run.current = i
+		a.shardMux.Unlock()
+
+		// Drop a stale completion signal from an earlier run
+		select {
+		case <-a.runDone:
+		default:
+		}
+
+		params := cp.Params.CheckParams
+		proxies, err := cp.readShard(i)
+		if err == nil {
+			params.ProxyList = proxies
+			err = a.filterInput(&params)
+		}
+		if err != nil {
+			a.emit("log", fmt.Sprintf("Sharded run %s stopped at shard %d of %d: %v", cp.ID, i+1, cp.Shards, err))
+			a.emit("sharded-run-status", "stopped")
+			return
+		}
+
+		a.emit("log", fmt.Sprintf("Starting shard %d of %d", i+1, cp.Shards))
+		if outcome := a.startCheck(params); outcome != checkStarted {
+			a.emit("log", fmt.Sprintf("Sharded run %s stopped, shard %d of %d could not start: %s", cp.ID, i+1, cp.Shards, outcome))
+			a.emit("sharded-run-status", "stopped")
+			return
+		}
+
+		select {
+		case <-a.runDone:
+		case <-run.stop:
+			// The stop may have come in before the shard started
+			a.manager.Stop(false)
+			<-a.runDone
+			a.exportShard(cp, i, true)
+			a.emit("log", fmt.Sprintf("Sharded run %s stopped during shard %d of %d", cp.ID, i+1, cp.Shards))
+			a.emit("sharded-run-status", "stopped")
+			return
+		}
+
+		live := a.exportShard(cp, i, false)
+		cp.Completed = append(cp.Completed, i)
+		cp.Live += live
+		if err := a.saveShardCheckpoint(cp); err != nil {
+			a.emit("log", err.Error())
+		}
+		a.emit("sharded-run-status", a.GetShardedRunStatus())
+
+		// Only keep the current shard in memory
+		a.resetResults()
+	}
+
+	a.emit("log", fmt.Sprintf("Sharded run %s complete: %d live proxies", cp.ID, cp.Live))
+	a.emit("sharded-run-status", "complete")
+}
+
+// resetResults drops the results of the finished main run, without recording a user action
+func (a *App) resetResults() {
+	a.resultsMux.Lock()
+	a.results = []ProxyResult{}
+	a.resultsMux.Unlock()
+	a.manager.ClearResults()
+	a.emit("results-update", []ProxyResult{})
+}
+
+// exportShard writes the live proxies of a shard to its own file and returns their number
+func (a *App) exportShard(cp *ShardCheckpoint, index int, partial bool) int {
+	live := a.exportable(a.liveResults(a.manager.GetResults()))
+
+	name := fmt.Sprintf("shard_%04d", index+1)
+	if partial {
+		name += "_partial"
+	}
+	path := filepath.Join(a.config.ExportDir(), cp.ID, name+".txt")
+
+	data, _ := export.Format(live, export.FormatPlain)
+	if err := export.WriteFile(path, data); err != nil {
+		a.emit("log", fmt.Sprintf("Failed to export shard %d: %v", index+1, err))
+		return len(live)
+	}
+
+	a.emit("log", fmt.Sprintf("Shard %d: %d live proxies exported to %s", index+1, len(live), path))
+	return len(live)
+}
+
+// saveShardCheckpoint writes the checkpoint of a sharded run
+func (a *App) saveShardCheckpoint(cp *ShardCheckpoint) error {
+	cp.Updated = time.Now()
+
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	path := filepath.Join(a.config.ExportDir(), cp.ID, shardCheckpointFile)
+	if err := export.WriteFile(path, data); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// loadShardCheckpoint reads the checkpoint of sharded run id
+func (a *App) loadShardCheckpoint(id string) (*ShardCheckpoint, error) {
+	if !strings.HasPrefix(id, "shards_") || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid sharded run: %s", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(a.config.ExportDir(), id, shardCheckpointFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var cp ShardCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint: %w", err)
+	}
+
+	// Checkpoints of older versions hold the whole list; move it out to the list file
+	if cp.Source == "" && len(cp.Params.CheckParams.ProxyList) > 0 {
+		cp.Source = filepath.Join(a.config.ExportDir(), id, shardListFile)
+		if err := writeShardList(cp.Source, cp.Params.CheckParams.ProxyList); err != nil {
+			return nil, err
+		}
+		cp.Params.CheckParams.ProxyList = nil
+		if err := cp.index(); err != nil {
+			return nil, err
+		}
+		if err := a.saveShardCheckpoint(&cp); err != nil {
+			return nil, err
+		}
+	}
+	return &cp, nil
+}
@@ -0,0 +1,244 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/control"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/server"
+)
+
+// sessionFile is the checkpoint of a main run interrupted by quitting, in the data directory
+const sessionFile = "session.json"
+
+// shutdownTimeout bounds how long quitting waits for running checks to wind down
+const shutdownTimeout = 5 * time.Second
+
+// SessionCheckpoint records a main run that was interrupted by quitting the app
+type SessionCheckpoint struct {
+	Saved time.Time `json:"saved"`
+	// Params are the run parameters; ProxyList holds only the proxies left unchecked
+	Params  CheckParams `json:"params"`
+	Checked int         `json:"checked"`
+	// Live are the live results found before the app was closed
+	Live []checker.ProxyResult `json:"live"`
+}
+
+// BeforeClose is called when the user quits the app (Wails OnBeforeClose)
+// Running checks are stopped and all state is flushed to disk; closing is never prevented
+func (a *App) BeforeClose(ctx context.Context) bool {
+	a.shutdown()
+	return false
+}
+
+// Shutdown is called when the app is terminating (Wails OnShutdown)
+func (a *App) Shutdown(ctx context.Context) {
+	a.shutdown()
+}
+
+// shutdown stops all activity and persists state; only the first call does anything
+func (a *App) shutdown() {
+	a.closeOnce.Do(func() {
+		a.closing.Store(true)
+
+		interrupted := a.manager.IsRunning()
+
+		a.StopMonitoring()
+		a.coordinator.Cancel()
+		if a.geoUpdateStop != nil {
+			close(a.geoUpdateStop)
+		}
+		if a.schedulerStop != nil {
+			close(a.schedulerStop)
+		}
+
+		// A sharded run keeps its own checkpoint and exports the partial shard when stopped
+		a.shardMux.Lock()
+		sharded := a.sharded
+		a.shardMux.Unlock()
+		if sharded != nil {
+			a.StopShardedRun()
+			interrupted = false
+		}
+
+		a.manager.Stop(true)
+		a.runsMux.Lock()
+		for _, run := range a.runs {
+			run.manager.Stop(true)
+		}
+		a.runsMux.Unlock()
+
+		a.waitStopped(sharded)
+
+		if interrupted {
+			if err := a.saveSession(); err != nil {
+				log.Printf("Failed to save session checkpoint: %v", err)
+			}
+			a.saveRunLog()
+		}
+		if err := a.autoSave(); err != nil {
+			log.Printf("Failed to auto-save results: %v", err)
+		}
+
+		if err := a.liveServer.Stop(); err != nil && !errors.Is(err, server.ErrNotRunning) {
+			log.Printf("Failed to stop live list server: %v", err)
+		}
+		if err := a.controlAPI.Stop(); err != nil && !errors.Is(err, control.ErrNotRunning) {
+			log.Printf("Failed to stop control API: %v", err)
+		}
+
+		a.debug.stop()
+		a.stopRoundTripCallback()
+		a.saveRDAPCache()
+		a.saveTypeCache()
+		a.vault.Lock()
+
+		if err := a.config.Save(); err != nil {
+			log.Printf("Failed to save config: %v", err)
+		}
+
+		// Release the GeoIP database
+		a.geoMux.Lock()
+		a.geo = nil
+		a.geoMux.Unlock()
+	})
+}
+
+// waitStopped waits until every run has stopped, or shutdownTimeout has passed
+func (a *App) waitStopped(sharded *shardedRun) {
+	deadline := time.Now().Add(shutdownTimeout)
+
+	if sharded != nil {
+		select {
+		case <-sharded.finished:
+		case <-time.After(shutdownTimeout):
+		}
+	}
+
+	for time.Now().Before(deadline) {
+		running := a.manager.IsRunning()
+		a.runsMux.Lock()
+		for _, run := range a.runs {
+			running = running || run.manager.IsRunning()
+		}
+		a.runsMux.Unlock()
+
+		if !running {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// autoSave writes the live results to the export directory if auto-save is enabled
+func (a *App) autoSave() error {
+	cfg := a.config.GetConfig()
+	if !cfg.AutoSaveResults {
+		return nil
+	}
+
+	live := a.exportable(a.liveResults(a.manager.GetResults()))
+	if len(live) == 0 {
+		return nil
+	}
+
+	_, err := a.saveExport("autosave_"+time.Now().Format("20060102_150405"), live, cfg.ExportFormat)
+	return err
+}
+
+// saveSession writes the checkpoint of the interrupted main run
+func (a *App) saveSession() error {
+	results := a.manager.GetResults()
+
+	// Aborted checks are checked again on resume
+	checked := make(map[string]bool, len(results))
+	done := 0
+	for _, r := range results {
+		if !strings.EqualFold(string(r.Status), string(checker.StatusAborted)) {
+			checked[r.Proxy] = true
+			done++
+		}
+	}
+
+	a.resultsMux.Lock()
+	params := a.lastParams
+	a.resultsMux.Unlock()
+
+	remaining := make([]string, 0, len(params.ProxyList))
+	for _, proxy := range params.ProxyList {
+		if !checked[proxy] {
+			remaining = append(remaining, proxy)
+		}
+	}
+	params.ProxyList = remaining
+
+	data, err := json.Marshal(SessionCheckpoint{
+		Saved:   time.Now(),
+		Params:  params,
+		Checked: done,
+		Live:    a.liveResults(results),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	return export.WriteFile(filepath.Join(a.config.DataDir(), sessionFile), data)
+}
+
+// GetSessionCheckpoint returns the run interrupted by the last quit, or nil if there is none
+func (a *App) GetSessionCheckpoint() (*SessionCheckpoint, error) {
+	data, err := os.ReadFile(filepath.Join(a.config.DataDir(), sessionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var session SessionCheckpoint
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("invalid session checkpoint: %w", err)
+	}
+	return &session, nil
+}
+
+// ResumeSession checks the proxies left unchecked by the interrupted run and discards the checkpoint
+func (a *App) ResumeSession() string {
+	session, err := a.GetSessionCheckpoint()
+	if err != nil {
+		return err.Error()
+	}
+	if session == nil || len(session.Params.ProxyList) == 0 {
+		return "No interrupted session to resume"
+	}
+
+	msg := a.StartCheck(session.Params)
+	a.DiscardSession()
+	return msg
+}
+
+// DiscardSession deletes the checkpoint of the interrupted run
+func (a *App) DiscardSession() error {
+	err := os.Remove(filepath.Join(a.config.DataDir(), sessionFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
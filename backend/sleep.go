@@ -0,0 +1,111 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+)
+
+const (
+	// sleepPollInterval is how often the clocks are compared during a run
+	sleepPollInterval = 2 * time.Second
+
+	// sleepThreshold is how much longer than sleepPollInterval a tick may take before the
+	// gap is put down to a system sleep rather than scheduling delays
+	sleepThreshold = 10 * time.Second
+
+	// suspectWindow is how long before a sleep failed checks are flagged as suspect; the
+	// network usually degrades in the seconds before a laptop suspends
+	suspectWindow = 30 * time.Second
+)
+
+// watchSleep detects system sleep during the main run from gaps between ticks: the wall
+// clock keeps running during a suspend while, on most systems, the monotonic clock and
+// the ticker stop. On resume the run is paused while the network and endpoint are checked
+// again, the failures recorded around the sleep are flagged as suspect, and the run goes
+// on if everything is reachable
+func (a *App) watchSleep(generation int64, req checker.ProxyCheckRequest) {
+	if !a.config.GetConfig().SleepDetection {
+		return
+	}
+
+	ticker := time.NewTicker(sleepPollInterval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for range ticker.C {
+		if a.monitorGen.Load() != generation || !a.manager.IsRunning() {
+			return
+		}
+
+		now := time.Now()
+		monotonic := now.Sub(last)
+		wall := now.Round(0).Sub(last.Round(0))
+		sleptAt := last
+		last = now
+		if monotonic < sleepPollInterval+sleepThreshold && wall < sleepPollInterval+sleepThreshold {
+			continue
+		}
+
+		a.handleResume(req, sleptAt.Round(0), now.Round(0))
+		last = time.Now()
+	}
+}
+
+// handleResume pauses the main run after a system sleep, validates the network and the
+// endpoint, flags the failures since shortly before the sleep and resumes the run if healthy
+func (a *App) handleResume(req checker.ProxyCheckRequest, sleptAt time.Time, resumedAt time.Time) {
+	resume := event.SystemResume{SleptAt: sleptAt, ResumedAt: resumedAt}
+	if !a.manager.IsPaused() && a.manager.Pause() {
+		resume.Paused = true
+		a.setRunState(MainRunID, event.StatePaused)
+	}
+	a.emit("log", fmt.Sprintf("System woke up after sleeping for %s, checking the network before going on",
+		resumedAt.Sub(sleptAt).Round(time.Second)))
+
+	// The checks in flight during the sleep finish while the network is checked
+	dialer := monitorDialer(req.SourceAddress)
+	if check := diagnoseConnectivity(dialer); !check.OK {
+		resume.Error = "local network is down: " + check.Error
+	} else if ok, err := anyEndpointUp(dialer, diagnosticEndpoints(req.Endpoint, req.FallbackEndpoints)); !ok {
+		resume.Error = "endpoint " + req.Endpoint + " is down: " + err
+	} else {
+		resume.Healthy = true
+	}
+
+	resume.Suspect = a.manager.MarkSuspect(sleptAt.Add(-suspectWindow))
+	a.updateResults()
+	if resume.Suspect > 0 {
+		a.emit("log", fmt.Sprintf("%d failures around the sleep were flagged as suspect; recheck them to be sure", resume.Suspect))
+	}
+
+	switch {
+	case !resume.Healthy:
+		a.emit("log", "After the sleep the "+resume.Error+"; resume the check once it is back")
+	case resume.Paused && a.manager.Resume():
+		resume.Resumed = true
+		a.setRunState(MainRunID, event.StateRunning)
+		a.emit("log", "Network and endpoint are reachable after the sleep, check resumed")
+	}
+	a.emitTyped(event.NameSystemResume, MainRunID, resume)
+}
+
+// RecheckSuspect queues the proxies whose results were flagged as suspect for another
+// check and returns their number; the run goes on once resumed
+func (a *App) RecheckSuspect() int {
+	n := a.manager.RequeueSuspect()
+	a.updateResults()
+	a.updateStats()
+	a.emit("log", fmt.Sprintf("%d suspect proxies will be checked again", n))
+	return n
+}
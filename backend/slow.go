@@ -0,0 +1,36 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// SetMaxLatency sets the maximum acceptable latency (ms, 0 to disable) and reclassifies
+// the current results as live or slow without rechecking them
+func (a *App) SetMaxLatency(maxLatency int) string {
+	if maxLatency < 0 {
+		return "Maximum latency cannot be negative"
+	}
+
+	err := a.config.UpdateConfig(func(cfg *config.Config) {
+		cfg.MaxAcceptableLatency = maxLatency
+	})
+	if err != nil {
+		return fmt.Sprintf("Failed to save settings: %v", err)
+	}
+
+	changed := a.manager.Reclassify(int64(maxLatency))
+	a.updateResults()
+	a.updateStats()
+
+	return fmt.Sprintf("Reclassified %d proxies", changed)
+}
@@ -0,0 +1,103 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/event"
+)
+
+const (
+	// snapshotLogs is the number of recent log lines kept for snapshots
+	snapshotLogs = 200
+
+	// snapshotResults is the number of most recent results included in a snapshot
+	snapshotResults = 500
+)
+
+// RunSnapshot is the state of the main run, for the frontend to rebuild its view after
+// a reload without replaying the events it missed
+type RunSnapshot struct {
+	// State is "running", "paused", "stopping" or "idle"
+	State string `json:"state"`
+	// Params are the parameters of the run, without the proxy list
+	Params CheckParams `json:"params"`
+	Stats  Stats       `json:"stats"`
+	// Logs are the most recent log lines, oldest first
+	Logs []Event `json:"logs"`
+	// Results are the most recent results; ResultCount is the number of results of the run
+	Results     []ProxyResult `json:"results"`
+	ResultCount int           `json:"resultCount"`
+	Queued      []QueuedCheck `json:"queued"`
+	Time        time.Time     `json:"time"`
+}
+
+// logBuffer keeps the most recent log lines
+type logBuffer struct {
+	mutex sync.Mutex
+	lines []Event
+}
+
+// add appends a line, dropping the oldest one when full
+func (b *logBuffer) add(e Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lines = append(b.lines, e)
+	if len(b.lines) > snapshotLogs {
+		b.lines = append([]Event(nil), b.lines[len(b.lines)-snapshotLogs:]...)
+	}
+}
+
+// snapshot returns a copy of the lines
+func (b *logBuffer) snapshot() []Event {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]Event(nil), b.lines...)
+}
+
+// GetRunSnapshot returns the parameters, stats, recent logs and most recent results of
+// the main run in one call
+func (a *App) GetRunSnapshot() RunSnapshot {
+	a.resultsMux.Lock()
+	params := a.lastParams
+	a.resultsMux.Unlock()
+	params.ProxyList = nil
+	params.Sources = nil
+	params.Metadata = nil
+
+	results := a.manager.GetResults()
+	count := len(results)
+	if len(results) > snapshotResults {
+		results = results[len(results)-snapshotResults:]
+	}
+
+	state := "idle"
+	switch {
+	case a.manager.IsStopping():
+		state = event.StateStopping
+	case a.manager.IsPaused():
+		state = event.StatePaused
+	case a.manager.IsRunning():
+		state = event.StateRunning
+	}
+
+	return RunSnapshot{
+		State:       state,
+		Params:      params,
+		Stats:       convertStats(a.manager.GetStats()),
+		Logs:        a.logs.snapshot(),
+		Results:     a.convertResults(results),
+		ResultCount: count,
+		Queued:      a.GetQueuedChecks(),
+		Time:        time.Now(),
+	}
+}
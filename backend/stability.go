@@ -0,0 +1,64 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/score"
+)
+
+// stabilityWindow is the number of recent runs considered for a proxy's stability
+const stabilityWindow = 10
+
+// stabilityTracker remembers the recent outcomes of every proxy across runs
+type stabilityTracker struct {
+	mutex   sync.Mutex
+	history map[string][]bool
+}
+
+// record adds the outcomes of a completed run
+func (t *stabilityTracker) record(results []checker.ProxyResult) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.history == nil {
+		t.history = make(map[string][]bool)
+	}
+
+	for _, r := range results {
+		h := append(t.history[r.Proxy], strings.EqualFold(string(r.Status), string(checker.StatusLive)))
+		if len(h) > stabilityWindow {
+			h = h[len(h)-stabilityWindow:]
+		}
+		t.history[r.Proxy] = h
+	}
+}
+
+// stability returns the fraction of recent runs a proxy was live, or score.Unknown
+// if it was seen in fewer than two runs
+func (t *stabilityTracker) stability(proxy string) float64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	h := t.history[proxy]
+	if len(h) < 2 {
+		return score.Unknown
+	}
+
+	live := 0
+	for _, ok := range h {
+		if ok {
+			live++
+		}
+	}
+	return float64(live) / float64(len(h))
+}
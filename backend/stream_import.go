@@ -0,0 +1,103 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+)
+
+// streamBatchSize is the number of lines filtered at a time during a streamed import
+const streamBatchSize = 5000
+
+// ImportFromFileStreaming checks a proxy list file while it is being read line by line,
+// so a multi-gigabyte dump never has to be loaded into the frontend or held in memory.
+// The blocklist, allowlist and country filter are applied as lines are read;
+// params.ProxyList is ignored
+func (a *App) ImportFromFileStreaming(path string, params CheckParams) (outcome string) {
+	defer func() { a.recordAudit("start-stream", fmt.Sprintf("path=%q %s", path, checkDetails(params)), outcome) }()
+
+	if a.closing.Load() {
+		return "Check refused: application is shutting down"
+	}
+	if err := a.requireLive(); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	if a.shardedActive() {
+		return "Check refused: " + ErrShardedRunActive.Error()
+	}
+	if a.manager.IsRunning() {
+		return "Check already in progress"
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "Check refused: " + err.Error()
+	}
+	if len(params.Countries) > 0 && a.geoDB() == nil {
+		return "Check refused: country filter: " + ErrNoGeoIPDatabase.Error()
+	}
+
+	params.ProxyList = nil
+	a.clampThreads(&params)
+	req := a.buildCheckRequest(params)
+	req.DefaultSource = importer.FileSource(path)
+	if err := req.ValidateStream(); err != nil {
+		a.emit("log", err.Error())
+		return "Check refused: " + err.Error()
+	}
+
+	feed := func(add func(proxy string) bool) error {
+		batch := make([]string, 0, streamBatchSize)
+
+		// flush filters the pending batch and queues what is left; false means the run was stopped
+		flush := func() (bool, error) {
+			filtered := params
+			filtered.ProxyList = batch
+			batch = make([]string, 0, streamBatchSize)
+			if err := a.filterInput(&filtered); err != nil {
+				return false, err
+			}
+			for _, proxy := range filtered.ProxyList {
+				if !add(proxy) {
+					return false, nil
+				}
+			}
+			return true, nil
+		}
+
+		var flushErr error
+		stats, err := importer.StreamFile(path, func(proxy string) bool {
+			batch = append(batch, proxy)
+			if len(batch) < streamBatchSize {
+				return true
+			}
+			more, err := flush()
+			flushErr = err
+			return more
+		})
+		if err == nil && flushErr == nil && len(batch) > 0 {
+			_, flushErr = flush()
+		}
+
+		a.emit("log", fmt.Sprintf("Streamed %d proxies from %s (%d duplicates, %d invalid lines skipped)",
+			stats.Accepted, path, stats.Duplicates, stats.Invalid))
+		if err != nil {
+			return err
+		}
+		return flushErr
+	}
+
+	start := func(req checker.ProxyCheckRequest, logCb func(string), updateCb func()) bool {
+		return a.manager.StartStream(req, feed, logCb, updateCb)
+	}
+	return a.launchCheck(params, req, start,
+		fmt.Sprintf("Streaming check of %s, type: %s, threads: %d", path, params.ProxyType, params.Threads))
+}
@@ -0,0 +1,487 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/provider"
+)
+
+const (
+	// subscriptionsFile is the file in the data directory holding the list subscriptions
+	subscriptionsFile = "subscriptions.json"
+
+	// defaultSubscriptionInterval is the refresh interval of subscriptions in minutes
+	defaultSubscriptionInterval = 60
+
+	// schedulerTick is how often the scheduler looks for subscriptions due for a refresh
+	schedulerTick = time.Minute
+)
+
+// Source kinds of a subscription
+const (
+	SourceURL      = "url"
+	SourceFile     = "file"
+	SourceProvider = "provider"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrUnknownSourceKind    = errors.New("unknown source kind")
+)
+
+// Subscription is a proxy source (remote list, local file or provider API) fetched again
+// every interval. The entries not seen in previous fetches are queued for checking, and
+// with a freshness policy the entries last checked longer ago than FreshnessMinutes are
+// queued again
+type Subscription struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// URL is the list URL, the file path or the provider name, depending on Kind
+	URL             string `json:"url"`
+	IntervalMinutes int    `json:"intervalMinutes"`
+	// FreshnessMinutes is the age after which entries are rechecked (0 never rechecks)
+	FreshnessMinutes int                 `json:"freshnessMinutes"`
+	ProxyType        string              `json:"proxyType"`
+	Validators       importer.Validators `json:"validators"`
+	LastFetch        time.Time           `json:"lastFetch"`
+	LastChange       time.Time           `json:"lastChange"`
+	LastError        string              `json:"lastError,omitempty"`
+	// LastNew is the number of new entries queued by the last changed fetch
+	LastNew int `json:"lastNew"`
+	// LastStale is the number of entries queued again by the last fetch for being stale
+	LastStale int `json:"lastStale"`
+	// Known is the number of entries seen so far
+	Known int `json:"known"`
+}
+
+// subscription is a subscription with the entries of its list and when they were last
+// queued for checking
+type subscription struct {
+	Subscription
+	Checked map[string]time.Time `json:"checked"`
+	// Seen is the set of entries saved by earlier versions, converted to Checked on load
+	Seen map[string]bool `json:"seen,omitempty"`
+}
+
+// due returns true if the subscription should be fetched again, because its interval
+// elapsed or some of its entries are stale
+func (s *subscription) due(now time.Time) bool {
+	if now.Sub(s.LastFetch) >= time.Duration(s.IntervalMinutes)*time.Minute {
+		return true
+	}
+	return len(s.stale(now)) > 0
+}
+
+// stale returns the entries last checked longer ago than the freshness policy allows
+func (s *subscription) stale(now time.Time) []string {
+	if s.FreshnessMinutes <= 0 {
+		return nil
+	}
+
+	cutoff := now.Add(-time.Duration(s.FreshnessMinutes) * time.Minute)
+	var stale []string
+	for proxy, checked := range s.Checked {
+		if checked.Before(cutoff) {
+			stale = append(stale, proxy)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// GetSubscriptions returns the list subscriptions
+func (a *App) GetSubscriptions() []Subscription {
+	a.subsMux.Lock()
+	defer a.subsMux.Unlock()
+
+	subs := make([]Subscription, len(a.subs))
+	for i, s := range a.subs {
+		subs[i] = s.Subscription
+		subs[i].Known = len(s.Checked)
+	}
+	return subs
+}
+
+// AddSubscription subscribes to a remote proxy list, refreshed every intervalMinutes
+// (60 if not set). The list is fetched and its entries checked right away
+func (a *App) AddSubscription(rawURL string, intervalMinutes int, proxyType string) (Subscription, error) {
+	return a.AddSource(SourceURL, rawURL, intervalMinutes, 0, proxyType)
+}
+
+// AddSource subscribes to a proxy source: a list URL, a local file or a provider name
+// depending on kind. The source is fetched every intervalMinutes (60 if not set), and
+// entries are rechecked once older than freshnessMinutes (never if 0)
+func (a *App) AddSource(kind string, location string, intervalMinutes int, freshnessMinutes int, proxyType string) (Subscription, error) {
+	if err := validateSource(kind, location); err != nil {
+		return Subscription{}, err
+	}
+	if intervalMinutes <= 0 {
+		intervalMinutes = defaultSubscriptionInterval
+	}
+	if freshnessMinutes < 0 {
+		freshnessMinutes = 0
+	}
+
+	sub := &subscription{
+		Subscription: Subscription{
+			ID:               fmt.Sprintf("sub-%d", time.Now().UnixNano()),
+			Kind:             kind,
+			URL:              location,
+			IntervalMinutes:  intervalMinutes,
+			FreshnessMinutes: freshnessMinutes,
+			ProxyType:        proxyType,
+		},
+		Checked: make(map[string]time.Time),
+	}
+
+	a.subsMux.Lock()
+	a.subs = append(a.subs, sub)
+	a.subsMux.Unlock()
+
+	a.emit("log", fmt.Sprintf("Subscribed to %s %s every %d minutes", kind, location, intervalMinutes))
+	go a.refreshSubscription(sub.ID)
+	return sub.Subscription, nil
+}
+
+// SetSubscriptionSchedule changes the refresh interval and the freshness policy of a
+// subscription
+func (a *App) SetSubscriptionSchedule(id string, intervalMinutes int, freshnessMinutes int) error {
+	if intervalMinutes <= 0 {
+		intervalMinutes = defaultSubscriptionInterval
+	}
+	if freshnessMinutes < 0 {
+		freshnessMinutes = 0
+	}
+
+	a.subsMux.Lock()
+	defer a.subsMux.Unlock()
+
+	for _, s := range a.subs {
+		if s.ID == id {
+			s.IntervalMinutes = intervalMinutes
+			s.FreshnessMinutes = freshnessMinutes
+			return a.saveSubscriptionsLocked()
+		}
+	}
+	return ErrSubscriptionNotFound
+}
+
+// validateSource checks the location of a source of the given kind
+func validateSource(kind string, location string) error {
+	switch kind {
+	case SourceURL:
+		u, err := url.Parse(location)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid subscription URL %q", location)
+		}
+	case SourceFile:
+		if _, err := os.Stat(location); err != nil {
+			return fmt.Errorf("invalid subscription file: %w", err)
+		}
+	case SourceProvider:
+		if _, err := provider.Get(location); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownSourceKind, kind)
+	}
+	return nil
+}
+
+// RemoveSubscription stops refreshing a subscription
+func (a *App) RemoveSubscription(id string) error {
+	a.subsMux.Lock()
+	defer a.subsMux.Unlock()
+
+	for i, s := range a.subs {
+		if s.ID == id {
+			a.subs = append(a.subs[:i], a.subs[i+1:]...)
+			return a.saveSubscriptionsLocked()
+		}
+	}
+	return ErrSubscriptionNotFound
+}
+
+// RefreshSubscription fetches a subscription now instead of waiting for its interval
+func (a *App) RefreshSubscription(id string) error {
+	if a.findSubscription(id) == nil {
+		return ErrSubscriptionNotFound
+	}
+	go a.refreshSubscription(id)
+	return nil
+}
+
+// findSubscription returns the subscription with the given ID, or nil
+func (a *App) findSubscription(id string) *subscription {
+	a.subsMux.Lock()
+	defer a.subsMux.Unlock()
+
+	for _, s := range a.subs {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// fetchSource reads the list of a source. It returns ErrNotModified if the list did not
+// change since the fetch that returned since
+func (a *App) fetchSource(kind string, location string, proxyType string, since importer.Validators) (*importer.List, importer.Validators, error) {
+	switch kind {
+	case SourceFile:
+		info, err := os.Stat(location)
+		if err != nil {
+			return nil, since, err
+		}
+		validators := importer.Validators{LastModified: info.ModTime().UTC().Format(http.TimeFormat)}
+		if validators == since {
+			return nil, since, importer.ErrNotModified
+		}
+		list, err := importer.FromFile(location)
+		return list, validators, err
+
+	case SourceProvider:
+		p, err := provider.Get(location)
+		if err != nil {
+			return nil, since, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), providerTimeout)
+		defer cancel()
+		list, err := provider.Import(ctx, provider.NewClient(30*time.Second, providerRequestInterval), p, provider.Request{
+			APIKey:   a.config.GetConfig().ProviderKeys[p.Name()],
+			Protocol: checker.ProxyType(proxyType),
+		})
+		return list, since, err
+
+	default:
+		return importer.FromURLIfModified(location, 30*time.Second, since)
+	}
+}
+
+// refreshSubscription fetches a subscription and queues for checking the entries not
+// seen before, and the entries gone stale under its freshness policy
+func (a *App) refreshSubscription(id string) {
+	a.subsMux.Lock()
+	var sub *subscription
+	for _, s := range a.subs {
+		if s.ID == id {
+			sub = s
+		}
+	}
+	if sub == nil {
+		a.subsMux.Unlock()
+		return
+	}
+	kind, location, proxyType, validators := sub.Kind, sub.URL, sub.ProxyType, sub.Validators
+	a.subsMux.Unlock()
+
+	list, validators, err := a.fetchSource(kind, location, proxyType, validators)
+
+	a.subsMux.Lock()
+	defer a.subsMux.Unlock()
+
+	now := time.Now()
+	sub.LastFetch = now
+	sub.LastError = ""
+	changed := err == nil
+	if err != nil && !errors.Is(err, importer.ErrNotModified) {
+		sub.LastError = err.Error()
+		a.emit("log", fmt.Sprintf("Subscription %s failed: %v", location, err))
+		a.saveSubscriptionsLocked()
+		return
+	}
+
+	params := CheckParams{ProxyType: sub.ProxyType, Sources: make(map[string]string)}
+	checked := sub.Checked
+	if changed {
+		// Entries no longer listed are forgotten, and checked again if they come back
+		checked = make(map[string]time.Time, len(list.Entries))
+		for _, e := range list.Entries {
+			if t, ok := sub.Checked[e.Proxy]; ok {
+				checked[e.Proxy] = t
+				continue
+			}
+			params.ProxyList = append(params.ProxyList, e.Proxy)
+			params.Sources[e.Proxy] = e.Source
+		}
+	}
+	fresh := len(params.ProxyList)
+
+	sub.Checked = checked
+	stale := sub.stale(now)
+	params.ProxyList = append(params.ProxyList, stale...)
+
+	if changed {
+		sub.Validators = validators
+		sub.LastChange = now
+		sub.LastNew = fresh
+	}
+	sub.LastStale = len(stale)
+	if len(params.ProxyList) > 0 {
+		if err := a.submitCheck(a.defaultCheckParams(params)); err != nil {
+			sub.LastError = err.Error()
+			a.emit("log", fmt.Sprintf("Subscription %s: failed to queue %d proxies: %v", location, len(params.ProxyList), err))
+			a.saveSubscriptionsLocked()
+			return
+		}
+		for _, proxy := range params.ProxyList {
+			sub.Checked[proxy] = now
+		}
+	}
+
+	if changed || len(stale) > 0 {
+		a.emit("log", fmt.Sprintf("Subscription %s: %d new and %d stale proxies queued for checking", location, fresh, len(stale)))
+	}
+	a.saveSubscriptionsLocked()
+}
+
+// schedulerLoop refreshes the subscriptions that are due every tick until stop is closed
+func (a *App) schedulerLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			a.subsMux.Lock()
+			var due []string
+			for _, s := range a.subs {
+				if s.due(now) {
+					due = append(due, s.ID)
+				}
+			}
+			a.subsMux.Unlock()
+
+			for _, id := range due {
+				a.refreshSubscription(id)
+			}
+		}
+	}
+}
+
+// defaultCheckParams fills the unset type, endpoint and upstream proxy of a background
+// check with the last used ones
+func (a *App) defaultCheckParams(params CheckParams) CheckParams {
+	cfg := a.config.GetConfig()
+	if params.ProxyType == "" {
+		params.ProxyType = string(cfg.LastProxyType)
+	}
+	if params.Endpoint == "" {
+		params.Endpoint = cfg.LastEndpoint
+	}
+	if params.UpstreamProxy == "" && cfg.LastUpstreamProxy != "" {
+		params.UpstreamProxy = cfg.LastUpstreamProxy
+		params.UpstreamType = string(cfg.LastUpstreamProxyType)
+	}
+	return params
+}
+
+// submitCheck starts a background check, queueing it behind the current run
+func (a *App) submitCheck(params CheckParams) error {
+	if a.closing.Load() {
+		return errors.New("application is shutting down")
+	}
+	if err := a.requireLive(); err != nil {
+		return err
+	}
+	if err := a.filterInput(&params); err != nil {
+		return err
+	}
+	if len(params.ProxyList) == 0 {
+		return nil
+	}
+
+	a.clampThreads(&params)
+	if err := a.buildCheckRequest(params).Validate(); err != nil {
+		return err
+	}
+
+	// A sharded run owns the main manager between shards too; the queue goes on after it
+	if a.manager.IsRunning() || a.shardedActive() {
+		position := a.enqueueCheck(params)
+		a.emit("log", fmt.Sprintf("Check queued at position %d", position))
+		return nil
+	}
+	if outcome := a.startCheck(params); outcome != checkStarted {
+		return errors.New(outcome)
+	}
+	return nil
+}
+
+// subscriptionsPath returns the file the subscriptions are saved to
+func (a *App) subscriptionsPath() string {
+	return filepath.Join(a.config.DataDir(), subscriptionsFile)
+}
+
+// saveSubscriptionsLocked writes the subscriptions to the data directory
+// (must be called with subsMux locked)
+func (a *App) saveSubscriptionsLocked() error {
+	data, err := json.Marshal(a.subs)
+	if err != nil {
+		return fmt.Errorf("failed to encode subscriptions: %w", err)
+	}
+	if err := export.WriteFile(a.subscriptionsPath(), data); err != nil {
+		log.Printf("Failed to save subscriptions: %v", err)
+		return err
+	}
+	return nil
+}
+
+// loadSubscriptions reads the saved subscriptions; a missing file leaves none
+func (a *App) loadSubscriptions() error {
+	data, err := os.ReadFile(a.subscriptionsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		a.subsMux.Lock()
+		a.subs = nil
+		a.subsMux.Unlock()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read subscriptions: %w", err)
+	}
+
+	var subs []*subscription
+	if err := json.Unmarshal(data, &subs); err != nil {
+		return fmt.Errorf("invalid subscriptions: %w", err)
+	}
+	for _, s := range subs {
+		if s.Kind == "" {
+			s.Kind = SourceURL
+		}
+		if s.Checked == nil {
+			s.Checked = make(map[string]time.Time, len(s.Seen))
+		}
+		for proxy := range s.Seen {
+			s.Checked[proxy] = s.LastChange
+		}
+		s.Seen = nil
+	}
+
+	a.subsMux.Lock()
+	a.subs = subs
+	a.subsMux.Unlock()
+	return nil
+}
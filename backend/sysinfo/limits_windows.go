@@ -0,0 +1,16 @@
+//go:build windows
+
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package sysinfo
+
+// openFileLimit reports no limit; Windows has no per-process descriptor ulimit
+func openFileLimit() (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,67 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package sysinfo derives resource limits from the host hardware.
+package sysinfo
+
+import (
+	"runtime"
+	"time"
+)
+
+const (
+	// threadsPerCPU is the number of concurrent checks per CPU core; checks mostly wait on the network
+	threadsPerCPU = 25
+
+	// reservedFiles is the number of file descriptors kept free for the app itself
+	reservedFiles = 64
+
+	// filesPerThread is the number of descriptors a check may hold (proxy and upstream connection)
+	filesPerThread = 2
+
+	// minThreads is the lowest recommended thread count
+	minThreads = 10
+)
+
+// RecommendedThreads returns a thread limit suited to the CPU count and open file limit
+func RecommendedThreads() int {
+	threads := runtime.NumCPU() * threadsPerCPU
+
+	if limit := FileThreadLimit(); limit > 0 && limit < threads {
+		threads = limit
+	}
+
+	if threads < minThreads {
+		threads = minThreads
+	}
+	return threads
+}
+
+// OpenFileLimit returns the soft limit on open file descriptors, if known
+func OpenFileLimit() (uint64, bool) {
+	return openFileLimit()
+}
+
+// ProcessCPUTime returns the CPU time the process has used so far, if known
+func ProcessCPUTime() (time.Duration, bool) {
+	return processCPUTime()
+}
+
+// FileThreadLimit returns the number of threads the open file limit allows, or 0 if unlimited or unknown
+func FileThreadLimit() int {
+	limit, ok := openFileLimit()
+	if !ok || limit <= reservedFiles {
+		return 0
+	}
+
+	threads := (limit - reservedFiles) / filesPerThread
+	if threads > uint64(int(^uint(0)>>1)) {
+		return 0
+	}
+	return int(threads)
+}
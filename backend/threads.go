@@ -0,0 +1,69 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/sysinfo"
+)
+
+// ThreadClamp is the payload of the "threads-clamped" warning event
+type ThreadClamp struct {
+	Requested int    `json:"requested"`
+	Allowed   int    `json:"allowed"`
+	Reason    string `json:"reason"`
+}
+
+// GetThreadLimit returns the highest thread count a check may use
+func (a *App) GetThreadLimit() int {
+	limit, _ := a.threadLimit()
+	return limit
+}
+
+// threadLimit returns the effective thread limit and what imposes it
+func (a *App) threadLimit() (int, string) {
+	limit := a.config.GetConfig().MaxThreads
+	reason := "configured maximum"
+	if limit <= 0 {
+		limit = sysinfo.RecommendedThreads()
+		reason = "hardware default"
+	}
+
+	if files := sysinfo.FileThreadLimit(); files > 0 && files < limit {
+		limit = files
+		reason = "open file limit"
+	}
+
+	return limit, reason
+}
+
+// clampThreads enforces the thread limit on a request, warning when it is lowered
+// A missing thread count uses the last one, within the limit
+func (a *App) clampThreads(params *CheckParams) {
+	limit, reason := a.threadLimit()
+
+	if params.Threads <= 0 {
+		params.Threads = a.config.GetConfig().LastThreadCount
+		if params.Threads <= 0 || params.Threads > limit {
+			params.Threads = limit
+		}
+		return
+	}
+
+	if params.Threads <= limit {
+		return
+	}
+
+	clamp := ThreadClamp{Requested: params.Threads, Allowed: limit, Reason: reason}
+	params.Threads = limit
+
+	a.emit("log", fmt.Sprintf("Requested %d threads exceeds the %s, using %d", clamp.Requested, clamp.Reason, clamp.Allowed))
+	a.emit("threads-clamped", clamp)
+}
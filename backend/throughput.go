@@ -0,0 +1,166 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+const (
+	// throughputFile is the file in the data directory holding the throughput history
+	throughputFile = "throughput.json"
+
+	// throughputDecayChecks is the number of checks after which the history of a thread
+	// count is halved, so recent runs weigh more than old ones
+	throughputDecayChecks = 100000
+)
+
+// throughputSample is the accumulated throughput of the runs at one thread count
+type throughputSample struct {
+	Checks  float64 `json:"checks"`
+	Seconds float64 `json:"seconds"`
+	Bytes   float64 `json:"bytes"`
+}
+
+// throughputHistory remembers the checks per second and traffic per check of past runs
+// by thread count
+type throughputHistory struct {
+	mutex     sync.Mutex
+	byThreads map[int]*throughputSample
+}
+
+// record adds a finished run
+func (h *throughputHistory) record(threads int, stats checker.Stats) {
+	checks := float64(stats.Live + stats.Slow + stats.Dead + stats.Blocked + stats.Errors)
+	seconds := stats.ElapsedTime.Seconds()
+	if threads <= 0 || checks == 0 || seconds <= 0 {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.byThreads == nil {
+		h.byThreads = make(map[int]*throughputSample)
+	}
+	s := h.byThreads[threads]
+	if s == nil {
+		s = &throughputSample{}
+		h.byThreads[threads] = s
+	}
+	s.Checks += checks
+	s.Seconds += seconds
+	s.Bytes += float64(stats.BytesTransferred)
+	for s.Checks > throughputDecayChecks {
+		s.Checks /= 2
+		s.Seconds /= 2
+		s.Bytes /= 2
+	}
+}
+
+// rate returns the historical checks per second at a thread count, scaled from the
+// nearest recorded thread count if there is no history at that one (0 without history)
+func (h *throughputHistory) rate(threads int) float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	nearest := 0
+	for t, s := range h.byThreads {
+		if s.Seconds <= 0 {
+			continue
+		}
+		if nearest == 0 || abs64(int64(t-threads)) < abs64(int64(nearest-threads)) {
+			nearest = t
+		}
+	}
+	if nearest == 0 {
+		return 0
+	}
+
+	s := h.byThreads[nearest]
+	return s.Checks / s.Seconds * float64(threads) / float64(nearest)
+}
+
+// bytesPerCheck returns the average traffic of a check over all runs (0 without history)
+func (h *throughputHistory) bytesPerCheck() float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	var checks, bytes float64
+	for _, s := range h.byThreads {
+		checks += s.Checks
+		bytes += s.Bytes
+	}
+	if checks == 0 {
+		return 0
+	}
+	return bytes / checks
+}
+
+// save writes the history to path
+func (h *throughputHistory) save(path string) error {
+	h.mutex.Lock()
+	data, err := json.Marshal(h.byThreads)
+	h.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode throughput history: %w", err)
+	}
+
+	return export.WriteFile(path, data)
+}
+
+// load reads the history from path; a missing file empties it
+func (h *throughputHistory) load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		h.mutex.Lock()
+		h.byThreads = nil
+		h.mutex.Unlock()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read throughput history: %w", err)
+	}
+
+	var byThreads map[int]*throughputSample
+	if err := json.Unmarshal(data, &byThreads); err != nil {
+		return fmt.Errorf("invalid throughput history: %w", err)
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.byThreads = byThreads
+	return nil
+}
+
+// throughputPath returns the file the throughput history is saved to
+func (a *App) throughputPath() string {
+	return filepath.Join(a.config.DataDir(), throughputFile)
+}
+
+// recordThroughput adds a finished main run to the throughput history
+func (a *App) recordThroughput(stats checker.Stats) {
+	a.resultsMux.Lock()
+	threads := a.lastParams.Threads
+	a.resultsMux.Unlock()
+
+	a.throughput.record(threads, stats)
+	if err := a.throughput.save(a.throughputPath()); err != nil {
+		log.Printf("Failed to save throughput history: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"log"
+	"path/filepath"
+	"time"
+)
+
+// typeCacheTTL is how long a type detected in Auto mode is trusted
+const typeCacheTTL = 7 * 24 * time.Hour
+
+// typeCacheFile is the file in the data directory holding the detected types
+const typeCacheFile = "type_cache.json"
+
+// saveTypeCache writes the detected types to the data directory
+func (a *App) saveTypeCache() {
+	if !a.config.GetConfig().TypeCacheEnabled {
+		return
+	}
+	if err := a.typeCache.Save(filepath.Join(a.config.DataDir(), typeCacheFile)); err != nil {
+		log.Printf("Failed to save type cache: %v", err)
+	}
+}
+
+// GetTypeCacheSize returns the number of proxies with a cached detected type
+func (a *App) GetTypeCacheSize() int {
+	return a.typeCache.Len()
+}
+
+// ClearTypeCache forgets all detected types, so the next Auto run probes every proxy again
+func (a *App) ClearTypeCache() error {
+	a.typeCache.Clear()
+	if err := a.typeCache.Save(filepath.Join(a.config.DataDir(), typeCacheFile)); err != nil {
+		return err
+	}
+
+	a.emit("log", "Type cache cleared")
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+)
+
+// PIN is the salted PBKDF2 hash of an application PIN or password
+type PIN struct {
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Hash       []byte `json:"hash"`
+}
+
+// HashPIN hashes a PIN with a new random salt
+func HashPIN(pin string) (*PIN, error) {
+	if pin == "" {
+		return nil, ErrEmptyPassword
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return &PIN{
+		Iterations: kdfIterations,
+		Salt:       salt,
+		Hash:       pbkdf2([]byte(pin), salt, kdfIterations, 32),
+	}, nil
+}
+
+// Verify returns true if pin matches the hash
+func (p *PIN) Verify(pin string) bool {
+	if p == nil || p.Iterations <= 0 || len(p.Hash) == 0 {
+		return false
+	}
+
+	hash := pbkdf2([]byte(pin), p.Salt, p.Iterations, len(p.Hash))
+	return subtle.ConstantTimeCompare(hash, p.Hash) == 1
+}
@@ -0,0 +1,288 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Package vault stores proxy credentials encrypted with a master password.
+//
+// The credentials are kept in a single file encrypted with AES-256-GCM under a key
+// derived from the master password with PBKDF2-HMAC-SHA256. The key only lives in
+// memory while the vault is unlocked.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrLocked        = errors.New("credential vault is locked")
+	ErrWrongPassword = errors.New("wrong vault password")
+	ErrEmptyPassword = errors.New("vault password is empty")
+)
+
+// kdfIterations is the PBKDF2 iteration count of new vaults
+const kdfIterations = 200000
+
+// maxKDFIterations bounds the iteration count read from a vault file, so a crafted
+// file cannot make unlocking hang
+const maxKDFIterations = 10 * kdfIterations
+
+// fileVersion is the version of the vault file format
+const fileVersion = 1
+
+// Credential is the login of an authenticated proxy
+type Credential struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// file is the on-disk form of a vault
+type file struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// Vault holds proxy credentials, keyed by proxy address (host:port)
+type Vault struct {
+	mutex       sync.Mutex
+	path        string
+	key         []byte
+	salt        []byte
+	iterations  int
+	credentials map[string]Credential
+}
+
+// New creates a locked vault stored at path
+func New(path string) *Vault {
+	return &Vault{path: path}
+}
+
+// Exists returns true if the vault file has been created
+func (v *Vault) Exists() bool {
+	_, err := os.Stat(v.path)
+	return err == nil
+}
+
+// Unlocked returns true if the vault is unlocked
+func (v *Vault) Unlocked() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.key != nil
+}
+
+// Unlock opens the vault with the master password, creating an empty vault protected
+// by password if none exists yet
+func (v *Vault) Unlock(password string) error {
+	if password == "" {
+		return ErrEmptyPassword
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	data, err := os.ReadFile(v.path)
+	if os.IsNotExist(err) {
+		v.salt = make([]byte, 16)
+		if _, err := rand.Read(v.salt); err != nil {
+			return fmt.Errorf("failed to generate salt: %w", err)
+		}
+		v.iterations = kdfIterations
+		v.key = pbkdf2([]byte(password), v.salt, v.iterations, 32)
+		v.credentials = make(map[string]Credential)
+		return v.saveLocked()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read vault: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("invalid vault file: %w", err)
+	}
+	if f.Version != fileVersion {
+		return fmt.Errorf("unsupported vault version %d", f.Version)
+	}
+	if f.Iterations < kdfIterations || f.Iterations > maxKDFIterations {
+		return fmt.Errorf("invalid vault file: %d key derivation iterations", f.Iterations)
+	}
+
+	key := pbkdf2([]byte(password), f.Salt, f.Iterations, 32)
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	if len(f.Nonce) != gcm.NonceSize() {
+		return fmt.Errorf("invalid vault file: nonce of %d bytes", len(f.Nonce))
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return ErrWrongPassword
+	}
+
+	credentials := make(map[string]Credential)
+	if err := json.Unmarshal(plain, &credentials); err != nil {
+		return fmt.Errorf("invalid vault contents: %w", err)
+	}
+
+	v.key = key
+	v.salt = f.Salt
+	v.iterations = f.Iterations
+	v.credentials = credentials
+	return nil
+}
+
+// Lock forgets the key and the decrypted credentials
+func (v *Vault) Lock() {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	for i := range v.key {
+		v.key[i] = 0
+	}
+	v.key = nil
+	v.credentials = nil
+}
+
+// Get returns the credential of a proxy
+func (v *Vault) Get(proxy string) (Credential, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	cred, ok := v.credentials[proxy]
+	return cred, ok
+}
+
+// Set stores the credentials of proxies and saves the vault
+func (v *Vault) Set(credentials map[string]Credential) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.key == nil {
+		return ErrLocked
+	}
+	for proxy, cred := range credentials {
+		v.credentials[proxy] = cred
+	}
+	return v.saveLocked()
+}
+
+// Delete removes the credentials of proxies and saves the vault
+func (v *Vault) Delete(proxies []string) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.key == nil {
+		return ErrLocked
+	}
+	for _, proxy := range proxies {
+		delete(v.credentials, proxy)
+	}
+	return v.saveLocked()
+}
+
+// Proxies returns the addresses with stored credentials, sorted
+func (v *Vault) Proxies() ([]string, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.key == nil {
+		return nil, ErrLocked
+	}
+	proxies := make([]string, 0, len(v.credentials))
+	for proxy := range v.credentials {
+		proxies = append(proxies, proxy)
+	}
+	sort.Strings(proxies)
+	return proxies, nil
+}
+
+// ChangePassword re-encrypts the vault under a new master password
+func (v *Vault) ChangePassword(password string) error {
+	if password == "" {
+		return ErrEmptyPassword
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.key == nil {
+		return ErrLocked
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	v.salt = salt
+	v.iterations = kdfIterations
+	v.key = pbkdf2([]byte(password), salt, v.iterations, 32)
+	return v.saveLocked()
+}
+
+// saveLocked encrypts and writes the vault (must be called with mutex locked)
+func (v *Vault) saveLocked() error {
+	plain, err := json.Marshal(v.credentials)
+	if err != nil {
+		return fmt.Errorf("failed to encode vault: %w", err)
+	}
+
+	gcm, err := newGCM(v.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data, err := json.Marshal(file{
+		Version:    fileVersion,
+		Iterations: v.iterations,
+		Salt:       v.salt,
+		Nonce:      nonce,
+		Data:       gcm.Seal(nil, nonce, plain, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode vault: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(v.path), 0700); err != nil {
+		return fmt.Errorf("failed to create vault directory: %w", err)
+	}
+	tmp := v.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+	if err := os.Rename(tmp, v.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace vault: %w", err)
+	}
+	return nil
+}
+
+// newGCM returns an AES-GCM cipher for key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,198 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// verifiedFile is the file in the data directory holding the verified pool
+const verifiedFile = "verified.json"
+
+// verifiedPool accumulates proxies that were live in several consecutive runs
+// A proxy joins once its streak reaches the threshold and leaves on its next failure
+type verifiedPool struct {
+	mutex   sync.Mutex
+	streaks map[string]int
+	pool    map[string]checker.ProxyResult
+}
+
+// verifiedState is the persisted form of the verified pool
+type verifiedState struct {
+	Streaks map[string]int                 `json:"streaks"`
+	Pool    map[string]checker.ProxyResult `json:"pool"`
+}
+
+// record updates the streaks with the results of a completed run and returns the
+// number of proxies that joined and left the pool
+func (p *verifiedPool) record(results []checker.ProxyResult, threshold int) (joined, left int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.streaks == nil {
+		p.streaks = make(map[string]int)
+		p.pool = make(map[string]checker.ProxyResult)
+	}
+
+	for _, r := range results {
+		switch strings.ToLower(string(r.Status)) {
+		case string(checker.StatusLive):
+			p.streaks[r.Proxy]++
+			_, member := p.pool[r.Proxy]
+			if member || p.streaks[r.Proxy] >= threshold {
+				if !member {
+					joined++
+				}
+				p.pool[r.Proxy] = r
+			}
+		case string(checker.StatusDead), string(checker.StatusError), string(checker.StatusSlow), string(checker.StatusBlocked):
+			delete(p.streaks, r.Proxy)
+			if _, member := p.pool[r.Proxy]; member {
+				delete(p.pool, r.Proxy)
+				left++
+			}
+		}
+	}
+
+	return joined, left
+}
+
+// snapshot returns the proxies of the pool, fastest first
+func (p *verifiedPool) snapshot() []checker.ProxyResult {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	results := make([]checker.ProxyResult, 0, len(p.pool))
+	for _, r := range p.pool {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Latency != results[j].Latency {
+			return results[i].Latency < results[j].Latency
+		}
+		return results[i].Proxy < results[j].Proxy
+	})
+	return results
+}
+
+// clear empties the pool and forgets all streaks
+func (p *verifiedPool) clear() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.streaks = make(map[string]int)
+	p.pool = make(map[string]checker.ProxyResult)
+}
+
+// save writes the pool to path
+func (p *verifiedPool) save(path string) error {
+	p.mutex.Lock()
+	data, err := json.Marshal(verifiedState{Streaks: p.streaks, Pool: p.pool})
+	p.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode verified pool: %w", err)
+	}
+
+	return export.WriteFile(path, data)
+}
+
+// load reads the pool from path; a missing file leaves the pool empty
+func (p *verifiedPool) load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read verified pool: %w", err)
+	}
+
+	var state verifiedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid verified pool: %w", err)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.streaks = state.Streaks
+	p.pool = state.Pool
+	if p.streaks == nil {
+		p.streaks = make(map[string]int)
+	}
+	if p.pool == nil {
+		p.pool = make(map[string]checker.ProxyResult)
+	}
+	return nil
+}
+
+// GetVerifiedPool returns the proxies that passed the configured number of consecutive runs
+func (a *App) GetVerifiedPool() []checker.ProxyResult {
+	if a.lock.isLocked() {
+		return nil
+	}
+	return a.verified.snapshot()
+}
+
+// ExportVerifiedPool writes the verified pool to the export directory and returns the written files
+func (a *App) ExportVerifiedPool(format string) ([]string, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	pool := a.verified.snapshot()
+	if len(pool) == 0 {
+		return nil, errors.New("the verified pool is empty")
+	}
+
+	paths, err := a.saveExport("verified_"+time.Now().Format("20060102_150405"), pool, format)
+	if err != nil {
+		return nil, err
+	}
+
+	a.emit("log", fmt.Sprintf("Exported %d verified proxies", len(pool)))
+	return paths, nil
+}
+
+// ClearVerifiedPool empties the verified pool
+func (a *App) ClearVerifiedPool() error {
+	a.verified.clear()
+	return a.verified.save(a.verifiedPath())
+}
+
+// updateVerifiedPool records a completed run in the verified pool
+func (a *App) updateVerifiedPool(results []checker.ProxyResult) {
+	threshold := a.config.GetConfig().VerifiedPoolThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	joined, left := a.verified.record(results, threshold)
+	if joined == 0 && left == 0 {
+		return
+	}
+
+	a.emit("log", fmt.Sprintf("Verified pool: %d joined, %d left", joined, left))
+	if err := a.verified.save(a.verifiedPath()); err != nil {
+		a.emit("log", fmt.Sprintf("Failed to save verified pool: %v", err))
+	}
+}
+
+// verifiedPath returns the path of the verified pool file
+func (a *App) verifiedPath() string {
+	return filepath.Join(a.config.DataDir(), verifiedFile)
+}
@@ -0,0 +1,191 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package backend
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/config"
+)
+
+// WipeConfirmation must be passed to WipeAllData to confirm the wipe
+const WipeConfirmation = "WIPE ALL DATA"
+
+// stamp is the timestamp in the names of the files the app writes
+const stamp = `_\d{8}_\d{6}`
+
+// exportFiles match the exact names of the files the app writes to a custom export
+// directory: a timestamp and a known extension, so files of the user are never touched
+var exportFiles = regexp.MustCompile(`^(?:` +
+	`(?:live|export|autosave|verified|top\d+)` + stamp + `(?:_\d{4})?\.(?:txt|json|jsonl)(?:\.gz)?` +
+	`|(?:live|export|autosave|verified|top\d+)` + stamp + `_index\.json` +
+	`|stats` + stamp + `\.csv` +
+	`|settings` + stamp + `\.json` +
+	`|report` + stamp + `\.(?:md|html)` +
+	`|log` + stamp + `\.txt` +
+	`|run` + stamp + regexp.QuoteMeta(bundleExt) +
+	`)$`)
+
+// shardDirs match the directories of sharded runs, and shardFiles the files written in them
+var (
+	shardDirs  = regexp.MustCompile(`^shards` + stamp + `$`)
+	shardFiles = regexp.MustCompile(`^(?:shard_\d{4}(?:_partial)?\.txt|` + regexp.QuoteMeta(shardCheckpointFile) + `|` + regexp.QuoteMeta(shardListFile) + `)$`)
+)
+
+// WipeReport describes a completed wipe
+type WipeReport struct {
+	Files  int      `json:"files"`
+	Bytes  int64    `json:"bytes"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// WipeAllData securely removes the data of every profile: configuration, credential
+// vault, caches, history, logs, audit trail and auto-saved exports. Files are overwritten
+// with random data before they are removed. On SSDs and copy-on-write or journaling
+// file systems, overwriting does not guarantee the old blocks are gone; full-disk
+// encryption is needed for that. The app returns to the default settings afterwards
+func (a *App) WipeAllData(confirmation string) (WipeReport, error) {
+	if confirmation != WipeConfirmation {
+		return WipeReport{}, fmt.Errorf("wipe not confirmed: type %q", WipeConfirmation)
+	}
+	if err := a.requireUnlocked(); err != nil {
+		return WipeReport{}, err
+	}
+	if a.checksRunning() {
+		return WipeReport{}, fmt.Errorf("cannot wipe data: %w", ErrRunActive)
+	}
+
+	a.vault.Lock()
+	a.debug.stop()
+
+	var report WipeReport
+	for _, dir := range config.AutoSavePaths() {
+		report.shredExports(dir)
+	}
+	report.shredAll(config.RootDir())
+
+	// Forget the in-memory state of the wiped data
+	a.config.Reset()
+	a.resultsMux.Lock()
+	a.previous = nil
+	a.completedLive = nil
+	a.resultsMux.Unlock()
+	a.runLogMux.Lock()
+	a.runLog = nil
+	a.runLogMux.Unlock()
+	a.loadProfileData()
+
+	// The new audit trail starts with the wipe itself
+	a.recordAudit("wipe", fmt.Sprintf("files=%d bytes=%d", report.Files, report.Bytes), "")
+	a.emit("log", fmt.Sprintf("Wiped %d files (%d bytes)", report.Files, report.Bytes))
+	a.emit("data-wiped", report)
+	if len(report.Errors) > 0 {
+		return report, fmt.Errorf("failed to wipe %d files", len(report.Errors))
+	}
+	return report, nil
+}
+
+// shredExports overwrites and removes the files the app wrote to an export directory;
+// a shard directory is removed only once nothing else is left in it
+func (r *WipeReport) shredExports(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			r.Errors = append(r.Errors, err.Error())
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.Type().IsRegular() && exportFiles.MatchString(entry.Name()):
+			r.shredFile(path)
+		case entry.IsDir() && shardDirs.MatchString(entry.Name()):
+			files, err := os.ReadDir(path)
+			if err != nil {
+				r.Errors = append(r.Errors, err.Error())
+				continue
+			}
+			for _, file := range files {
+				if file.Type().IsRegular() && shardFiles.MatchString(file.Name()) {
+					r.shredFile(filepath.Join(path, file.Name()))
+				}
+			}
+			if rest, err := os.ReadDir(path); err == nil && len(rest) == 0 {
+				os.Remove(path)
+			}
+		}
+	}
+}
+
+// shredFile overwrites and removes a single file
+func (r *WipeReport) shredFile(path string) {
+	size, err := shred(path)
+	if err != nil {
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", path, err))
+		return
+	}
+	r.Files++
+	r.Bytes += size
+}
+
+// shredAll overwrites and removes a file or directory tree
+func (r *WipeReport) shredAll(root string) {
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				r.Errors = append(r.Errors, err.Error())
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			r.shredFile(path)
+		}
+		return nil
+	})
+	if err != nil {
+		r.Errors = append(r.Errors, err.Error())
+	}
+
+	if err := os.RemoveAll(root); err != nil {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
+
+// shred overwrites a file with random data, flushes it to disk and removes it
+func shred(path string) (int64, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := file.Stat()
+	if err == nil {
+		_, err = io.CopyN(file, rand.Reader, info.Size())
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), os.Remove(path)
+}
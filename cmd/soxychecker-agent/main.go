@@ -0,0 +1,59 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Command soxychecker-agent is a headless worker that checks proxy shards
+// handed out by a SoxyChecker GUI running with the control API enabled.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/agent"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+func main() {
+	hostname, _ := os.Hostname()
+
+	coordinator := flag.String("coordinator", "http://127.0.0.1:8766", "control API URL of the SoxyChecker GUI")
+	token := flag.String("token", os.Getenv("SOXY_TOKEN"), "control API token (or SOXY_TOKEN)")
+	name := flag.String("name", hostname, "agent name shown in the GUI")
+	jsonl := flag.Bool("jsonl", false, "also stream every result to stdout as a JSON line")
+	flag.Parse()
+
+	if *token == "" {
+		log.Fatal("a control API token is required (-token or SOXY_TOKEN)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	worker := &agent.Worker{
+		Coordinator: *coordinator,
+		Token:       *token,
+		Name:        *name,
+		Log:         func(msg string) { log.Println(msg) },
+	}
+	if *jsonl {
+		out := export.NewJSONLWriter(os.Stdout)
+		worker.OnResult = func(r checker.ProxyResult) {
+			if err := out.Write(r); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	if err := worker.Run(ctx); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+// Command soxychecker-cli checks a proxy list without the GUI, streaming every result to
+// stdout as a JSON line as soon as it completes; progress messages go to stderr.
+//
+//	soxychecker-cli -list proxies.txt -type socks5 | jq -r 'select(.status == "LIVE") | .proxy'
+//
+// The exit code gates CI-style automation on the health of a pool:
+//
+//	0  the run completed and met the live thresholds
+//	1  the run could not start (bad flags, unreadable list)
+//	2  fewer proxies than -min-live or -min-live-rate were live
+//	3  the run was aborted before every proxy was checked
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/importer"
+)
+
+func main() {
+	list := flag.String("list", "-", "proxy list file, or - for stdin")
+	proxyType := flag.String("type", string(checker.HTTP), "proxy type: http, https, socks4, socks5 or auto")
+	endpoint := flag.String("endpoint", checker.DefaultEndpoint, "judge endpoint returning the outgoing IP")
+	threads := flag.Int("threads", checker.DefaultThreads, "number of concurrent checks")
+	verbose := flag.Bool("v", false, "log every check to stderr")
+	minLive := flag.Int("min-live", 0, "exit with code 2 if fewer proxies are live")
+	minLiveRate := flag.Float64("min-live-rate", 0, "exit with code 2 if a lower percentage of proxies is live")
+	summaryPath := flag.String("summary", "", "write a JSON summary of the run to this file")
+	flag.Parse()
+
+	proxies, sources, err := readList(*list)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	out := export.NewJSONLWriter(os.Stdout)
+	opts := []checker.Option{
+		checker.WithProxyType(checker.ProxyType(*proxyType)),
+		checker.WithEndpoint(*endpoint),
+		checker.WithThreads(*threads),
+		checker.WithSources(sources),
+		checker.WithResultHandler(func(r checker.ProxyResult) {
+			if err := out.Write(r); err != nil {
+				log.Println(err)
+			}
+		}),
+	}
+	if *verbose {
+		opts = append(opts, checker.WithLogger(func(msg string) { log.Println(msg) }))
+	}
+
+	log.Printf("Checking %d proxies with %d threads", len(proxies), *threads)
+	_, stats, err := checker.Check(ctx, proxies, opts...)
+	if err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+	log.Printf("Done: %d live, %d slow, %d dead, %d blocked, %d errors in %s",
+		stats.Live, stats.Slow, stats.Dead, stats.Blocked, stats.Errors, stats.ElapsedTime.Round(time.Millisecond))
+
+	summary := newSummary(stats, err != nil, Params{
+		List:        *list,
+		Type:        *proxyType,
+		Endpoint:    *endpoint,
+		Threads:     *threads,
+		MinLive:     *minLive,
+		MinLiveRate: *minLiveRate,
+	})
+	if *summaryPath != "" {
+		if err := summary.write(*summaryPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if summary.ExitCode != exitOK {
+		log.Printf("Exiting with code %d: %s", summary.ExitCode, summary.Reason)
+	}
+	os.Exit(summary.ExitCode)
+}
+
+// readList imports the proxy list at path, or from stdin if path is "-"
+func readList(path string) ([]string, map[string]string, error) {
+	var list *importer.List
+	var err error
+	if path == "-" {
+		list, err = importer.Parse(os.Stdin, "stdin")
+	} else {
+		list, err = importer.FromFile(path)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(list.Ranges) > 0 {
+		if err := list.ExpandRanges(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(list.Entries) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", importer.ErrEmptyList, path)
+	}
+	return list.Proxies(), list.Sources(), nil
+}
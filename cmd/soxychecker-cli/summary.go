@@ -0,0 +1,97 @@
+/*
+ * SoxyChecker GUI - A powerful proxy checker application
+ * Copyright (c) 2025 Rajesh Mondal (r4j3sh.com)
+ *
+ * This software is licensed under the MIT License.
+ * See the LICENSE file in the project root for full license information.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/checker"
+	"github.com/r4j3sh-com/soxyCheckerGui/backend/export"
+)
+
+// Exit codes of the command
+const (
+	exitOK          = 0
+	exitBelowTarget = 2
+	exitAborted     = 3
+)
+
+// Params are the parameters of a run, repeated in its summary
+type Params struct {
+	List        string  `json:"list"`
+	Type        string  `json:"type"`
+	Endpoint    string  `json:"endpoint"`
+	Threads     int     `json:"threads"`
+	MinLive     int     `json:"minLive,omitempty"`
+	MinLiveRate float64 `json:"minLiveRate,omitempty"`
+}
+
+// Summary is the machine-readable outcome of a run
+type Summary struct {
+	Total    int       `json:"total"`
+	Checked  int       `json:"checked"`
+	Live     int       `json:"live"`
+	Slow     int       `json:"slow"`
+	Dead     int       `json:"dead"`
+	Blocked  int       `json:"blocked"`
+	Errors   int       `json:"errors"`
+	LiveRate float64   `json:"liveRate"`
+	Start    time.Time `json:"start"`
+	Duration int64     `json:"duration"` // milliseconds
+	Aborted  bool      `json:"aborted"`
+	Params   Params    `json:"params"`
+
+	// ExitCode is the exit code of the command and Reason explains a non-zero one
+	ExitCode int    `json:"exitCode"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// newSummary builds the summary of a run and decides its exit code
+func newSummary(stats checker.Stats, aborted bool, params Params) Summary {
+	s := Summary{
+		Total:    stats.Total,
+		Checked:  stats.Live + stats.Slow + stats.Dead + stats.Blocked + stats.Errors,
+		Live:     stats.Live,
+		Slow:     stats.Slow,
+		Dead:     stats.Dead,
+		Blocked:  stats.Blocked,
+		Errors:   stats.Errors,
+		Start:    stats.StartTime,
+		Duration: stats.ElapsedTime.Milliseconds(),
+		Aborted:  aborted || stats.Aborted > 0,
+		Params:   params,
+	}
+	if s.Total > 0 {
+		s.LiveRate = float64(s.Live) / float64(s.Total) * 100
+	}
+
+	switch {
+	case s.Aborted || s.Checked < s.Total:
+		s.ExitCode = exitAborted
+		s.Reason = fmt.Sprintf("run aborted after %d of %d proxies", s.Checked, s.Total)
+	case s.Live < params.MinLive:
+		s.ExitCode = exitBelowTarget
+		s.Reason = fmt.Sprintf("%d live proxies, below the minimum of %d", s.Live, params.MinLive)
+	case s.LiveRate < params.MinLiveRate:
+		s.ExitCode = exitBelowTarget
+		s.Reason = fmt.Sprintf("%.1f%% live proxies, below the minimum of %.1f%%", s.LiveRate, params.MinLiveRate)
+	}
+	return s
+}
+
+// write saves the summary as JSON to path
+func (s Summary) write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	return export.WriteFile(path, data)
+}